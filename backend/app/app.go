@@ -8,6 +8,7 @@ import (
 	v1 "duels-api/internal/handler/v1"
 	"duels-api/internal/service"
 	"duels-api/internal/storage/cache"
+	"duels-api/internal/storage/object"
 	"duels-api/internal/storage/repository"
 	auth "duels-api/pkg/jwt"
 	"duels-api/pkg/logger"
@@ -24,6 +25,7 @@ func Build() *fx.App {
 
 		repository.Module(),
 		cache.Module(),
+		object.Module(),
 
 		client.Module(),
 
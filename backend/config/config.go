@@ -23,11 +23,12 @@ const (
 )
 
 type Config struct {
-	HTTP  HTTPConfig
-	Auth  AuthConfig
-	PG    DBConfig
-	Redis RedisConfig
-	App   AppConfig
+	HTTP    HTTPConfig
+	Auth    AuthConfig
+	PG      DBConfig
+	Redis   RedisConfig
+	Storage StorageConfig
+	App     AppConfig
 }
 
 type HTTPConfig struct {
@@ -40,9 +41,20 @@ type HTTPConfig struct {
 }
 
 type AuthConfig struct {
-	SecretSignKey   string        `env:"SECRET_SIGN_KEY,required"`
+	// KeysDir holds the jwt signing keys, see pkg/jwt/keyset.go for the layout
+	KeysDir            string        `env:"JWT_KEYS_DIR,required"`
+	KeysReloadInterval time.Duration `env:"JWT_KEYS_RELOAD_INTERVAL" envDefault:"1m"`
 	RefreshTokenTTL time.Duration `env:"REFRESH_TOKEN_TTL,required"`
 	AccessTokenTTL  time.Duration `env:"ACCESS_TOKEN_TTL,required"`
+
+	SignInDomain       string        `env:"SIGN_IN_DOMAIN" envDefault:"localhost"`
+	SignInChallengeTTL time.Duration `env:"SIGN_IN_CHALLENGE_TTL" envDefault:"5m"`
+
+	// AdminAddresses are wallets whose owners may issue api keys for any user
+	AdminAddresses         []string `env:"ADMIN_ADDRESSES" envSeparator:","`
+	APIKeyDefaultRateLimit uint32   `env:"API_KEY_DEFAULT_RATE_LIMIT" envDefault:"60"`
+	APIKeyMaxRateLimit     uint32   `env:"API_KEY_MAX_RATE_LIMIT" envDefault:"600"`
+	APIKeyMaxPerUser       int      `env:"API_KEY_MAX_PER_USER" envDefault:"10"`
 }
 
 type RedisConfig struct {
@@ -52,6 +64,24 @@ type RedisConfig struct {
 	Password string `env:"REDIS_PASSWORD,required"`
 }
 
+type StorageConfig struct {
+	// Backend is either local or s3
+	Backend  string `env:"STORAGE_BACKEND" envDefault:"local"`
+	LocalDir string `env:"STORAGE_LOCAL_DIR" envDefault:"storage"`
+	// UploadOrphanTTL is how long an upload may stay unreferenced before it is deleted
+	UploadOrphanTTL time.Duration `env:"UPLOAD_ORPHAN_TTL" envDefault:"24h"`
+
+	S3Endpoint  string `env:"S3_ENDPOINT"`
+	S3Region    string `env:"S3_REGION" envDefault:"us-east-1"`
+	S3Bucket    string `env:"S3_BUCKET"`
+	S3AccessKey string `env:"S3_ACCESS_KEY"`
+	S3SecretKey string `env:"S3_SECRET_KEY"`
+	S3UseSSL    bool   `env:"S3_USE_SSL" envDefault:"true"`
+	// S3PublicURL is the base url of a public bucket, signed urls are used if empty
+	S3PublicURL string        `env:"S3_PUBLIC_URL"`
+	S3URLTTL    time.Duration `env:"S3_URL_TTL" envDefault:"15m"`
+}
+
 type AppConfig struct {
 	Environment        string `env:"ENVIRONMENT,required"`
 	SolanaNodeURL      string `env:"SOLANA_URL,required"`
@@ -67,4 +97,13 @@ type AppConfig struct {
 	ContractAddressApi    string `env:"CONTRACT_ADDRESS_API,required"`
 
 	NotificationTtl uint32 `env:"NOTIFICATION_TTL,required"`
+
+	// LeaderboardMinDuels is how many resolved duels a player needs to be ranked by win rate
+	LeaderboardMinDuels int `env:"LEADERBOARD_MIN_DUELS" envDefault:"5"`
+
+	// EventRetention is how long user events are kept for clients resuming with last_event_id
+	EventRetention time.Duration `env:"EVENT_RETENTION" envDefault:"24h"`
+
+	// WSMaxConnectionsPerUser caps the WebSocket and SSE connections of a user per instance, 0 disables it
+	WSMaxConnectionsPerUser int `env:"WS_MAX_CONNECTIONS_PER_USER" envDefault:"5"`
 }
@@ -2,6 +2,7 @@ package client
 
 import (
 	"duels-api/internal/client/solana"
+	"duels-api/internal/client/ws"
 	"go.uber.org/fx"
 )
 
@@ -9,6 +10,7 @@ func Module() fx.Option {
 	return fx.Module("Clients",
 		fx.Provide(
 			solana.NewClient,
+			ws.NewHub,
 		),
 	)
 }
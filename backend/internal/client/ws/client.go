@@ -1,9 +1,13 @@
 package ws
 
 import (
+	"context"
+	auth "duels-api/pkg/jwt"
+	"errors"
 	"time"
 
 	"github.com/fasthttp/websocket"
+	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
@@ -13,6 +17,9 @@ const (
 	pongWait       = 60 * time.Second
 	pingPeriod     = (pongWait * 9) / 10
 	maxMessageSize = 20000
+	// closeWait is how long the peer has to answer a close frame, closing a hijacked
+	// connection does not interrupt its read until the handler returns
+	closeWait = 2 * time.Second
 )
 
 var (
@@ -23,19 +30,31 @@ type Client struct {
 	UserID uuid.UUID
 	Conn   *websocket.Conn
 	Send   chan []byte
+	// topics are owned by the hub goroutine
+	topics map[string]bool
+	// resumeFrom is the last event the client received before reconnecting
+	resumeFrom string
+	// replaying and pending are owned by the hub goroutine, live messages are
+	// held while events missed since resumeFrom are replayed
+	replaying bool
+	pending   []Message
+	// sessionID, familyID and expiresAt are owned by the hub goroutine, they are renewed by refresh messages
+	sessionID uuid.UUID
+	familyID  uuid.UUID
+	expiresAt time.Time
+	// authenticate parses tokens of refresh messages, it is nil for clients that cannot send messages
+	authenticate Authenticate
+	// closeCode and closeReason are set by the hub before Send is closed
+	closeCode   int
+	closeReason string
 }
 
-func (h *Hub) Conn(conn *websocket.Conn, userID uuid.UUID) {
-	if h == nil {
-		return
-	}
-
-	client := New(userID, conn)
-	h.register <- client
+// Serve runs the pumps of a registered client on the connection until it is closed
+func (h *Hub) Serve(client *Client, conn *websocket.Conn) {
+	client.Conn = conn
 
 	go h.writeMessengerPump(client)
-	h.readMessengerPump(client, userID)
-
+	h.readMessengerPump(client, client.UserID)
 }
 
 func New(userID uuid.UUID, conn *websocket.Conn) *Client {
@@ -43,12 +62,13 @@ func New(userID uuid.UUID, conn *websocket.Conn) *Client {
 		UserID: userID,
 		Conn:   conn,
 		Send:   make(chan []byte, 256),
+		topics: make(map[string]bool),
 	}
 }
 
 func (h *Hub) readMessengerPump(c *Client, userID uuid.UUID) {
 	defer func() {
-		h.unregister <- c
+		h.Unregister(c)
 		if err := c.Conn.Close(); err != nil {
 			zap.L().Error("failed to close conn after unregistering client", zap.Error(err))
 		}
@@ -62,7 +82,8 @@ func (h *Hub) readMessengerPump(c *Client, userID uuid.UUID) {
 		},
 	)
 	for {
-		if _, _, err := c.Conn.ReadMessage(); err != nil {
+		_, data, err := c.Conn.ReadMessage()
+		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				zap.L().Error(
 					"websocket conn unexpected close",
@@ -72,6 +93,20 @@ func (h *Hub) readMessengerPump(c *Client, userID uuid.UUID) {
 			}
 			break
 		}
+
+		var message ClientMessage
+		if err = json.Unmarshal(data, &message); err != nil {
+			// malformed frames are answered with an unknown action error
+			message = ClientMessage{}
+		}
+
+		cmd := command{client: c, message: message}
+		if message.Action == ActionAuth || message.Action == ActionRefresh {
+			// tokens are parsed here, the storage lookup must not block the hub
+			cmd.claims, cmd.err = c.parseToken(message.Token)
+		}
+
+		h.commands <- cmd
 	}
 }
 
@@ -82,6 +117,15 @@ func (h *Hub) writeMessengerPump(c *Client) {
 		_ = c.Conn.Close()
 	}()
 
+	err := h.resume(c, func(data []byte) error {
+		_ = c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
+		return c.Conn.WriteMessage(websocket.TextMessage, data)
+	})
+	if err != nil {
+		zap.L().Error("failed to replay events", zap.String("user_id", c.UserID.String()), zap.Error(err))
+		return
+	}
+
 	for {
 		select {
 		case <-ticker.C:
@@ -93,6 +137,9 @@ func (h *Hub) writeMessengerPump(c *Client) {
 		case message, ok := <-c.Send:
 			_ = c.Conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if !ok {
+				// the hub closed the client, the read pump ends once the peer answers or the deadline passes
+				_ = c.Conn.WriteMessage(websocket.CloseMessage, websocket.FormatCloseMessage(c.closeCode, c.closeReason))
+				_ = c.Conn.SetReadDeadline(time.Now().Add(closeWait))
 				return
 			}
 
@@ -115,3 +162,14 @@ func (h *Hub) writeMessengerPump(c *Client) {
 		}
 	}
 }
+
+func (c *Client) parseToken(token string) (*auth.TokenClaims, error) {
+	if c.authenticate == nil {
+		return nil, errors.New("client cannot authenticate")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), authWait)
+	defer cancel()
+
+	return c.authenticate(ctx, token)
+}
@@ -2,39 +2,70 @@ package ws
 
 import (
 	"context"
+	"duels-api/config"
 	"duels-api/internal/storage/cache"
+	"duels-api/internal/storage/repository"
+	"duels-api/pkg/apperrors"
+	auth "duels-api/pkg/jwt"
+	"expvar"
+	"time"
 
+	"github.com/fasthttp/websocket"
 	"github.com/google/uuid"
 	"go.uber.org/zap"
 )
 
-var hub *Hub
+// Gauges of the hub, served with the other expvars at /debug/vars
+var (
+	// Connections is the number of open WebSocket and SSE connections
+	Connections = expvar.NewInt("ws_connections")
+	// DroppedMessages counts messages not delivered to clients whose buffer was full
+	DroppedMessages = expvar.NewInt("ws_dropped_messages")
+	// EvictedClients counts clients disconnected as slow consumers
+	EvictedClients = expvar.NewInt("ws_evicted_clients")
+)
 
 type Hub struct {
-	clients    map[uuid.UUID]map[*Client]bool
+	clients map[uuid.UUID]map[*Client]bool
+	// draining are clients removed from delivery whose connection is not closed yet
+	draining   map[*Client]bool
 	broadcast  chan Message
-	register   chan *Client
+	register   chan registration
 	unregister chan *Client
+	// topic subscriptions of clients
+	topics         map[string]map[*Client]bool
+	topicBroadcast chan TopicMessage
+	commands       chan command
+	replayed       chan replayDone
 	// event streaming
 	eventPubSub *cache.EventPubSub
 	// per-user subscription cancelers
 	userCancels map[uuid.UUID]context.CancelFunc
-}
-
-func init() {
-	hub = &Hub{
-		broadcast:   make(chan Message),
-		register:    make(chan *Client),
-		unregister:  make(chan *Client),
-		clients:     make(map[uuid.UUID]map[*Client]bool),
-		userCancels: make(map[uuid.UUID]context.CancelFunc),
-	}
+	// per-topic subscription cancelers
+	topicCancels map[string]context.CancelFunc
+	// session checks
+	jwtStorage   *cache.JWTStorage
+	revocations  chan uuid.UUID
+	revoked      chan []session
+	stopWatching context.CancelFunc
+	// duel viewers, instanceID tells the viewers of this instance apart in the presence storage
+	presence       *cache.Presence
+	duelRepository *repository.DuelRepository
+	instanceID     string
+	presenceOps    chan presenceOp
+	presenceDone   chan struct{}
 
-	go hub.Run()
+	maxConnectionsPerUser int
+	// closing is set once Stop is called, new clients are rejected
+	closing bool
+	stop    chan struct{}
+	// done is closed when Run returns
+	done chan struct{}
 }
 
-func Stream() *Hub {
-	return hub
+type registration struct {
+	client *Client
+	result chan error
 }
 
 type Message struct {
@@ -42,9 +73,182 @@ type Message struct {
 	UserID  uuid.UUID
 }
 
-// SetEventPubSub injects the redis pub/sub dependency.
-func (h *Hub) SetEventPubSub(ps *cache.EventPubSub) {
-	h.eventPubSub = ps
+func NewHub(
+	c *config.Config,
+	eventPubSub *cache.EventPubSub,
+	jwtStorage *cache.JWTStorage,
+	presence *cache.Presence,
+	duelRepository *repository.DuelRepository,
+) *Hub {
+	return &Hub{
+		broadcast:             make(chan Message),
+		register:              make(chan registration),
+		unregister:            make(chan *Client),
+		clients:               make(map[uuid.UUID]map[*Client]bool),
+		draining:              make(map[*Client]bool),
+		userCancels:           make(map[uuid.UUID]context.CancelFunc),
+		topics:                make(map[string]map[*Client]bool),
+		topicBroadcast:        make(chan TopicMessage),
+		commands:              make(chan command),
+		replayed:              make(chan replayDone),
+		topicCancels:          make(map[string]context.CancelFunc),
+		eventPubSub:           eventPubSub,
+		jwtStorage:            jwtStorage,
+		revocations:           make(chan uuid.UUID),
+		revoked:               make(chan []session),
+		presence:              presence,
+		duelRepository:        duelRepository,
+		instanceID:            uuid.NewString(),
+		presenceOps:           make(chan presenceOp, presenceQueueSize),
+		presenceDone:          make(chan struct{}),
+		maxConnectionsPerUser: c.App.WSMaxConnectionsPerUser,
+		stop:                  make(chan struct{}),
+		done:                  make(chan struct{}),
+	}
+}
+
+func (h *Hub) Start(_ context.Context) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	h.stopWatching = cancel
+
+	if h.jwtStorage != nil {
+		go h.watchRevocations(ctx)
+	}
+	go h.runPresence()
+	go h.Run()
+
+	return nil
+}
+
+// Stop closes all connections with a going away frame and waits until they are drained
+// and their viewers are removed
+func (h *Hub) Stop(ctx context.Context) error {
+	h.stopWatching()
+	close(h.stop)
+
+	for _, done := range []chan struct{}{h.done, h.presenceDone} {
+		select {
+		case <-done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+
+	return nil
+}
+
+// Register adds a client of the session, if lastEventID is set the events published since
+// then are replayed before live delivery resumes. The client must be served or unregistered,
+// it is closed when the token expires or the session is revoked
+func (h *Hub) Register(claims auth.TokenClaims, lastEventID string) (*Client, error) {
+	client := New(claims.UserID, nil)
+	client.sessionID = claims.SessionID
+	client.familyID = claims.FamilyID
+	client.expiresAt = claims.ExpiresAt
+	client.resumeFrom = lastEventID
+	client.replaying = lastEventID != ""
+
+	r := registration{client: client, result: make(chan error, 1)}
+
+	select {
+	case h.register <- r:
+	case <-h.done:
+		return nil, apperrors.ServiceUnavailable("server is shutting down")
+	}
+
+	if err := <-r.result; err != nil {
+		return nil, err
+	}
+
+	return client, nil
+}
+
+// Unregister releases the client, it is safe to call for a client that was already closed by the hub
+func (h *Hub) Unregister(client *Client) {
+	select {
+	case h.unregister <- client:
+	case <-h.done:
+	}
+}
+
+func (h *Hub) add(r registration) {
+	client := r.client
+	userID := client.UserID
+
+	if h.closing {
+		r.result <- apperrors.ServiceUnavailable("server is shutting down")
+		return
+	}
+	if h.maxConnectionsPerUser > 0 && len(h.clients[userID]) >= h.maxConnectionsPerUser {
+		r.result <- apperrors.TooManyRequests("too many connections")
+		return
+	}
+
+	if _, ok := h.clients[userID]; !ok {
+		h.clients[userID] = make(map[*Client]bool)
+	}
+	h.clients[userID][client] = true
+	Connections.Add(1)
+	// start per-user subscription on first client
+	h.ensureSubscription(userID)
+
+	r.result <- nil
+}
+
+// remove releases a client whose connection is closed
+func (h *Hub) remove(client *Client) {
+	h.closeClient(client, websocket.CloseNormalClosure, "")
+
+	if h.draining[client] {
+		delete(h.draining, client)
+		Connections.Add(-1)
+	}
+}
+
+// closeClient stops delivery to the client and closes its Send channel with the close frame to write,
+// the client is drained until its connection unregisters. A client is closed at most once
+func (h *Hub) closeClient(client *Client, code int, reason string) {
+	if !h.registered(client) {
+		return
+	}
+
+	userID := client.UserID
+
+	h.removeFromTopics(client)
+	delete(h.clients[userID], client)
+	h.draining[client] = true
+
+	client.closeCode = code
+	client.closeReason = reason
+	close(client.Send)
+
+	if len(h.clients[userID]) == 0 {
+		delete(h.clients, userID)
+		// stop per-user subscription when last client disconnects
+		h.stopSubscriptionIfIdle(userID)
+	}
+}
+
+// evict drops a client that does not keep up with its messages
+func (h *Hub) evict(client *Client) {
+	if !h.registered(client) {
+		return
+	}
+
+	DroppedMessages.Add(1)
+	EvictedClients.Add(1)
+	h.closeClient(client, websocket.ClosePolicyViolation, "slow consumer")
+}
+
+// shutdown closes all clients, which stops their subscriptions, the hub keeps running until they are drained
+func (h *Hub) shutdown() {
+	h.closing = true
+
+	for _, userClients := range h.clients {
+		for client := range userClients {
+			h.closeClient(client, websocket.CloseGoingAway, "server is shutting down")
+		}
+	}
 }
 
 func (h *Hub) ensureSubscription(userID uuid.UUID) {
@@ -71,7 +275,11 @@ func (h *Hub) ensureSubscription(userID uuid.UUID) {
 			case <-ctx.Done():
 				return
 			case msg := <-sub.Channel():
-				h.broadcast <- Message{UserID: userID, Message: []byte(msg.Payload)}
+				select {
+				case h.broadcast <- Message{UserID: userID, Message: []byte(msg.Payload)}:
+				case <-ctx.Done():
+					return
+				}
 			}
 		}
 	}()
@@ -88,51 +296,68 @@ func (h *Hub) stopSubscriptionIfIdle(userID uuid.UUID) {
 }
 
 func (h *Hub) Run() {
+	defer close(h.done)
+	// the hub is the only sender of presence ops
+	defer close(h.presenceOps)
+
+	ticker := time.NewTicker(sessionCheckPeriod)
+	defer ticker.Stop()
+
+	presenceTicker := time.NewTicker(presenceRefreshPeriod)
+	defer presenceTicker.Stop()
+
+	stop := h.stop
 	for {
 		select {
-		case client := <-h.register:
-			userID := client.UserID
-			if _, ok := h.clients[userID]; !ok {
-				h.clients[userID] = make(map[*Client]bool)
-			}
-			h.clients[userID][client] = true
-			// start per-user subscription on first client
-			h.ensureSubscription(userID)
+		case <-stop:
+			stop = nil
+			h.shutdown()
+
+		case <-ticker.C:
+			h.expireSessions()
+			h.checkSessions(h.allSessions())
+
+		case <-presenceTicker.C:
+			h.refreshPresence()
+
+		case userID := <-h.revocations:
+			h.checkSessions(h.sessions(userID))
+
+		case sessions := <-h.revoked:
+			h.closeRevoked(sessions)
+
+		case r := <-h.register:
+			h.add(r)
 
 		case client := <-h.unregister:
-			userID := client.UserID
-
-			if userClients, ok := h.clients[userID]; ok {
-				if _, ok = userClients[client]; ok {
-					delete(userClients, client)
-					close(client.Send)
-
-					if len(userClients) == 0 {
-						delete(h.clients, userID)
-						// stop per-user subscription when last client disconnects
-						h.stopSubscriptionIfIdle(userID)
-					}
-				}
-			}
+			h.remove(client)
 
 		case message := <-h.broadcast:
-			userID := message.UserID
-
-			if userClients, ok := h.clients[userID]; ok {
-				for client := range userClients {
-					select {
-					case client.Send <- message.Message:
-					default:
-						close(client.Send)
-						delete(userClients, client)
-
-						if len(userClients) == 0 {
-							delete(h.clients, userID)
-							h.stopSubscriptionIfIdle(userID)
-						}
-					}
+			for client := range h.clients[message.UserID] {
+				if client.replaying {
+					h.hold(client, message)
+					continue
+				}
+
+				select {
+				case client.Send <- message.Message:
+				default:
+					h.evict(client)
 				}
 			}
+
+		case done := <-h.replayed:
+			h.finishReplay(done)
+
+		case message := <-h.topicBroadcast:
+			h.broadcastTopic(message)
+
+		case cmd := <-h.commands:
+			h.handleCommand(cmd)
+		}
+
+		if h.closing && len(h.clients) == 0 && len(h.draining) == 0 {
+			return
 		}
 	}
 }
@@ -0,0 +1,185 @@
+package ws
+
+import (
+	"context"
+	"duels-api/internal/model"
+	repo "duels-api/pkg/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const (
+	// presenceRefreshPeriod keeps viewers counted well within cache.PresenceTTL
+	presenceRefreshPeriod = 15 * time.Second
+	presenceTimeout       = 5 * time.Second
+	presenceQueueSize     = 256
+)
+
+type presenceKind uint8
+
+const (
+	presenceJoin presenceKind = iota
+	presenceLeave
+	presenceRefresh
+	// presenceClose is the leave of the last viewer of the duel through this instance
+	presenceClose
+)
+
+// presenceOp is a change of the viewers of a duel through this instance,
+// ops are applied in order by a single worker so a leave never overtakes its join
+type presenceOp struct {
+	kind    presenceKind
+	topic   string
+	duelID  uuid.UUID
+	userIDs []uuid.UUID
+}
+
+// viewing reports whether any client of the user is subscribed to the topic
+func (h *Hub) viewing(topic string, userID uuid.UUID) bool {
+	for client := range h.topics[topic] {
+		if client.UserID == userID {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (h *Hub) queuePresence(kind presenceKind, topic string, userIDs ...uuid.UUID) {
+	if h.presence == nil {
+		return
+	}
+
+	duelID, ok := model.TopicDuelID(topic)
+	if !ok {
+		return
+	}
+
+	// dropped ops are repaired by the next refresh or expire with the viewer
+	select {
+	case h.presenceOps <- presenceOp{kind: kind, topic: topic, duelID: duelID, userIDs: userIDs}:
+	default:
+		zap.L().Warn("presence queue is full, dropping op", zap.String("topic", topic))
+	}
+}
+
+// refreshPresence keeps the viewers of this instance counted
+func (h *Hub) refreshPresence() {
+	for topic, topicClients := range h.topics {
+		seen := make(map[uuid.UUID]bool, len(topicClients))
+		userIDs := make([]uuid.UUID, 0, len(topicClients))
+
+		for client := range topicClients {
+			if !seen[client.UserID] {
+				seen[client.UserID] = true
+				userIDs = append(userIDs, client.UserID)
+			}
+		}
+
+		h.queuePresence(presenceRefresh, topic, userIDs...)
+	}
+}
+
+// runPresence applies presence ops until the hub stops and publishes the viewer count
+// to the duel topic whenever it changes, including viewers of other instances expired by
+// a refresh. Only duels in process are counted, viewers of finished duels expire
+func (h *Hub) runPresence() {
+	defer close(h.presenceDone)
+
+	// viewers is the last count published for duels counted through this instance
+	viewers := make(map[string]uint64)
+	// inProcess caches the duel status of topics viewed through this instance, a duel leaves
+	// the process once, so joins rely on the status checked by the last refresh of the topic
+	inProcess := make(map[string]bool)
+
+	for op := range h.presenceOps {
+		last, counted := viewers[op.topic]
+
+		leaving := op.kind == presenceLeave || op.kind == presenceClose
+		if op.kind == presenceClose {
+			delete(inProcess, op.topic)
+		}
+		if leaving && !counted {
+			continue
+		}
+		if !leaving {
+			current, checked := inProcess[op.topic]
+			if !checked || op.kind == presenceRefresh {
+				current = h.inProcess(op.duelID)
+				inProcess[op.topic] = current
+			}
+			if !current {
+				continue
+			}
+		}
+
+		count, err := h.applyPresence(op)
+		if err != nil {
+			zap.L().Error("failed to update duel viewers", zap.String("topic", op.topic), zap.Error(err))
+			continue
+		}
+
+		if op.kind == presenceClose {
+			delete(viewers, op.topic)
+		} else {
+			viewers[op.topic] = count
+		}
+
+		if counted && last == count {
+			continue
+		}
+
+		h.publishViewers(op, count)
+	}
+}
+
+// inProcess reports whether viewers of the duel are counted
+func (h *Hub) inProcess(duelID uuid.UUID) bool {
+	if h.duelRepository == nil {
+		return true
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), presenceTimeout)
+	defer cancel()
+
+	status, err := h.duelRepository.GetStatus(ctx, duelID)
+	if err != nil {
+		if !repo.IsErrNoRows(err) {
+			zap.L().Error("failed to get duel status", zap.String("duel_id", duelID.String()), zap.Error(err))
+		}
+		return false
+	}
+
+	return status == model.DuelStatusInProcess
+}
+
+func (h *Hub) applyPresence(op presenceOp) (uint64, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), presenceTimeout)
+	defer cancel()
+
+	if op.kind == presenceLeave || op.kind == presenceClose {
+		return h.presence.Leave(ctx, op.duelID, h.instanceID, op.userIDs[0])
+	}
+
+	return h.presence.Join(ctx, op.duelID, h.instanceID, op.userIDs...)
+}
+
+func (h *Hub) publishViewers(op presenceOp, count uint64) {
+	if h.eventPubSub == nil {
+		return
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), presenceTimeout)
+	defer cancel()
+
+	event := model.NewTopicEvent(op.topic, model.EventDuelViewers, &model.DuelViewersEvent{
+		DuelID:  op.duelID,
+		Viewers: count,
+	})
+
+	if err := h.eventPubSub.PublishTopic(ctx, event); err != nil {
+		zap.L().Error("failed to publish duel viewers", zap.String("topic", op.topic), zap.Error(err))
+	}
+}
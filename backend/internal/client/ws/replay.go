@@ -0,0 +1,109 @@
+package ws
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/internal/storage/cache"
+)
+
+type replayDone struct {
+	client      *Client
+	lastEventID string
+}
+
+// resume replays the events missed by the client and switches it to live delivery,
+// it must run in the goroutine that writes to the client
+func (h *Hub) resume(c *Client, write func(data []byte) error) error {
+	if c.resumeFrom == "" {
+		return nil
+	}
+
+	lastEventID, err := h.replay(c, write)
+
+	select {
+	case h.replayed <- replayDone{client: c, lastEventID: lastEventID}:
+	case <-h.done:
+	}
+
+	return err
+}
+
+// replay writes the events stored since the cursor of the client before live delivery
+// starts and returns the id of the last event written. A reset event comes first
+// when the stream was trimmed past the cursor
+func (h *Hub) replay(c *Client, write func(data []byte) error) (string, error) {
+	lastEventID := c.resumeFrom
+	if h.eventPubSub == nil {
+		return lastEventID, nil
+	}
+
+	trimmed, err := h.eventPubSub.Trimmed(context.Background(), c.UserID, lastEventID)
+	if err != nil {
+		return lastEventID, err
+	}
+
+	if trimmed {
+		reset, err := model.NewEvent(model.EventReplayReset).MarshalBinary()
+		if err != nil {
+			return lastEventID, err
+		}
+
+		if err = write(reset); err != nil {
+			return lastEventID, err
+		}
+	}
+
+	for {
+		events, err := h.eventPubSub.ReadSince(context.Background(), c.UserID, lastEventID)
+		if err != nil {
+			return lastEventID, err
+		}
+
+		if len(events) == 0 {
+			return lastEventID, nil
+		}
+
+		for _, event := range events {
+			if err = write(event.Data); err != nil {
+				return lastEventID, err
+			}
+			lastEventID = event.ID
+		}
+	}
+}
+
+// hold keeps a live message until the replay of the client is done
+func (h *Hub) hold(c *Client, message Message) {
+	if len(c.pending) >= cap(c.Send) {
+		h.evict(c)
+		return
+	}
+
+	c.pending = append(c.pending, message)
+}
+
+// finishReplay switches the client to live delivery, held messages that were
+// replayed already are skipped
+func (h *Hub) finishReplay(done replayDone) {
+	c := done.client
+	if !h.registered(c) {
+		return
+	}
+
+	pending := c.pending
+	c.replaying = false
+	c.pending = nil
+
+	for _, message := range pending {
+		if !cache.EventIDAfter(cache.ParseEventID(message.Message), done.lastEventID) {
+			continue
+		}
+
+		select {
+		case c.Send <- message.Message:
+		default:
+			h.evict(c)
+			return
+		}
+	}
+}
@@ -0,0 +1,239 @@
+package ws
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/apperrors"
+	auth "duels-api/pkg/jwt"
+	"errors"
+	"time"
+
+	"github.com/fasthttp/websocket"
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// Close codes of connections closed for their session
+const (
+	CloseUnauthorized   = 4001
+	CloseTokenExpired   = 4002
+	CloseSessionRevoked = 4003
+)
+
+const (
+	authWait = 10 * time.Second
+	// sessionCheckPeriod is how often expired tokens and revoked sessions are looked for
+	sessionCheckPeriod = 30 * time.Second
+)
+
+// Authenticate parses an access token, rejecting tokens of revoked sessions
+type Authenticate func(ctx context.Context, token string) (*auth.TokenClaims, error)
+
+// session is a snapshot of the session of a client, checked outside the hub goroutine
+type session struct {
+	client    *Client
+	userID    uuid.UUID
+	sessionID uuid.UUID
+	familyID  uuid.UUID
+}
+
+// Accept authenticates the connection with its first message {"action":"auth","token":"<access token>"}
+// and serves it, so the token never appears in urls. If lastEventID is set the events published since
+// then are replayed before live delivery resumes
+func (h *Hub) Accept(conn *websocket.Conn, lastEventID string, authenticate Authenticate) {
+	conn.SetReadLimit(maxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(authWait))
+
+	claims, err := readAuth(conn, authenticate)
+	if err != nil {
+		closeConn(conn, CloseUnauthorized, "authentication failed")
+		return
+	}
+
+	client, err := h.Register(*claims, lastEventID)
+	if err != nil {
+		reason := "connection rejected"
+		if appErr, ok := apperrors.IsAppError(err); ok {
+			reason = appErr.Message
+		}
+		closeConn(conn, websocket.CloseTryAgainLater, reason)
+		return
+	}
+	client.authenticate = authenticate
+
+	// the pumps are not started yet, the connection has no other writer
+	message, err := json.Marshal(authenticatedEvent(claims))
+	if err == nil {
+		_ = conn.SetWriteDeadline(time.Now().Add(writeWait))
+		err = conn.WriteMessage(websocket.TextMessage, message)
+	}
+	if err != nil {
+		h.Unregister(client)
+		return
+	}
+
+	h.Serve(client, conn)
+}
+
+func readAuth(conn *websocket.Conn, authenticate Authenticate) (*auth.TokenClaims, error) {
+	_, data, err := conn.ReadMessage()
+	if err != nil {
+		return nil, err
+	}
+
+	var message ClientMessage
+	if err = json.Unmarshal(data, &message); err != nil {
+		return nil, err
+	}
+
+	if message.Action != ActionAuth {
+		return nil, errors.New("first message must authenticate")
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), authWait)
+	defer cancel()
+
+	return authenticate(ctx, message.Token)
+}
+
+func closeConn(conn *websocket.Conn, code int, reason string) {
+	_ = conn.WriteControl(
+		websocket.CloseMessage,
+		websocket.FormatCloseMessage(code, reason),
+		time.Now().Add(writeWait),
+	)
+}
+
+func authenticatedEvent(claims *auth.TokenClaims) *model.Event {
+	return model.NewEventWithPayload(model.EventAuthenticated, &model.AuthenticatedEvent{ExpiresAt: claims.ExpiresAt})
+}
+
+// refresh extends the connection with a renewed access token of the same user
+func (h *Hub) refresh(cmd command) {
+	c := cmd.client
+
+	if cmd.err != nil || cmd.claims == nil {
+		h.replyError(c, "", "invalid token")
+		return
+	}
+	if cmd.claims.UserID != c.UserID {
+		h.replyError(c, "", "token of another user")
+		return
+	}
+
+	c.sessionID = cmd.claims.SessionID
+	c.familyID = cmd.claims.FamilyID
+	c.expiresAt = cmd.claims.ExpiresAt
+
+	h.reply(c, authenticatedEvent(cmd.claims))
+}
+
+// expireSessions closes clients whose token expired without being refreshed
+func (h *Hub) expireSessions() {
+	now := time.Now()
+
+	for _, userClients := range h.clients {
+		for client := range userClients {
+			if !client.expiresAt.IsZero() && now.After(client.expiresAt) {
+				h.closeClient(client, CloseTokenExpired, "token expired")
+			}
+		}
+	}
+}
+
+func (h *Hub) sessions(userID uuid.UUID) []session {
+	sessions := make([]session, 0, len(h.clients[userID]))
+	for client := range h.clients[userID] {
+		sessions = append(sessions, session{
+			client:    client,
+			userID:    userID,
+			sessionID: client.sessionID,
+			familyID:  client.familyID,
+		})
+	}
+
+	return sessions
+}
+
+func (h *Hub) allSessions() []session {
+	sessions := make([]session, 0, len(h.clients))
+	for userID := range h.clients {
+		sessions = append(sessions, h.sessions(userID)...)
+	}
+
+	return sessions
+}
+
+// checkSessions looks up the sessions in the storage without blocking the hub,
+// clients of revoked sessions are closed once the lookup is done. Sessions rotated by a
+// refresh stay open until their token expires, so clients can move to the new one
+func (h *Hub) checkSessions(sessions []session) {
+	if h.jwtStorage == nil || len(sessions) == 0 {
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), sessionCheckPeriod)
+		defer cancel()
+
+		revoked := make([]session, 0)
+		for _, s := range sessions {
+			exists, err := h.jwtStorage.SessionAlive(ctx, s.userID, s.sessionID, s.familyID)
+			if err != nil {
+				zap.L().Error("failed to check session of ws client", zap.String("user_id", s.userID.String()), zap.Error(err))
+				continue
+			}
+
+			if !exists {
+				revoked = append(revoked, s)
+			}
+		}
+
+		if len(revoked) == 0 {
+			return
+		}
+
+		select {
+		case h.revoked <- revoked:
+		case <-h.done:
+		}
+	}()
+}
+
+// closeRevoked closes clients that did not refresh to another session since the check
+func (h *Hub) closeRevoked(sessions []session) {
+	for _, s := range sessions {
+		if s.client.sessionID == s.sessionID {
+			h.closeClient(s.client, CloseSessionRevoked, "session revoked")
+		}
+	}
+}
+
+// watchRevocations checks the connections of users whose sessions were revoked on any instance
+func (h *Hub) watchRevocations(ctx context.Context) {
+	sub := h.jwtStorage.SubscribeRevocations(ctx)
+	defer func() {
+		if err := sub.Close(); err != nil {
+			zap.L().Error("failed to close revocations subscription", zap.Error(err))
+		}
+	}()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case msg := <-sub.Channel():
+			userID, err := uuid.Parse(msg.Payload)
+			if err != nil {
+				continue
+			}
+
+			select {
+			case h.revocations <- userID:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package ws
+
+import (
+	"bufio"
+	"bytes"
+	"duels-api/internal/storage/cache"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+const (
+	sseHeartbeatPeriod = 15 * time.Second
+	// sseRetry is the reconnection delay suggested to clients in milliseconds
+	sseRetry = "3000"
+)
+
+// ServeSSE streams the events of a registered client as Server-Sent Events until writing fails
+// or the hub closes it. It shares the per-user subscription and replay of WebSocket clients,
+// events are the same envelopes with event_id used as the SSE id
+func (h *Hub) ServeSSE(w *bufio.Writer, client *Client) {
+	defer h.Unregister(client)
+
+	write := func(data []byte) error {
+		return writeSSEEvent(w, data)
+	}
+
+	// the stream is flushed at once, so clients and proxies see it open before the first event
+	_, _ = w.WriteString("retry: " + sseRetry + "\n\n")
+	if err := w.Flush(); err != nil {
+		return
+	}
+
+	if err := h.resume(client, write); err != nil {
+		zap.L().Error("failed to replay events", zap.String("user_id", client.UserID.String()), zap.Error(err))
+		return
+	}
+
+	ticker := time.NewTicker(sseHeartbeatPeriod)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			// comments keep idle connections open and detect clients that went away
+			_, _ = w.WriteString(": heartbeat\n\n")
+			if err := w.Flush(); err != nil {
+				return
+			}
+
+		case message, ok := <-client.Send:
+			if !ok {
+				return
+			}
+
+			if err := write(message); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func writeSSEEvent(w *bufio.Writer, data []byte) error {
+	if id := cache.ParseEventID(data); id != "" {
+		_, _ = w.WriteString("id: " + id + "\n")
+	}
+
+	for _, line := range bytes.Split(data, newline) {
+		_, _ = w.WriteString("data: ")
+		_, _ = w.Write(line)
+		_, _ = w.WriteString("\n")
+	}
+	_, _ = w.WriteString("\n")
+
+	return w.Flush()
+}
@@ -0,0 +1,198 @@
+package ws
+
+import (
+	"context"
+	"duels-api/internal/model"
+	auth "duels-api/pkg/jwt"
+
+	"github.com/goccy/go-json"
+	"go.uber.org/zap"
+)
+
+const maxTopicsPerClient = 50
+
+const (
+	ActionAuth        = "auth"
+	ActionRefresh     = "refresh"
+	ActionSubscribe   = "subscribe"
+	ActionUnsubscribe = "unsubscribe"
+)
+
+// ClientMessage is a frame sent by the client, e.g. {"action":"subscribe","topic":"duel:<id>"}
+// or {"action":"refresh","token":"<access token>"}
+type ClientMessage struct {
+	Action string `json:"action"`
+	Topic  string `json:"topic"`
+	Token  string `json:"token"`
+}
+
+type command struct {
+	client  *Client
+	message ClientMessage
+	// claims of the token of auth and refresh messages
+	claims *auth.TokenClaims
+	err    error
+}
+
+type TopicMessage struct {
+	Topic   string
+	Message []byte
+}
+
+func (h *Hub) handleCommand(cmd command) {
+	// the client may have been evicted as a slow consumer while its frame was in flight
+	if !h.registered(cmd.client) {
+		return
+	}
+
+	switch cmd.message.Action {
+	case ActionAuth, ActionRefresh:
+		h.refresh(cmd)
+	case ActionSubscribe:
+		h.subscribeTopic(cmd.client, cmd.message.Topic)
+	case ActionUnsubscribe:
+		h.unsubscribeTopic(cmd.client, cmd.message.Topic)
+	default:
+		h.replyError(cmd.client, cmd.message.Topic, "unknown action")
+	}
+}
+
+func (h *Hub) subscribeTopic(c *Client, topic string) {
+	if !model.ValidTopic(topic) {
+		h.replyError(c, topic, "invalid topic")
+		return
+	}
+
+	if !c.topics[topic] {
+		if len(c.topics) >= maxTopicsPerClient {
+			h.replyError(c, topic, "too many subscriptions")
+			return
+		}
+
+		if !h.viewing(topic, c.UserID) {
+			h.queuePresence(presenceJoin, topic, c.UserID)
+		}
+
+		if _, ok := h.topics[topic]; !ok {
+			h.topics[topic] = make(map[*Client]bool)
+		}
+		h.topics[topic][c] = true
+		c.topics[topic] = true
+
+		h.ensureTopicSubscription(topic)
+	}
+
+	h.reply(c, model.NewTopicEvent(topic, model.EventSubscribed, nil))
+}
+
+func (h *Hub) unsubscribeTopic(c *Client, topic string) {
+	h.removeFromTopic(c, topic)
+	h.reply(c, model.NewTopicEvent(topic, model.EventUnsubscribed, nil))
+}
+
+func (h *Hub) removeFromTopic(c *Client, topic string) {
+	delete(c.topics, topic)
+
+	if topicClients, ok := h.topics[topic]; ok {
+		if !topicClients[c] {
+			return
+		}
+		delete(topicClients, c)
+
+		switch {
+		case len(topicClients) == 0:
+			h.queuePresence(presenceClose, topic, c.UserID)
+		case !h.viewing(topic, c.UserID):
+			h.queuePresence(presenceLeave, topic, c.UserID)
+		}
+
+		if len(topicClients) == 0 {
+			delete(h.topics, topic)
+			h.stopTopicSubscriptionIfIdle(topic)
+		}
+	}
+}
+
+// removeFromTopics must be called before the Send channel of the client is closed
+func (h *Hub) removeFromTopics(c *Client) {
+	for topic := range c.topics {
+		h.removeFromTopic(c, topic)
+	}
+}
+
+func (h *Hub) ensureTopicSubscription(topic string) {
+	if h.eventPubSub == nil {
+		return
+	}
+	if _, ok := h.topicCancels[topic]; ok {
+		return
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	h.topicCancels[topic] = cancel
+
+	sub := h.eventPubSub.SubscribeTopic(ctx, topic)
+
+	go func() {
+		defer func() {
+			if err := sub.Close(); err != nil {
+				zap.L().Error("failed to close topic subscription", zap.Error(err))
+			}
+		}()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case msg := <-sub.Channel():
+				select {
+				case h.topicBroadcast <- TopicMessage{Topic: topic, Message: []byte(msg.Payload)}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}
+	}()
+}
+
+func (h *Hub) stopTopicSubscriptionIfIdle(topic string) {
+	if canc, ok := h.topicCancels[topic]; ok {
+		if topicClients, has := h.topics[topic]; !has || len(topicClients) == 0 {
+			canc()
+			delete(h.topicCancels, topic)
+		}
+	}
+}
+
+// broadcastTopic skips subscribers whose buffer is full, topic events are
+// public snapshots and the next one supersedes a missed one
+func (h *Hub) broadcastTopic(message TopicMessage) {
+	for client := range h.topics[message.Topic] {
+		select {
+		case client.Send <- message.Message:
+		default:
+			DroppedMessages.Add(1)
+		}
+	}
+}
+
+func (h *Hub) registered(c *Client) bool {
+	return h.clients[c.UserID][c]
+}
+
+func (h *Hub) reply(c *Client, event *model.Event) {
+	message, err := json.Marshal(event)
+	if err != nil {
+		zap.L().Error("failed to marshal ws reply", zap.Error(err))
+		return
+	}
+
+	select {
+	case c.Send <- message:
+	default:
+		DroppedMessages.Add(1)
+	}
+}
+
+func (h *Hub) replyError(c *Client, topic, message string) {
+	h.reply(c, model.NewTopicEvent(topic, model.EventError, &model.ErrorEvent{Message: message}))
+}
@@ -0,0 +1,61 @@
+package cron
+
+import (
+	"context"
+	"duels-api/internal/service"
+
+	rcron "github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type LeaderboardCron struct {
+	Log                *zap.Logger
+	Cron               *rcron.Cron
+	LeaderboardService *service.LeaderboardService
+}
+
+const (
+	RunningEvery10Minutes = "*/10 * * * *"
+)
+
+func NewLeaderboardCron(
+	l *zap.Logger,
+	cron *rcron.Cron,
+	leaderboardService *service.LeaderboardService,
+) (*LeaderboardCron, error) {
+	leaderboardCron := &LeaderboardCron{
+		Log:                l,
+		Cron:               cron,
+		LeaderboardService: leaderboardService,
+	}
+
+	_, err := leaderboardCron.Cron.AddFunc(RunningEvery10Minutes, leaderboardCron.refreshLeaderboards)
+	if err != nil {
+		return nil, err
+	}
+
+	return leaderboardCron, nil
+}
+
+func (c *LeaderboardCron) refreshLeaderboards() {
+	err := c.LeaderboardService.Refresh(context.Background())
+	if err != nil {
+		LogErr(c.Log, err)
+	} else {
+		c.Log.Debug("leaderboard cron: successfully refreshed leaderboards")
+	}
+}
+
+func (c *LeaderboardCron) start(_ context.Context) error {
+	c.Log.Info("leaderboard cron started")
+	// leaderboards are served right after deploy instead of in up to 10 minutes
+	go c.refreshLeaderboards()
+	c.Cron.Start()
+	return nil
+}
+
+func (c *LeaderboardCron) stop(_ context.Context) error {
+	c.Log.Info("leaderboard cron stopped")
+	c.Cron.Stop()
+	return nil
+}
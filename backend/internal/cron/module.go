@@ -10,6 +10,10 @@ func Module() fx.Option {
 		fx.Provide(rcron.New),
 		fx.Provide(NewSomeCron),
 		fx.Provide(NewNotificationCron),
+		fx.Provide(NewUploadCron),
+		fx.Provide(NewLeaderboardCron),
+		fx.Provide(NewSeasonCron),
+		fx.Provide(NewSignatureCron),
 		fx.Invoke(
 			func(lc fx.Lifecycle, cron *SomeCron) {
 				lc.Append(fx.Hook{
@@ -23,6 +27,30 @@ func Module() fx.Option {
 					OnStop:  cron.stop,
 				})
 			},
+			func(lc fx.Lifecycle, cron *UploadCron) {
+				lc.Append(fx.Hook{
+					OnStart: cron.start,
+					OnStop:  cron.stop,
+				})
+			},
+			func(lc fx.Lifecycle, cron *LeaderboardCron) {
+				lc.Append(fx.Hook{
+					OnStart: cron.start,
+					OnStop:  cron.stop,
+				})
+			},
+			func(lc fx.Lifecycle, cron *SeasonCron) {
+				lc.Append(fx.Hook{
+					OnStart: cron.start,
+					OnStop:  cron.stop,
+				})
+			},
+			func(lc fx.Lifecycle, cron *SignatureCron) {
+				lc.Append(fx.Hook{
+					OnStart: cron.start,
+					OnStop:  cron.stop,
+				})
+			},
 		),
 	)
 }
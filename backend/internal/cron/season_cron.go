@@ -0,0 +1,66 @@
+package cron
+
+import (
+	"context"
+	"duels-api/internal/service"
+	"sync"
+
+	rcron "github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type SeasonCron struct {
+	Log           *zap.Logger
+	Cron          *rcron.Cron
+	SeasonService *service.SeasonService
+
+	// settling keeps runs of this instance from overlapping, prizes are claimed
+	// in the database so other instances never pay them twice
+	settling sync.Mutex
+}
+
+func NewSeasonCron(
+	l *zap.Logger,
+	cron *rcron.Cron,
+	seasonService *service.SeasonService,
+) (*SeasonCron, error) {
+	seasonCron := &SeasonCron{
+		Log:           l,
+		Cron:          cron,
+		SeasonService: seasonService,
+	}
+
+	_, err := seasonCron.Cron.AddFunc(RunningEvery10Minutes, seasonCron.settleSeasons)
+	if err != nil {
+		return nil, err
+	}
+
+	return seasonCron, nil
+}
+
+func (c *SeasonCron) settleSeasons() {
+	if !c.settling.TryLock() {
+		c.Log.Debug("season cron: previous settlement is still running")
+		return
+	}
+	defer c.settling.Unlock()
+
+	err := c.SeasonService.Settle(context.Background())
+	if err != nil {
+		LogErr(c.Log, err)
+	} else {
+		c.Log.Debug("season cron: successfully settled ended seasons")
+	}
+}
+
+func (c *SeasonCron) start(_ context.Context) error {
+	c.Log.Info("season cron started")
+	c.Cron.Start()
+	return nil
+}
+
+func (c *SeasonCron) stop(_ context.Context) error {
+	c.Log.Info("season cron stopped")
+	c.Cron.Stop()
+	return nil
+}
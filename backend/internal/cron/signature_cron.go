@@ -0,0 +1,62 @@
+package cron
+
+import (
+	"context"
+	"duels-api/internal/service"
+
+	rcron "github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+const (
+	// RunningEveryMinute resumes signatures well within service.SignatureClaimLease
+	RunningEveryMinute = "* * * * *"
+)
+
+// SignatureCron resumes pending signatures whose lease expired, e.g. because
+// the instance watching them went away or their handler failed transiently
+type SignatureCron struct {
+	Log              *zap.Logger
+	Cron             *rcron.Cron
+	SignatureService *service.SignatureService
+}
+
+func NewSignatureCron(
+	l *zap.Logger,
+	cron *rcron.Cron,
+	signatureService *service.SignatureService,
+) (*SignatureCron, error) {
+	signatureCron := &SignatureCron{
+		Log:              l,
+		Cron:             cron,
+		SignatureService: signatureService,
+	}
+
+	_, err := signatureCron.Cron.AddFunc(RunningEveryMinute, signatureCron.resumeSignatures)
+	if err != nil {
+		return nil, err
+	}
+
+	return signatureCron, nil
+}
+
+func (c *SignatureCron) resumeSignatures() {
+	err := c.SignatureService.Resume(context.Background())
+	if err != nil {
+		LogErr(c.Log, err)
+	} else {
+		c.Log.Debug("signature cron: successfully resumed pending signatures")
+	}
+}
+
+func (c *SignatureCron) start(_ context.Context) error {
+	c.Log.Info("signature cron started")
+	c.Cron.Start()
+	return nil
+}
+
+func (c *SignatureCron) stop(_ context.Context) error {
+	c.Log.Info("signature cron stopped")
+	c.Cron.Stop()
+	return nil
+}
@@ -0,0 +1,65 @@
+package cron
+
+import (
+	"context"
+	"duels-api/config"
+	"duels-api/internal/service"
+	"time"
+
+	rcron "github.com/robfig/cron/v3"
+	"go.uber.org/zap"
+)
+
+type UploadCron struct {
+	Log         *zap.Logger
+	Cron        *rcron.Cron
+	FileService *service.FileService
+	OrphanTTL   time.Duration
+}
+
+const (
+	RunningHourly = "@hourly"
+)
+
+func NewUploadCron(
+	c *config.Config,
+	l *zap.Logger,
+	cron *rcron.Cron,
+	fileService *service.FileService,
+) (*UploadCron, error) {
+	uploadCron := &UploadCron{
+		Log:         l,
+		Cron:        cron,
+		FileService: fileService,
+		OrphanTTL:   c.Storage.UploadOrphanTTL,
+	}
+
+	_, err := uploadCron.Cron.AddFunc(RunningHourly, uploadCron.cleanupOrphanedUploads)
+	if err != nil {
+		return nil, err
+	}
+
+	return uploadCron, nil
+}
+
+func (c *UploadCron) cleanupOrphanedUploads() {
+	cutoff := time.Now().Add(-c.OrphanTTL)
+	deleted, err := c.FileService.CleanupOrphanedUploads(context.Background(), cutoff)
+	if err != nil {
+		LogErr(c.Log, err)
+	} else {
+		c.Log.Debug("upload cron: successfully cleaned up orphaned uploads", zap.Int("deleted", deleted))
+	}
+}
+
+func (c *UploadCron) start(_ context.Context) error {
+	c.Log.Info("upload cron started")
+	c.Cron.Start()
+	return nil
+}
+
+func (c *UploadCron) stop(_ context.Context) error {
+	c.Log.Info("upload cron stopped")
+	c.Cron.Stop()
+	return nil
+}
@@ -1,22 +1,73 @@
 package middleware
 
 import (
+	"duels-api/pkg/apperrors"
+	auth "duels-api/pkg/jwt"
 	"duels-api/pkg/middleware/limiter"
+	redislimiter "duels-api/pkg/middleware/limiter/redis"
 	"github.com/gofiber/fiber/v3"
+	"github.com/redis/go-redis/v9"
 	"time"
 )
 
-func NewLimiter() fiber.Handler {
+const (
+	LimitByIP = iota
+	// LimitByUser keys requests by the authenticated user, so it must follow the auth middleware.
+	// Requests without claims are keyed by IP.
+	LimitByUser
+)
+
+// LimitPolicy is a rate limit of a group of routes, routes with the same policy share counters
+type LimitPolicy struct {
+	Name   string
+	Max    int
+	Window time.Duration
+	KeyBy  int
+}
+
+var (
+	LimitPolicyAuth    = LimitPolicy{Name: "auth", Max: 10, Window: time.Minute, KeyBy: LimitByIP}
+	LimitPolicySignTx  = LimitPolicy{Name: "sign-tx", Max: 20, Window: time.Minute, KeyBy: LimitByUser}
+	LimitPolicyCreate  = LimitPolicy{Name: "create", Max: 10, Window: time.Minute, KeyBy: LimitByUser}
+	LimitPolicyJoin    = LimitPolicy{Name: "join", Max: 10, Window: time.Minute, KeyBy: LimitByUser}
+	LimitPolicyResolve = LimitPolicy{Name: "resolve", Max: 5, Window: time.Minute, KeyBy: LimitByUser}
+	LimitPolicyUpload  = LimitPolicy{Name: "upload", Max: 3, Window: time.Minute, KeyBy: LimitByUser}
+	LimitPolicyComment = LimitPolicy{Name: "comment", Max: 10, Window: time.Minute, KeyBy: LimitByUser}
+)
+
+// RateLimiter builds limiters of the policies on top of the storage shared by all replicas
+type RateLimiter struct {
+	storage limiter.Storage
+}
+
+func NewRateLimiter(client *redis.Client) *RateLimiter {
+	return &RateLimiter{
+		storage: redislimiter.New(client, "limiter:"),
+	}
+}
+
+func (l *RateLimiter) Policy(policy LimitPolicy) fiber.Handler {
 	cfg := limiter.Config{
-		Max:        1,
-		Expiration: 1 * time.Second,
-		Skip: func(c fiber.Ctx) bool {
-			return c.Response().StatusCode() == fiber.StatusUnauthorized
-		},
+		Max:        policy.Max,
+		Expiration: policy.Window,
+		Storage:    l.storage,
 		KeyGenerator: func(c fiber.Ctx) string {
-			return c.IP() + ";" + c.Path()
+			return policy.Name + ":" + limitKey(c, policy.KeyBy)
+		},
+		LimitReached: func(c fiber.Ctx) error {
+			return apperrors.TooManyRequests("too many requests, try again later")
 		},
 	}
 
 	return limiter.NewSlidingWindow(cfg)
 }
+
+func limitKey(c fiber.Ctx, keyBy int) string {
+	if keyBy == LimitByUser {
+		if claims, ok := c.Locals("claims").(auth.TokenClaims); ok {
+			return "user:" + claims.UserID.String()
+		}
+	}
+
+	return "ip:" + c.IP()
+}
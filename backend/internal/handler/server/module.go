@@ -3,6 +3,7 @@ package server
 import (
 	"context"
 	"duels-api/config"
+	"duels-api/internal/handler/middleware"
 	"github.com/gofiber/fiber/v3"
 	"go.uber.org/fx"
 	"log"
@@ -13,6 +14,7 @@ func Module() fx.Option {
 	return fx.Module("handler",
 		fx.Provide(
 			NewServer,
+			middleware.NewRateLimiter,
 		),
 		fx.Invoke(
 			func(lc fx.Lifecycle, app *fiber.App, c *config.Config) {
@@ -3,6 +3,8 @@ package server
 import (
 	"duels-api/config"
 	"duels-api/internal/handler/middleware"
+	"duels-api/internal/storage/object"
+	"duels-api/pkg/apperrors"
 	"encoding/json"
 	"github.com/gofiber/fiber/v3"
 	"github.com/gofiber/fiber/v3/middleware/cors"
@@ -10,11 +12,14 @@ import (
 	recoverer "github.com/gofiber/fiber/v3/middleware/recover"
 	"github.com/gofiber/fiber/v3/middleware/static"
 	"go.uber.org/zap"
+	"os"
+	"path"
+	"path/filepath"
 	"strings"
 	"time"
 )
 
-func NewServer(c *config.Config, l *zap.Logger) *fiber.App {
+func NewServer(c *config.Config, l *zap.Logger, storage object.Storage) *fiber.App {
 	app := fiber.New(fiber.Config{
 		ProxyHeader: "X-Forwarded-For",
 		JSONEncoder: json.Marshal,
@@ -52,7 +57,41 @@ func NewServer(c *config.Config, l *zap.Logger) *fiber.App {
 	}))
 
 	app.Get("/static/*", static.New("./resources/static"))
-	app.Get("/media/*", static.New("./storage"))
+	app.Get(object.MediaURLPrefix+"/*", mediaHandler(c, storage))
 
 	return app
 }
+
+// mediaHandler resolves stored keys, local files are served directly
+// and files of the remote storage are redirected to their public or signed url.
+// Files uploaded before the switch to a remote storage stay served from the local dir
+func mediaHandler(c *config.Config, storage object.Storage) fiber.Handler {
+	local := static.New(c.Storage.LocalDir)
+	if c.Storage.Backend == object.BackendLocal {
+		return local
+	}
+
+	localDir := c.Storage.LocalDir
+	return func(c fiber.Ctx) error {
+		key := c.Params("*")
+		if localFileExists(localDir, key) {
+			return local(c)
+		}
+
+		url, err := storage.URL(c.Context(), key)
+		if err != nil {
+			return apperrors.NotFound("file not found", err)
+		}
+
+		return c.Redirect().Status(fiber.StatusFound).To(url)
+	}
+}
+
+func localFileExists(dir, key string) bool {
+	info, err := os.Stat(filepath.Join(dir, filepath.FromSlash(path.Clean("/"+key))))
+	if err != nil {
+		return false
+	}
+
+	return !info.IsDir()
+}
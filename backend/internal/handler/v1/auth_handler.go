@@ -1,61 +1,64 @@
 package v1
 
 import (
+	"duels-api/internal/handler/middleware"
 	"duels-api/internal/service"
 	"duels-api/pkg/apperrors"
-	auth "duels-api/pkg/jwt"
+	_ "duels-api/pkg/jwt"
 	"github.com/gofiber/fiber/v3"
-	"github.com/google/uuid"
-	"time"
-)
-
-var (
-	userImageUploadCache = make(map[uuid.UUID]int64)
 )
 
 type AuthHandler struct {
-	UserService *service.UserService
-	JWTService  *service.JWTService
+	UserService   *service.UserService
+	JWTService    *service.JWTService
+	APIKeyService *service.APIKeyService
+	Limiter       *middleware.RateLimiter
 }
 
 func NewAuthHandler(us *service.UserService,
-	jwtService *service.JWTService) *AuthHandler {
+	jwtService *service.JWTService,
+	apiKeyService *service.APIKeyService,
+	limiter *middleware.RateLimiter) *AuthHandler {
 	return &AuthHandler{
-		UserService: us,
-		JWTService:  jwtService,
+		UserService:   us,
+		JWTService:    jwtService,
+		APIKeyService: apiKeyService,
+		Limiter:       limiter,
 	}
 }
 
 func (h *AuthHandler) RegisterRoutes(app *fiber.App) {
+	app.Get("/.well-known/jwks.json", h.GetJWKS)
+
 	authGroup := app.Group("/auth")
 	{
-		authGroup.Post("/sign-in-wallet", h.SignInWithWallet)
+		authGroup.Post("/challenge", h.RateLimit(middleware.LimitPolicyAuth), h.IssueSignInChallenge)
+		authGroup.Post("/sign-in-wallet", h.RateLimit(middleware.LimitPolicyAuth), h.SignInWithWallet)
 
-		authGroup.Post("/refresh", h.RefreshTokens)
-	}
-}
+		authGroup.Post("/refresh", h.RateLimit(middleware.LimitPolicyAuth), h.RefreshTokens)
 
-func (h *AuthHandler) AuthMiddleware(c fiber.Ctx) error {
-	token := c.Get("Authorization")
+		authGroup.Get("/sessions", h.AuthMiddleware, h.GetSessions)
+		authGroup.Delete("/sessions/:id", h.AuthMiddleware, h.RevokeSession)
+		authGroup.Post("/logout", h.AuthMiddleware, h.Logout)
+		authGroup.Post("/logout-all", h.AuthMiddleware, h.LogoutAll)
 
-	claims, err := h.JWTService.ParseToken(token)
-	if err != nil {
-		return apperrors.Unauthorized("failed to parse token", err)
+		authGroup.Get("/api-keys", h.AuthMiddleware, h.GetAPIKeys)
+		authGroup.Post("/api-keys", h.AuthMiddleware, h.CreateAPIKey)
+		authGroup.Delete("/api-keys/:id", h.AuthMiddleware, h.RevokeAPIKey)
 	}
 
-	if claims == nil {
-		return apperrors.Internal("failed to generate claims")
+	adminGroup := app.Group("/admin", h.AuthMiddleware, h.AdminMiddleware)
+	{
+		adminGroup.Get("/api-keys", h.AdminGetAPIKeys)
+		adminGroup.Post("/api-keys", h.AdminCreateAPIKey)
+		adminGroup.Delete("/api-keys/:id", h.AdminRevokeAPIKey)
 	}
-
-	c.Locals("claims", *claims)
-
-	return c.Next()
 }
 
-func (h *AuthHandler) AuthMiddlewareQuery(c fiber.Ctx) error {
-	token := c.Query("token")
+func (h *AuthHandler) AuthMiddleware(c fiber.Ctx) error {
+	token := c.Get("Authorization")
 
-	claims, err := h.JWTService.ParseToken(token)
+	claims, err := h.JWTService.ParseAccessToken(c.Context(), token)
 	if err != nil {
 		return apperrors.Unauthorized("failed to parse token", err)
 	}
@@ -69,22 +72,22 @@ func (h *AuthHandler) AuthMiddlewareQuery(c fiber.Ctx) error {
 	return c.Next()
 }
 
-func (h *AuthHandler) UploadUserImageMiddleware(c fiber.Ctx) error {
-	claims, ok := c.Locals("claims").(auth.TokenClaims)
-	if !ok {
-		return apperrors.Unauthorized("claims not found")
-	}
-
-	now := time.Now().Unix()
-	lastUploadTime, ok := userImageUploadCache[claims.UserID]
-	if ok {
-		// add 60 seconds to last image upload
-		if lastUploadTime+60 > now {
-			return apperrors.TooManyRequests("only 3 images per minute allowed")
-		}
-	}
-
-	userImageUploadCache[claims.UserID] = now
+// RateLimit limits the route by the policy, policies keyed by user must follow the auth middleware
+func (h *AuthHandler) RateLimit(policy middleware.LimitPolicy) fiber.Handler {
+	return h.Limiter.Policy(policy)
+}
 
-	return c.Next()
+// GetJWKS godoc
+//
+//	@Summary		Token verification keys
+//	@Description	Publishes the public keys tokens are signed with as a JSON Web Key Set, keys are matched by the kid header of the token.
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	auth.JWKSet	"Verification keys"
+//	@Router			/.well-known/jwks.json [get]
+func (h *AuthHandler) GetJWKS(c fiber.Ctx) error {
+	// keys are rotated with an overlap, verifiers may cache them for a while
+	c.Set(fiber.HeaderCacheControl, "public, max-age=300")
+
+	return c.JSON(h.JWTService.JWKS())
 }
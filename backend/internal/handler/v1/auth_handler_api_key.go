@@ -0,0 +1,236 @@
+package v1
+
+import (
+	"duels-api/internal/model"
+	"duels-api/pkg/apperrors"
+	auth "duels-api/pkg/jwt"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// AuthOrAPIKeyMiddleware accepts an api key with the given scope in the X-API-Key header
+// and falls back to AuthMiddleware when the header is not set.
+// Requests authenticated by a key act on behalf of the key owner.
+func (h *AuthHandler) AuthOrAPIKeyMiddleware(scope string) fiber.Handler {
+	return func(c fiber.Ctx) error {
+		rawKey := c.Get(model.APIKeyHeader)
+		if rawKey == "" {
+			return h.AuthMiddleware(c)
+		}
+
+		key, err := h.APIKeyService.Authenticate(c.Context(), rawKey, scope)
+		if err != nil {
+			return err
+		}
+
+		c.Locals("claims", auth.TokenClaims{
+			UserID:    key.UserID,
+			TokenType: auth.TokenTypeAPIKey,
+			TokenID:   key.ID.String(),
+		})
+		c.Locals("api_key", key)
+
+		return c.Next()
+	}
+}
+
+// AdminMiddleware must follow AuthMiddleware, it lets through owners of admin wallets only
+func (h *AuthHandler) AdminMiddleware(c fiber.Ctx) error {
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	isAdmin, err := h.APIKeyService.IsAdmin(c.Context(), claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	if !isAdmin {
+		return apperrors.Forbidden("admin access required")
+	}
+
+	return c.Next()
+}
+
+// GetAPIKeys godoc
+//
+//	@Summary		List api keys
+//	@Description	Returns api keys of the authenticated user including revoked ones. The keys themselves are never returned, only their prefixes.
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	object{api_keys=[]model.APIKey}	"API keys"
+//	@Failure		401	{object}	apperrors.ErrorPublic			"Unauthorized"
+//	@Failure		500	{object}	apperrors.ErrorPublic			"Internal server error"
+//	@Router			/auth/api-keys [get]
+func (h *AuthHandler) GetAPIKeys(c fiber.Ctx) error {
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	keys, err := h.APIKeyService.GetUserKeys(c.Context(), claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{"api_keys": keys})
+}
+
+// CreateAPIKey godoc
+//
+//	@Summary		Create api key
+//	@Description	Issues an api key for bots and integrators acting on behalf of the user. Scopes: duels:read, duels:join, duels:resolve. The key is shown only once, pass it in the X-API-Key header.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		model.CreateAPIKeyReq	true	"Key name, scopes, rate limit per minute and optional expiration"
+//	@Success		201		{object}	model.IssuedAPIKey		"API key"
+//	@Failure		400		{object}	apperrors.ErrorPublic	"Invalid request"
+//	@Failure		401		{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/auth/api-keys [post]
+func (h *AuthHandler) CreateAPIKey(c fiber.Ctx) error {
+	var req model.CreateAPIKeyReq
+	if err := c.Bind().JSON(&req); err != nil {
+		return apperrors.BadRequest("invalid request body")
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	key, err := h.APIKeyService.Issue(c.Context(), claims.UserID, &req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(key)
+}
+
+// RevokeAPIKey godoc
+//
+//	@Summary		Revoke api key
+//	@Description	Revokes the api key of the authenticated user, requests with it are rejected from now on.
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"API key ID (UUID)"
+//	@Success		204	{object}	nil						"API key revoked"
+//	@Failure		400	{object}	apperrors.ErrorPublic	"Invalid api key ID"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"API key not found"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/auth/api-keys/{id} [delete]
+func (h *AuthHandler) RevokeAPIKey(c fiber.Ctx) error {
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	keyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid api key id")
+	}
+
+	if err = h.APIKeyService.Revoke(c.Context(), claims.UserID, keyID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// AdminGetAPIKeys godoc
+//
+//	@Summary		List api keys of a user (admin)
+//	@Description	Returns api keys of the given user.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			user_id	query		string							true	"User ID (UUID)"
+//	@Success		200		{object}	object{api_keys=[]model.APIKey}	"API keys"
+//	@Failure		400		{object}	apperrors.ErrorPublic			"Invalid user ID"
+//	@Failure		401		{object}	apperrors.ErrorPublic			"Unauthorized"
+//	@Failure		403		{object}	apperrors.ErrorPublic			"Admin access required"
+//	@Failure		500		{object}	apperrors.ErrorPublic			"Internal server error"
+//	@Router			/admin/api-keys [get]
+func (h *AuthHandler) AdminGetAPIKeys(c fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Query("user_id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid user id")
+	}
+
+	keys, err := h.APIKeyService.GetUserKeys(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{"api_keys": keys})
+}
+
+// AdminCreateAPIKey godoc
+//
+//	@Summary		Create api key for a user (admin)
+//	@Description	Issues an api key on behalf of the given user, the rate limit is not capped for admin issued keys.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		model.AdminCreateAPIKeyReq	true	"Owner user ID and key parameters"
+//	@Success		201		{object}	model.IssuedAPIKey			"API key"
+//	@Failure		400		{object}	apperrors.ErrorPublic		"Invalid request"
+//	@Failure		401		{object}	apperrors.ErrorPublic		"Unauthorized"
+//	@Failure		403		{object}	apperrors.ErrorPublic		"Admin access required"
+//	@Failure		404		{object}	apperrors.ErrorPublic		"User not found"
+//	@Failure		500		{object}	apperrors.ErrorPublic		"Internal server error"
+//	@Router			/admin/api-keys [post]
+func (h *AuthHandler) AdminCreateAPIKey(c fiber.Ctx) error {
+	var req model.AdminCreateAPIKeyReq
+	if err := c.Bind().JSON(&req); err != nil {
+		return apperrors.BadRequest("invalid request body")
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	key, err := h.APIKeyService.IssueByAdmin(c.Context(), claims.UserID, &req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(key)
+}
+
+// AdminRevokeAPIKey godoc
+//
+//	@Summary		Revoke any api key (admin)
+//	@Description	Revokes the api key regardless of its owner.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"API key ID (UUID)"
+//	@Success		204	{object}	nil						"API key revoked"
+//	@Failure		400	{object}	apperrors.ErrorPublic	"Invalid api key ID"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		403	{object}	apperrors.ErrorPublic	"Admin access required"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"API key not found"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/admin/api-keys/{id} [delete]
+func (h *AuthHandler) AdminRevokeAPIKey(c fiber.Ctx) error {
+	keyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid api key id")
+	}
+
+	if err = h.APIKeyService.Revoke(c.Context(), uuid.Nil, keyID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,122 @@
+package v1
+
+import (
+	"duels-api/internal/model"
+	"duels-api/pkg/apperrors"
+	auth "duels-api/pkg/jwt"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+func sessionMeta(c fiber.Ctx) model.SessionMeta {
+	return model.SessionMeta{
+		UserAgent: c.Get(fiber.HeaderUserAgent),
+		IP:        c.IP(),
+	}
+}
+
+// GetSessions godoc
+//
+//	@Summary		List active sessions
+//	@Description	Returns active sessions of the authenticated user with device and IP captured at sign in and the last seen ones, the current session is marked. family_id stays the same across token refreshes and identifies the session for revocation.
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	object{sessions=[]model.Session}	"Active sessions"
+//	@Failure		401	{object}	apperrors.ErrorPublic				"Unauthorized"
+//	@Failure		500	{object}	apperrors.ErrorPublic				"Internal server error"
+//	@Router			/auth/sessions [get]
+func (h *AuthHandler) GetSessions(c fiber.Ctx) error {
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	sessions, err := h.JWTService.GetSessions(c.Context(), claims)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{"sessions": sessions})
+}
+
+// RevokeSession godoc
+//
+//	@Summary		Revoke session
+//	@Description	Ends the session, its refresh and access tokens are rejected from now on.
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Session family ID (UUID)"
+//	@Success		204	{object}	nil						"Session revoked"
+//	@Failure		400	{object}	apperrors.ErrorPublic	"Invalid session ID"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"Session not found"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/auth/sessions/{id} [delete]
+func (h *AuthHandler) RevokeSession(c fiber.Ctx) error {
+	familyID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid session ID", err)
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	if err = h.JWTService.RevokeSession(c.Context(), claims.UserID, familyID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Logout godoc
+//
+//	@Summary		Log out
+//	@Description	Ends the current session.
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		204	{object}	nil						"Logged out"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/auth/logout [post]
+func (h *AuthHandler) Logout(c fiber.Ctx) error {
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	if err := h.JWTService.Logout(c.Context(), claims); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// LogoutAll godoc
+//
+//	@Summary		Log out everywhere
+//	@Description	Ends all sessions of the authenticated user, including the current one.
+//	@Tags			auth
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		204	{object}	nil						"Logged out everywhere"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/auth/logout-all [post]
+func (h *AuthHandler) LogoutAll(c fiber.Ctx) error {
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	if err := h.JWTService.RevokeAllSessions(c.Context(), claims.UserID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
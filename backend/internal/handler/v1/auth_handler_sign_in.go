@@ -7,10 +7,39 @@ import (
 	"github.com/gofiber/fiber/v3"
 )
 
+// IssueSignInChallenge godoc
+//
+//	@Summary		Issue wallet sign in challenge
+//	@Description	Issues a short-lived, single-use Sign-In With Solana message for the wallet. The wallet signs the returned message and sends the signature with the nonce to /auth/sign-in-wallet.
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			request	body		model.SignInChallengeReq	true	"Wallet address"
+//	@Success		200		{object}	model.SignInChallengeResp	"Challenge to sign"
+//	@Failure		400		{object}	apperrors.ErrorPublic		"Invalid request body"
+//	@Failure		500		{object}	apperrors.ErrorPublic		"Internal server error"
+//	@Router			/auth/challenge [post]
+func (h *AuthHandler) IssueSignInChallenge(c fiber.Ctx) error {
+	var req model.SignInChallengeReq
+	if err := c.Bind().JSON(&req); err != nil {
+		return apperrors.BadRequest("invalid request body")
+	}
+
+	challenge, err := h.UserService.IssueSignInChallenge(c.Context(), req.Address)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(model.SignInChallengeResp{
+		SignInChallenge: challenge,
+		Message:         challenge.Message(),
+	})
+}
+
 // SignInWithWallet godoc
 //
 //	@Summary		Sign in with crypto wallet
-//	@Description	Authenticates a user using a connected crypto wallet (e.g., Solana Phantom). On success returns the user profile and a new JWT token pair.
+//	@Description	Authenticates a user using a connected crypto wallet (e.g., Solana Phantom). The secret is the base64 signature of the message issued by /auth/challenge, each challenge can be used once. On success returns the user profile and a new JWT token pair.
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
@@ -36,7 +65,7 @@ func (h *AuthHandler) SignInWithWallet(c fiber.Ctx) error {
 		return apperrors.Internal("failed to generate token claims for user")
 	}
 
-	tokenPair, err := h.JWTService.GenerateTokenPair(c.Context(), claims)
+	tokenPair, err := h.JWTService.GenerateTokenPair(c.Context(), claims, sessionMeta(c))
 	if err != nil {
 		return err
 	}
@@ -63,7 +92,7 @@ func (h *AuthHandler) SignInWithWallet(c fiber.Ctx) error {
 func (h *AuthHandler) RefreshTokens(c fiber.Ctx) error {
 	token := c.Get("Authorization")
 
-	tokenPair, err := h.JWTService.RefreshSession(c.Context(), token)
+	tokenPair, err := h.JWTService.RefreshSession(c.Context(), token, sessionMeta(c))
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,309 @@
+package v1
+
+import (
+	"duels-api/internal/handler/middleware"
+	"duels-api/internal/model"
+	"duels-api/internal/service"
+	"duels-api/pkg/apperrors"
+	auth "duels-api/pkg/jwt"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type CommentHandler struct {
+	CommentService *service.CommentService
+}
+
+func NewCommentHandler(
+	commentService *service.CommentService,
+) *CommentHandler {
+	return &CommentHandler{
+		CommentService: commentService,
+	}
+}
+
+func (h *CommentHandler) RegisterRoutes(app *fiber.App, auth *AuthHandler) {
+	commentGroup := app.Group("/comments")
+	{
+		commentGroup.Get("/duel/:id<guid>", h.GetDuelComments)
+		commentGroup.Get("/:id<guid>/replies", h.GetReplies)
+
+		commentGroup.Post("/", auth.AuthMiddleware, auth.RateLimit(middleware.LimitPolicyComment), h.CreateComment)
+		commentGroup.Put("/:id<guid>", auth.AuthMiddleware, h.UpdateComment)
+		commentGroup.Delete("/:id<guid>", auth.AuthMiddleware, h.DeleteComment)
+
+		commentGroup.Post("/:id<guid>/pin", auth.AuthMiddleware, h.PinComment)
+		commentGroup.Delete("/:id<guid>/pin", auth.AuthMiddleware, h.UnpinComment)
+
+		commentGroup.Delete("/:id<guid>/moderation", auth.AuthMiddleware, auth.AdminMiddleware, h.RemoveComment)
+	}
+}
+
+// GetDuelComments godoc
+//
+//	@Summary		Get duel comments
+//	@Description	Returns top-level comments of the duel, the latest first. The pinned comment is returned separately on the first page.
+//	@Description	Deleted and removed comments keep their place in threads with an empty text, status is 0 active, 1 deleted by the author and 2 removed by a moderator.
+//	@Tags			comment
+//	@Produce		json
+//	@Param			id		path		string					true	"Duel ID (UUID)"
+//	@Param			cursor	query		string					false	"next_cursor of the previous page"
+//	@Param			limit	query		int						false	"Page size, up to 100"	default(20)
+//	@Success		200		{object}	model.CommentPage		"Comments"
+//	@Failure		400		{object}	apperrors.ErrorPublic	"Invalid cursor"
+//	@Failure		404		{object}	apperrors.ErrorPublic	"Duel not found"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/comments/duel/{id} [get]
+func (h *CommentHandler) GetDuelComments(c fiber.Ctx) error {
+	duelID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid duel ID", err)
+	}
+
+	var req model.CommentsReq
+	if err = c.Bind().Query(&req); err != nil {
+		return apperrors.BadRequest("invalid request params")
+	}
+
+	page, err := h.CommentService.GetThreads(c.Context(), duelID, &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(page)
+}
+
+// GetReplies godoc
+//
+//	@Summary		Get comment replies
+//	@Description	Returns replies of the thread the comment belongs to in the order they were written. parent_id of a reply is the comment it answers.
+//	@Tags			comment
+//	@Produce		json
+//	@Param			id		path		string					true	"Comment ID (UUID)"
+//	@Param			cursor	query		string					false	"next_cursor of the previous page"
+//	@Param			limit	query		int						false	"Page size, up to 100"	default(20)
+//	@Success		200		{object}	model.CommentPage		"Replies"
+//	@Failure		400		{object}	apperrors.ErrorPublic	"Invalid cursor"
+//	@Failure		404		{object}	apperrors.ErrorPublic	"Comment not found"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/comments/{id}/replies [get]
+func (h *CommentHandler) GetReplies(c fiber.Ctx) error {
+	commentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid comment ID", err)
+	}
+
+	var req model.CommentsReq
+	if err = c.Bind().Query(&req); err != nil {
+		return apperrors.BadRequest("invalid request params")
+	}
+
+	page, err := h.CommentService.GetReplies(c.Context(), commentID, &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(page)
+}
+
+// CreateComment godoc
+//
+//	@Summary		Create comment
+//	@Description	Comments the duel or replies to a comment if parent_id is set. Replies to replies join the thread of the top-level comment.
+//	@Description	The comment is pushed to WebSocket subscribers of the duel topic and the author of the parent comment is notified.
+//	@Tags			comment
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		model.CreateCommentReq	true	"Comment"
+//	@Success		201		{object}	model.Comment			"Created comment"
+//	@Failure		400		{object}	apperrors.ErrorPublic	"Invalid text or parent comment"
+//	@Failure		401		{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		404		{object}	apperrors.ErrorPublic	"Duel or parent comment not found"
+//	@Failure		429		{object}	apperrors.ErrorPublic	"Too many comments - rate limit exceeded"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/comments [post]
+func (h *CommentHandler) CreateComment(c fiber.Ctx) error {
+	var req model.CreateCommentReq
+	if err := c.Bind().JSON(&req); err != nil {
+		return apperrors.BadRequest("invalid request body")
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	comment, err := h.CommentService.Create(c.Context(), claims.UserID, &req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(comment)
+}
+
+// UpdateComment godoc
+//
+//	@Summary		Edit comment
+//	@Description	Replaces the text of an own comment, edited_at is set. The comment is pushed to WebSocket subscribers of the duel topic.
+//	@Tags			comment
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id		path		string					true	"Comment ID (UUID)"
+//	@Param			request	body		model.UpdateCommentReq	true	"New text"
+//	@Success		200		{object}	model.Comment			"Updated comment"
+//	@Failure		400		{object}	apperrors.ErrorPublic	"Invalid text"
+//	@Failure		401		{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		403		{object}	apperrors.ErrorPublic	"Comment belongs to another user"
+//	@Failure		404		{object}	apperrors.ErrorPublic	"Comment not found"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/comments/{id} [put]
+func (h *CommentHandler) UpdateComment(c fiber.Ctx) error {
+	commentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid comment ID", err)
+	}
+
+	var req model.UpdateCommentReq
+	if err = c.Bind().JSON(&req); err != nil {
+		return apperrors.BadRequest("invalid request body")
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	comment, err := h.CommentService.Update(c.Context(), claims.UserID, commentID, &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(comment)
+}
+
+// DeleteComment godoc
+//
+//	@Summary		Delete comment
+//	@Description	Deletes an own comment. Its replies stay in the thread, the redacted comment is pushed to WebSocket subscribers of the duel topic.
+//	@Tags			comment
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Comment ID (UUID)"
+//	@Success		204	{object}	nil						"Comment deleted"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		403	{object}	apperrors.ErrorPublic	"Comment belongs to another user"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"Comment not found"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/comments/{id} [delete]
+func (h *CommentHandler) DeleteComment(c fiber.Ctx) error {
+	commentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid comment ID", err)
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	if err = h.CommentService.Delete(c.Context(), claims.UserID, commentID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// PinComment godoc
+//
+//	@Summary		Pin comment
+//	@Description	Pins a top-level comment of an own duel, the previously pinned comment is unpinned.
+//	@Tags			comment
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Comment ID (UUID)"
+//	@Success		204	{object}	nil						"Comment pinned"
+//	@Failure		400	{object}	apperrors.ErrorPublic	"Comment is a reply or deleted"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		403	{object}	apperrors.ErrorPublic	"Only the duel owner can pin comments"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"Comment not found"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/comments/{id}/pin [post]
+func (h *CommentHandler) PinComment(c fiber.Ctx) error {
+	commentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid comment ID", err)
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	if err = h.CommentService.Pin(c.Context(), claims.UserID, commentID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// UnpinComment godoc
+//
+//	@Summary		Unpin comment
+//	@Description	Unpins a comment of an own duel.
+//	@Tags			comment
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Comment ID (UUID)"
+//	@Success		204	{object}	nil						"Comment unpinned"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		403	{object}	apperrors.ErrorPublic	"Only the duel owner can pin comments"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"Comment not found"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/comments/{id}/pin [delete]
+func (h *CommentHandler) UnpinComment(c fiber.Ctx) error {
+	commentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid comment ID", err)
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	if err = h.CommentService.Unpin(c.Context(), claims.UserID, commentID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// RemoveComment godoc
+//
+//	@Summary		Remove comment (admin)
+//	@Description	Removes any comment as a moderator. Its replies stay in the thread, the redacted comment is pushed to WebSocket subscribers of the duel topic.
+//	@Tags			admin
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Comment ID (UUID)"
+//	@Success		204	{object}	nil						"Comment removed"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		403	{object}	apperrors.ErrorPublic	"Admin access required"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"Comment not found"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/comments/{id}/moderation [delete]
+func (h *CommentHandler) RemoveComment(c fiber.Ctx) error {
+	commentID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid comment ID", err)
+	}
+
+	if err = h.CommentService.Remove(c.Context(), commentID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"duels-api/internal/handler/middleware"
 	"duels-api/internal/model"
 	"duels-api/internal/service"
 	"duels-api/pkg/apperrors"
@@ -25,15 +26,25 @@ func NewDuelHandler(
 }
 
 func (h *DuelHandler) RegisterRoutes(app *fiber.App, auth *AuthHandler) {
-	cryptoDuel := app.Group("/crypto-duel", auth.AuthMiddleware)
+	cryptoDuel := app.Group("/crypto-duel")
 	{
 		solana := cryptoDuel.Group("/solana")
 		{
-			solana.Post("/", h.CreateExternalWalletCryptoDuel)         // create after client sent tx
-			solana.Post("/sign-tx", h.SignCreateCryptoDuelTransaction) // server builds raw init tx
-			solana.Post("/join", h.JoinExternalWalletCryptoDuel)       // join after client sent tx
-			solana.Post("/join/sign-tx", h.SignJoinCryptoDuelTransaction)
-			solana.Put("/resolve", h.ResolveCryptoDuelByOwner) // payouts from admin wallet
+			// create after client sent tx
+			solana.Post("/", auth.AuthMiddleware,
+				auth.RateLimit(middleware.LimitPolicyCreate), h.CreateExternalWalletCryptoDuel)
+			// server builds raw init tx
+			solana.Post("/sign-tx", auth.AuthMiddleware,
+				auth.RateLimit(middleware.LimitPolicySignTx), h.SignCreateCryptoDuelTransaction)
+			// join after client sent tx
+			solana.Post("/join", auth.AuthMiddleware,
+				auth.RateLimit(middleware.LimitPolicyJoin), h.JoinExternalWalletCryptoDuel)
+			solana.Post("/join/sign-tx", auth.AuthOrAPIKeyMiddleware(model.APIKeyScopeSignJoinTx),
+				auth.RateLimit(middleware.LimitPolicySignTx), h.SignJoinCryptoDuelTransaction)
+			// payouts from admin wallet
+			solana.Put("/resolve", auth.AuthOrAPIKeyMiddleware(model.APIKeyScopeResolveDuels),
+				auth.RateLimit(middleware.LimitPolicyResolve), h.ResolveCryptoDuelByOwner)
+			solana.Get("/operations/:id", auth.AuthMiddleware, h.GetCryptoDuelOperation)
 		}
 	}
 
@@ -44,11 +55,12 @@ func (h *DuelHandler) RegisterRoutes(app *fiber.App, auth *AuthHandler) {
 		public.Get("/:id", h.GetDuelByIDPublic)
 	}
 
-	duel := app.Group("/duel", auth.AuthMiddleware)
+	duel := app.Group("/duel", auth.AuthOrAPIKeyMiddleware(model.APIKeyScopeReadDuels))
 	{
 		duel.Get("/all", h.GetAllDuelsAuthorized)
 		duel.Get("/my", h.GetMyDuels)
 		duel.Get("/my/participant", h.GetMyDuelsAsParticipant)
+		duel.Get("/feed", h.GetFeed)
 		duel.Get("/:id", h.GetDuelByIDAuthorized)
 	}
 }
@@ -56,15 +68,17 @@ func (h *DuelHandler) RegisterRoutes(app *fiber.App, auth *AuthHandler) {
 // CreateExternalWalletCryptoDuel godoc
 //
 //	@Summary		Create crypto duel (external Solana wallet)
-//	@Description	Creates a crypto duel after the client has submitted an init transaction from an external wallet. The backend validates on-chain data and persists the duel.
+//	@Description	Accepts the init transaction submitted by the client from an external wallet. The backend validates on-chain data and persists the duel in background, reporting each stage over WebSocket.
+//	@Description	Uploaded images in image_url and bg_url must be uploaded by the caller.
 //	@Tags			duel
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			request	body		model.CreateDuelReq			true	"Create duel request"
-//	@Success		200		{object}	model.CreateCryptoDuelResp	"Duel created"
+//	@Success		202		{object}	model.TrackedSignature		"Pending operation, duel_id is the id of the duel to be created"
 //	@Failure		400		{object}	apperrors.ErrorPublic		"Invalid request"
 //	@Failure		401		{object}	apperrors.ErrorPublic		"Unauthorized"
+//	@Failure		429		{object}	apperrors.ErrorPublic		"Too many requests - rate limit exceeded"
 //	@Failure		500		{object}	apperrors.ErrorPublic		"Internal error"
 //	@Router			/crypto-duel/solana [post]
 func (h *DuelHandler) CreateExternalWalletCryptoDuel(c fiber.Ctx) error {
@@ -78,18 +92,19 @@ func (h *DuelHandler) CreateExternalWalletCryptoDuel(c fiber.Ctx) error {
 		return apperrors.Unauthorized("claims not found")
 	}
 
-	resp, err := h.DuelService.CreateCryptoDuel(c.Context(), claims.UserID, &req)
+	operation, err := h.DuelService.CreateCryptoDuel(c.Context(), claims.UserID, &req)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(resp)
+	return c.Status(fiber.StatusAccepted).JSON(operation)
 }
 
 // SignCreateCryptoDuelTransaction godoc
 //
 //	@Summary		Build unsigned init transaction for creating a crypto duel
 //	@Description	Builds a base64-encoded Solana init transaction. The client signs and sends it using an external wallet (e.g., Phantom/Solflare).
+//	@Description	Uploaded images in image_url and bg_url must be uploaded by the caller.
 //	@Tags			duel
 //	@Accept			json
 //	@Produce		json
@@ -122,13 +137,13 @@ func (h *DuelHandler) SignCreateCryptoDuelTransaction(c fiber.Ctx) error {
 // JoinExternalWalletCryptoDuel godoc
 //
 //	@Summary		Join crypto duel (external Solana wallet)
-//	@Description	Records user participation in a crypto duel after the client submits a join transaction from an external wallet.
+//	@Description	Accepts the join transaction submitted by the client from an external wallet. User participation is recorded in background, each stage is reported over WebSocket.
 //	@Tags			duel
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			request	body		model.JoinDuelReq			true	"Duel ID, answer (0/1), optional tx hash"
-//	@Success		200		{object}	model.JoinCryptoDuelResp	"Joined successfully"
+//	@Success		202		{object}	model.TrackedSignature		"Pending operation"
 //	@Failure		400		{object}	apperrors.ErrorPublic		"Invalid request"
 //	@Failure		401		{object}	apperrors.ErrorPublic		"Unauthorized"
 //	@Failure		404		{object}	apperrors.ErrorPublic		"Duel not found"
@@ -145,12 +160,45 @@ func (h *DuelHandler) JoinExternalWalletCryptoDuel(c fiber.Ctx) error {
 		return apperrors.Unauthorized("claims not found")
 	}
 
-	resp, err := h.DuelService.JoinExternalWalletCryptoDuel(c.Context(), claims.UserID, &req)
+	operation, err := h.DuelService.JoinExternalWalletCryptoDuel(c.Context(), claims.UserID, &req)
 	if err != nil {
 		return err
 	}
 
-	return c.JSON(resp)
+	return c.Status(fiber.StatusAccepted).JSON(operation)
+}
+
+// GetCryptoDuelOperation godoc
+//
+//	@Summary		Get crypto duel operation
+//	@Description	Returns the current stage of a pending create or join operation. Fallback for clients without WebSocket connection.
+//	@Tags			duel
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"Operation ID (UUID)"
+//	@Success		200	{object}	model.TrackedSignature	"Operation"
+//	@Failure		400	{object}	apperrors.ErrorPublic	"Invalid operation ID"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"Operation not found"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal error"
+//	@Router			/crypto-duel/solana/operations/{id} [get]
+func (h *DuelHandler) GetCryptoDuelOperation(c fiber.Ctx) error {
+	operationID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid operation ID", err)
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	operation, err := h.DuelService.GetCryptoDuelOperation(c.Context(), claims.UserID, operationID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(operation)
 }
 
 // SignJoinCryptoDuelTransaction godoc
@@ -161,6 +209,7 @@ func (h *DuelHandler) JoinExternalWalletCryptoDuel(c fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Security		ApiKeyAuth
 //	@Param			request	body		model.JoinDuelReq		true	"Duel ID and answer (0/1)"
 //	@Success		200		{object}	object{tx=string}	"Unsigned base64 transaction"
 //	@Failure		400		{object}	apperrors.ErrorPublic	"Invalid request"
@@ -194,6 +243,7 @@ func (h *DuelHandler) SignJoinCryptoDuelTransaction(c fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Security		ApiKeyAuth
 //	@Param			request	body		model.DuelResolveReq		true	"Duel ID and final answer (0/1)"
 //	@Success		200		{object}	object{tx_hashes=[]string}	"Distribution transaction hashes"
 //	@Failure		400		{object}	apperrors.ErrorPublic		"Invalid request"
@@ -310,6 +360,7 @@ func (h *DuelHandler) GetDuelByIDPublic(c fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Security		ApiKeyAuth
 //	@Param			opts.pagination.page_size	query		uint64					false	"Page size"					default(10)
 //	@Param			opts.pagination.page_num	query		uint64					false	"Page number (starts at 1)"	default(1)
 //	@Param			opts.order.order_by			query		string					false	"Order by field"
@@ -339,6 +390,44 @@ func (h *DuelHandler) GetAllDuelsAuthorized(c fiber.Ctx) error {
 	return c.JSON(duels)
 }
 
+// GetFeed godoc
+//
+//	@Summary		Followed creators feed
+//	@Description	Returns active duels created by users the authenticated user follows, the newest first unless ordered otherwise.
+//	@Tags			duel
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Security		ApiKeyAuth
+//	@Param			opts.pagination.page_size	query		uint64					false	"Page size"					default(10)
+//	@Param			opts.pagination.page_num	query		uint64					false	"Page number (starts at 1)"	default(1)
+//	@Param			opts.order.order_by			query		string					false	"Order by field"
+//	@Param			opts.order.order_type		query		string					false	"Order type"	Enums(desc,asc)
+//	@Param			opts.filters[0].column		query		string					false	"Filter column"
+//	@Param			opts.filters[0].operator	query		string					false	"Filter operator"
+//	@Param			opts.filters[0].value		query		string					false	"Filter value"
+//	@Param			opts.filters[0].where_or	query		bool					false	"Use OR between filters"
+//	@Success		200							{array}		model.DuelShow			"Duels of followed creators"
+//	@Failure		400							{object}	apperrors.ErrorPublic	"Bad request"
+//	@Failure		401							{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		500							{object}	apperrors.ErrorPublic	"Internal error"
+//	@Router			/duel/feed [get]
+func (h *DuelHandler) GetFeed(c fiber.Ctx) error {
+	var req model.OptsReq
+	if err := c.Bind().Query(&req); err != nil {
+		return apperrors.BadRequest("invalid request params")
+	}
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+	duels, err := h.DuelService.GetFeed(c.Context(), claims.UserID, &req.Opts)
+	if err != nil {
+		return err
+	}
+	return c.JSON(duels)
+}
+
 // GetMyDuels godoc
 //
 //	@Summary		List my duels
@@ -347,6 +436,7 @@ func (h *DuelHandler) GetAllDuelsAuthorized(c fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Security		ApiKeyAuth
 //	@Param			opts.pagination.page_size	query		uint64					false	"Page size"					default(10)
 //	@Param			opts.pagination.page_num	query		uint64					false	"Page number (starts at 1)"	default(1)
 //	@Param			opts.order.order_by			query		string					false	"Order by field"
@@ -400,6 +490,7 @@ func (h *DuelHandler) GetMyDuelsAsParticipant(c fiber.Ctx) error {
 //	@Accept			json
 //	@Produce		json
 //	@Security		BearerAuth
+//	@Security		ApiKeyAuth
 //	@Param			id	path		string													true	"Duel ID (UUID)"
 //	@Success		200	{object}	object{duel=model.DuelShow,players=[]model.PlayerShow}	"Duel and players"
 //	@Failure		400	{object}	apperrors.ErrorPublic									"Invalid duel ID"
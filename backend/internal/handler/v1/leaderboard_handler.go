@@ -0,0 +1,93 @@
+package v1
+
+import (
+	"duels-api/internal/model"
+	"duels-api/internal/service"
+	"duels-api/pkg/apperrors"
+	auth "duels-api/pkg/jwt"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+type LeaderboardHandler struct {
+	LeaderboardService *service.LeaderboardService
+}
+
+func NewLeaderboardHandler(
+	leaderboardService *service.LeaderboardService,
+) *LeaderboardHandler {
+	return &LeaderboardHandler{
+		LeaderboardService: leaderboardService,
+	}
+}
+
+func (h *LeaderboardHandler) RegisterRoutes(app *fiber.App, auth *AuthHandler) {
+	leaderboardGroup := app.Group("/leaderboard")
+	{
+		leaderboardGroup.Get("/", h.GetLeaderboard)
+		leaderboardGroup.Get("/me", auth.AuthMiddleware, h.GetMyRank)
+	}
+}
+
+// GetLeaderboard godoc
+//
+//	@Summary		Get leaderboard
+//	@Description	Returns players ranked by the metric over the period. Leaderboards are precomputed every 10 minutes, refreshed_at is the time of the last refresh.
+//	@Description	Win rate ranks players with a minimum number of resolved duels, commission ranks duel creators.
+//	@Tags			leaderboard
+//	@Produce		json
+//	@Param			period		query		string					false	"Rolling window over duel resolution time"	Enums(daily,weekly,monthly,all_time)	default(all_time)
+//	@Param			metric		query		string					false	"Ranking metric"							Enums(net_profit,wins,win_rate,volume,commission)	default(net_profit)
+//	@Param			page_size	query		int						false	"Page size, up to 100"						default(50)
+//	@Param			page_num	query		int						false	"Page number (starts at 1)"					default(1)
+//	@Success		200			{object}	model.Leaderboard		"Leaderboard"
+//	@Failure		400			{object}	apperrors.ErrorPublic	"Invalid period or metric"
+//	@Failure		500			{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/leaderboard [get]
+func (h *LeaderboardHandler) GetLeaderboard(c fiber.Ctx) error {
+	var req model.LeaderboardReq
+	if err := c.Bind().Query(&req); err != nil {
+		return apperrors.BadRequest("invalid request params")
+	}
+
+	leaderboard, err := h.LeaderboardService.GetLeaderboard(c.Context(), &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(leaderboard)
+}
+
+// GetMyRank godoc
+//
+//	@Summary		Get my rank
+//	@Description	Returns the rank of the authenticated user by the metric over the period.
+//	@Tags			leaderboard
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			period	query		string					false	"Rolling window over duel resolution time"	Enums(daily,weekly,monthly,all_time)	default(all_time)
+//	@Param			metric	query		string					false	"Ranking metric"							Enums(net_profit,wins,win_rate,volume,commission)	default(net_profit)
+//	@Success		200		{object}	model.LeaderboardEntry	"Rank of the user"
+//	@Failure		400		{object}	apperrors.ErrorPublic	"Invalid period or metric"
+//	@Failure		401		{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		404		{object}	apperrors.ErrorPublic	"User is not ranked"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/leaderboard/me [get]
+func (h *LeaderboardHandler) GetMyRank(c fiber.Ctx) error {
+	var req model.LeaderboardReq
+	if err := c.Bind().Query(&req); err != nil {
+		return apperrors.BadRequest("invalid request params")
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	entry, err := h.LeaderboardService.GetUserRank(c.Context(), claims.UserID, &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(entry)
+}
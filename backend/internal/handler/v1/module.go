@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"duels-api/internal/client/ws"
 	"duels-api/internal/handler/v1/swagger"
 	"github.com/gofiber/fiber/v3"
 	"go.uber.org/fx"
@@ -14,6 +15,9 @@ func Module() fx.Option {
 			NewDuelHandler,
 			NewNotificationHandler,
 			NewWSHandler,
+			NewLeaderboardHandler,
+			NewSeasonHandler,
+			NewCommentHandler,
 			swagger.NewSwaggerHandler,
 		),
 		fx.Invoke(func(app *fiber.App, authHandler *AuthHandler) {
@@ -28,9 +32,26 @@ func Module() fx.Option {
 		fx.Invoke(func(app *fiber.App, auth *AuthHandler, wsHandler *WSHandler) {
 			wsHandler.RegisterRoutes(app, auth)
 		}),
+		// the hub hooks are appended after the server ones, so on stop open
+		// connections are drained before the server waits for them
+		fx.Invoke(func(lc fx.Lifecycle, hub *ws.Hub) {
+			lc.Append(fx.Hook{
+				OnStart: hub.Start,
+				OnStop:  hub.Stop,
+			})
+		}),
 		fx.Invoke(func(app *fiber.App, auth *AuthHandler, notificationHandler *NotificationHandler) {
 			notificationHandler.RegisterRoutes(app, auth)
 		}),
+		fx.Invoke(func(app *fiber.App, auth *AuthHandler, leaderboardHandler *LeaderboardHandler) {
+			leaderboardHandler.RegisterRoutes(app, auth)
+		}),
+		fx.Invoke(func(app *fiber.App, auth *AuthHandler, seasonHandler *SeasonHandler) {
+			seasonHandler.RegisterRoutes(app, auth)
+		}),
+		fx.Invoke(func(app *fiber.App, auth *AuthHandler, commentHandler *CommentHandler) {
+			commentHandler.RegisterRoutes(app, auth)
+		}),
 		fx.Invoke(func(app *fiber.App, swaggerHandler *swagger.Handler) {
 			swaggerHandler.RegisterRoutes(app)
 		}),
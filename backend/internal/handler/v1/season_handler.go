@@ -0,0 +1,169 @@
+package v1
+
+import (
+	"duels-api/internal/model"
+	"duels-api/internal/service"
+	"duels-api/pkg/apperrors"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+type SeasonHandler struct {
+	SeasonService *service.SeasonService
+}
+
+func NewSeasonHandler(
+	seasonService *service.SeasonService,
+) *SeasonHandler {
+	return &SeasonHandler{
+		SeasonService: seasonService,
+	}
+}
+
+func (h *SeasonHandler) RegisterRoutes(app *fiber.App, auth *AuthHandler) {
+	seasonGroup := app.Group("/seasons")
+	{
+		seasonGroup.Get("/current", h.GetCurrentSeason)
+		seasonGroup.Get("/past", h.GetPastSeasons)
+		seasonGroup.Get("/:id<guid>/standings", h.GetStandings)
+		seasonGroup.Get("/:id<guid>/results", h.GetResults)
+
+		seasonGroup.Post("/", auth.AuthMiddleware, auth.AdminMiddleware, h.AdminCreateSeason)
+	}
+}
+
+// GetCurrentSeason godoc
+//
+//	@Summary		Get current season
+//	@Description	Returns the running season. Players earn season points for joining and winning duels while it runs.
+//	@Tags			season
+//	@Produce		json
+//	@Success		200	{object}	model.Season			"Current season"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"No season is running"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/seasons/current [get]
+func (h *SeasonHandler) GetCurrentSeason(c fiber.Ctx) error {
+	season, err := h.SeasonService.GetCurrent(c.Context())
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(season)
+}
+
+// GetPastSeasons godoc
+//
+//	@Summary		Get past seasons
+//	@Description	Returns ended seasons, the latest first. Status is 0 before settlement, 1 while prizes are paid out and 2 once all prizes are paid.
+//	@Tags			season
+//	@Produce		json
+//	@Param			page_size	query		int						false	"Page size, up to 100"		default(50)
+//	@Param			page_num	query		int						false	"Page number (starts at 1)"	default(1)
+//	@Success		200			{array}		model.Season			"Past seasons"
+//	@Failure		400			{object}	apperrors.ErrorPublic	"Invalid request params"
+//	@Failure		500			{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/seasons/past [get]
+func (h *SeasonHandler) GetPastSeasons(c fiber.Ctx) error {
+	var req model.SeasonPageReq
+	if err := c.Bind().Query(&req); err != nil {
+		return apperrors.BadRequest("invalid request params")
+	}
+
+	seasons, err := h.SeasonService.GetPastSeasons(c.Context(), &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(seasons)
+}
+
+// GetStandings godoc
+//
+//	@Summary		Get season standings
+//	@Description	Returns players ranked by season points, ties are broken by wins and then by who reached the points first.
+//	@Tags			season
+//	@Produce		json
+//	@Param			id			path		string					true	"Season ID (UUID)"
+//	@Param			page_size	query		int						false	"Page size, up to 100"		default(50)
+//	@Param			page_num	query		int						false	"Page number (starts at 1)"	default(1)
+//	@Success		200			{object}	model.SeasonStandings	"Season standings"
+//	@Failure		400			{object}	apperrors.ErrorPublic	"Invalid request params"
+//	@Failure		404			{object}	apperrors.ErrorPublic	"Season not found"
+//	@Failure		500			{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/seasons/{id}/standings [get]
+func (h *SeasonHandler) GetStandings(c fiber.Ctx) error {
+	seasonID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid season ID", err)
+	}
+
+	var req model.SeasonPageReq
+	if err = c.Bind().Query(&req); err != nil {
+		return apperrors.BadRequest("invalid request params")
+	}
+
+	standings, err := h.SeasonService.GetStandings(c.Context(), seasonID, &req)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(standings)
+}
+
+// GetResults godoc
+//
+//	@Summary		Get season results
+//	@Description	Returns the prizes of an ended season by rank. Prize status is 0 pending, 1 paid and 2 failed, failed transfers are retried.
+//	@Tags			season
+//	@Produce		json
+//	@Param			id	path		string					true	"Season ID (UUID)"
+//	@Success		200	{object}	model.SeasonResults		"Season results"
+//	@Failure		400	{object}	apperrors.ErrorPublic	"Season has not ended yet"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"Season not found"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/seasons/{id}/results [get]
+func (h *SeasonHandler) GetResults(c fiber.Ctx) error {
+	seasonID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid season ID", err)
+	}
+
+	results, err := h.SeasonService.GetResults(c.Context(), seasonID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(results)
+}
+
+// AdminCreateSeason godoc
+//
+//	@Summary		Create season (admin)
+//	@Description	Schedules a season, seasons must not overlap. Prize split is the percent of the prize pool per rank starting at the first place, it must not exceed 100 in total.
+//	@Description	Prizes are paid in USDC from the admin wallet once the season ends.
+//	@Tags			admin
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		model.CreateSeasonReq	true	"Season params"
+//	@Success		201		{object}	model.Season			"Season"
+//	@Failure		400		{object}	apperrors.ErrorPublic	"Invalid season params"
+//	@Failure		401		{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		403		{object}	apperrors.ErrorPublic	"Admin access required"
+//	@Failure		409		{object}	apperrors.ErrorPublic	"Season overlaps with another season"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/seasons [post]
+func (h *SeasonHandler) AdminCreateSeason(c fiber.Ctx) error {
+	var req model.CreateSeasonReq
+	if err := c.Bind().JSON(&req); err != nil {
+		return apperrors.BadRequest("invalid request body")
+	}
+
+	season, err := h.SeasonService.Create(c.Context(), &req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(season)
+}
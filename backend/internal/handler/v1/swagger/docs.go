@@ -15,108 +15,102 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/auth/refresh": {
-            "post": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Refreshes the user's session using the refresh token supplied in the Authorization header. Returns a new access/refresh token pair.",
-                "consumes": [
-                    "application/json"
-                ],
+        "/.well-known/jwks.json": {
+            "get": {
+                "description": "Publishes the public keys tokens are signed with as a JSON Web Key Set, keys are matched by the kid header of the token.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "auth"
                 ],
-                "summary": "Refresh JWT tokens",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "default": "Bearer \u003crefresh_token\u003e",
-                        "description": "Bearer refresh token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                "summary": "Token verification keys",
+                "responses": {
+                    "200": {
+                        "description": "Verification keys",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_jwt.JWKSet"
+                        }
                     }
+                }
+            }
+        },
+        "/achievements": {
+            "get": {
+                "description": "Returns all achievements with the XP awarded on unlock, profiles refer to them by code.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
                 ],
+                "summary": "List achievements",
                 "responses": {
                     "200": {
-                        "description": "Tokens refreshed",
+                        "description": "Achievements",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "jwt_info": {
-                                    "$ref": "#/definitions/duels-api_pkg_jwt.TokenPair"
-                                }
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/duels-api_internal_model.Achievement"
                             }
                         }
-                    },
-                    "401": {
-                        "description": "Unauthorized - invalid or expired refresh token",
-                        "schema": {
-                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
-                        }
-                    },
-                    "500": {
-                        "description": "Internal server error",
-                        "schema": {
-                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
-                        }
                     }
                 }
             }
         },
-        "/auth/sign-in-wallet": {
-            "post": {
-                "description": "Authenticates a user using a connected crypto wallet (e.g., Solana Phantom). On success returns the user profile and a new JWT token pair.",
-                "consumes": [
-                    "application/json"
+        "/admin/api-keys": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Returns api keys of the given user.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "auth"
+                    "admin"
                 ],
-                "summary": "Sign in with crypto wallet",
+                "summary": "List api keys of a user (admin)",
                 "parameters": [
                     {
-                        "description": "Wallet sign-in payload",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/duels-api_internal_model.AuthWithWallet"
-                        }
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "user_id",
+                        "in": "query",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Authenticated successfully",
+                        "description": "API keys",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "jwt_info": {
-                                    "$ref": "#/definitions/duels-api_pkg_jwt.TokenPair"
-                                },
-                                "user": {
-                                    "$ref": "#/definitions/duels-api_internal_model.User"
+                                "api_keys": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/duels-api_internal_model.APIKey"
+                                    }
                                 }
                             }
                         }
                     },
                     "400": {
-                        "description": "Invalid request body",
+                        "description": "Invalid user ID",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid wallet data/signature",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -128,16 +122,14 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/crypto-duel/solana": {
+            },
             "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Creates a crypto duel after the client has submitted an init transaction from an external wallet. The backend validates on-chain data and persists the duel.",
+                "description": "Issues an api key on behalf of the given user, the rate limit is not capped for admin issued keys.",
                 "consumes": [
                     "application/json"
                 ],
@@ -145,25 +137,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "duel"
+                    "admin"
                 ],
-                "summary": "Create crypto duel (external Solana wallet)",
+                "summary": "Create api key for a user (admin)",
                 "parameters": [
                     {
-                        "description": "Create duel request",
+                        "description": "Owner user ID and key parameters",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/duels-api_internal_model.CreateDuelReq"
+                            "$ref": "#/definitions/duels-api_internal_model.AdminCreateAPIKeyReq"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Duel created",
+                    "201": {
+                        "description": "API key",
                         "schema": {
-                            "$ref": "#/definitions/duels-api_internal_model.CreateCryptoDuelResp"
+                            "$ref": "#/definitions/duels-api_internal_model.IssuedAPIKey"
                         }
                     },
                     "400": {
@@ -178,8 +170,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -187,44 +191,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/crypto-duel/solana/join": {
-            "post": {
+        "/admin/api-keys/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Records user participation in a crypto duel after the client submits a join transaction from an external wallet.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Revokes the api key regardless of its owner.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "duel"
+                    "admin"
                 ],
-                "summary": "Join crypto duel (external Solana wallet)",
+                "summary": "Revoke any api key (admin)",
                 "parameters": [
                     {
-                        "description": "Duel ID, answer (0/1), optional tx hash",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/duels-api_internal_model.JoinDuelReq"
-                        }
+                        "type": "string",
+                        "description": "API key ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Joined successfully",
-                        "schema": {
-                            "$ref": "#/definitions/duels-api_internal_model.JoinCryptoDuelResp"
-                        }
+                    "204": {
+                        "description": "API key revoked"
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid api key ID",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -235,14 +231,20 @@ const docTemplate = `{
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
                     "404": {
-                        "description": "Duel not found",
+                        "description": "API key not found",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -250,53 +252,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/crypto-duel/solana/join/sign-tx": {
-            "post": {
+        "/auth/api-keys": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Builds a base64-encoded Solana join transaction for the client to sign in an external wallet.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns api keys of the authenticated user including revoked ones. The keys themselves are never returned, only their prefixes.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "duel"
-                ],
-                "summary": "Build unsigned join transaction for a crypto duel",
-                "parameters": [
-                    {
-                        "description": "Duel ID and answer (0/1)",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/duels-api_internal_model.JoinDuelReq"
-                        }
-                    }
+                    "auth"
                 ],
+                "summary": "List api keys",
                 "responses": {
                     "200": {
-                        "description": "Unsigned base64 transaction",
+                        "description": "API keys",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "tx": {
-                                    "type": "string"
+                                "api_keys": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/duels-api_internal_model.APIKey"
+                                    }
                                 }
                             }
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
-                        }
-                    },
                     "401": {
                         "description": "Unauthorized",
                         "schema": {
@@ -304,22 +289,20 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     }
                 }
-            }
-        },
-        "/crypto-duel/solana/resolve": {
-            "put": {
+            },
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Allows the duel owner to resolve the duel and trigger payouts. Returns transaction hashes of on-chain transfers.",
+                "description": "Issues an api key for bots and integrators acting on behalf of the user. Scopes: duels:read, duels:join, duels:resolve. The key is shown only once, pass it in the X-API-Key header.",
                 "consumes": [
                     "application/json"
                 ],
@@ -327,33 +310,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "duel"
+                    "auth"
                 ],
-                "summary": "Resolve crypto duel by owner (payouts)",
+                "summary": "Create api key",
                 "parameters": [
                     {
-                        "description": "Duel ID and final answer (0/1)",
+                        "description": "Key name, scopes, rate limit per minute and optional expiration",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/duels-api_internal_model.DuelResolveReq"
+                            "$ref": "#/definitions/duels-api_internal_model.CreateAPIKeyReq"
                         }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Distribution transaction hashes",
+                    "201": {
+                        "description": "API key",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "tx_hashes": {
-                                    "type": "array",
-                                    "items": {
-                                        "type": "string"
-                                    }
-                                }
-                            }
+                            "$ref": "#/definitions/duels-api_internal_model.IssuedAPIKey"
                         }
                     },
                     "400": {
@@ -369,7 +344,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -377,49 +352,36 @@ const docTemplate = `{
                 }
             }
         },
-        "/crypto-duel/solana/sign-tx": {
-            "post": {
+        "/auth/api-keys/{id}": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Builds a base64-encoded Solana init transaction. The client signs and sends it using an external wallet (e.g., Phantom/Solflare).",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Revokes the api key of the authenticated user, requests with it are rejected from now on.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "duel"
+                    "auth"
                 ],
-                "summary": "Build unsigned init transaction for creating a crypto duel",
+                "summary": "Revoke api key",
                 "parameters": [
                     {
-                        "description": "Duel parameters",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/duels-api_internal_model.CreateDuelReq"
-                        }
+                        "type": "string",
+                        "description": "API key ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Unsigned base64 transaction",
-                        "schema": {
-                            "type": "object",
-                            "properties": {
-                                "tx": {
-                                    "type": "string"
-                                }
-                            }
-                        }
+                    "204": {
+                        "description": "API key revoked"
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Invalid api key ID",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -430,8 +392,14 @@ const docTemplate = `{
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
+                    "404": {
+                        "description": "API key not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -439,14 +407,9 @@ const docTemplate = `{
                 }
             }
         },
-        "/duel/all": {
-            "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Returns duels with user-specific flags (e.g., joined, your_answer). Requires authentication.",
+        "/auth/challenge": {
+            "post": {
+                "description": "Issues a short-lived, single-use Sign-In With Solana message for the wallet. The wallet signs the returned message and sends the signature with the nonce to /auth/sign-in-wallet.",
                 "consumes": [
                     "application/json"
                 ],
@@ -454,82 +417,94 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "duel"
+                    "auth"
                 ],
-                "summary": "List duels (authorized, with participation flags)",
+                "summary": "Issue wallet sign in challenge",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "format": "int64",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "opts.pagination.page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "default": 1,
-                        "description": "Page number (starts at 1)",
-                        "name": "opts.pagination.page_num",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order by field",
-                        "name": "opts.order.order_by",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "desc",
-                            "asc"
-                        ],
-                        "type": "string",
-                        "description": "Order type",
-                        "name": "opts.order.order_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter column",
-                        "name": "opts.filters[0].column",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter operator",
-                        "name": "opts.filters[0].operator",
-                        "in": "query"
+                        "description": "Wallet address",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.SignInChallengeReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Challenge to sign",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.SignInChallengeResp"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter value",
-                        "name": "opts.filters[0].value",
-                        "in": "query"
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "security": [
                     {
-                        "type": "boolean",
-                        "description": "Use OR between filters",
-                        "name": "opts.filters[0].where_or",
-                        "in": "query"
+                        "BearerAuth": []
                     }
                 ],
+                "description": "Ends the current session.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log out",
                 "responses": {
-                    "200": {
-                        "description": "Duels with participation flags",
+                    "204": {
+                        "description": "Logged out"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/duels-api_internal_model.DuelShow"
-                            }
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
+                    }
+                }
+            }
+        },
+        "/auth/logout-all": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Ends all sessions of the authenticated user, including the current one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Log out everywhere",
+                "responses": {
+                    "204": {
+                        "description": "Logged out everywhere"
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -538,7 +513,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -546,14 +521,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/duel/my": {
-            "get": {
+        "/auth/refresh": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns duels created by the authenticated user. Supports filtering/sorting/pagination.",
+                "description": "Refreshes the user's session using the refresh token supplied in the Authorization header. Returns a new access/refresh token pair.",
                 "consumes": [
                     "application/json"
                 ],
@@ -561,82 +536,75 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "duel"
+                    "auth"
                 ],
-                "summary": "List my duels",
+                "summary": "Refresh JWT tokens",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "opts.pagination.page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "default": 1,
-                        "description": "Page number (starts at 1)",
-                        "name": "opts.pagination.page_num",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Order by field",
-                        "name": "opts.order.order_by",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "desc",
-                            "asc"
-                        ],
-                        "type": "string",
-                        "description": "Order type",
-                        "name": "opts.order.order_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter column",
-                        "name": "opts.filters[0].column",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter operator",
-                        "name": "opts.filters[0].operator",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Filter value",
-                        "name": "opts.filters[0].value",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Use OR between filters",
-                        "name": "opts.filters[0].where_or",
-                        "in": "query"
+                        "default": "Bearer \u003crefresh_token\u003e",
+                        "description": "Bearer refresh token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "My duels",
+                        "description": "Tokens refreshed",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/duels-api_internal_model.DuelShow"
+                            "type": "object",
+                            "properties": {
+                                "jwt_info": {
+                                    "$ref": "#/definitions/duels-api_pkg_jwt.TokenPair"
+                                }
                             }
                         }
                     },
-                    "400": {
-                        "description": "Bad request",
+                    "401": {
+                        "description": "Unauthorized - invalid or expired refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
+                    }
+                }
+            }
+        },
+        "/auth/sessions": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns active sessions of the authenticated user with device and IP captured at sign in and the last seen ones, the current session is marked. family_id stays the same across token refreshes and identifies the session for revocation.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "List active sessions",
+                "responses": {
+                    "200": {
+                        "description": "Active sessions",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "sessions": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/duels-api_internal_model.Session"
+                                    }
+                                }
+                            }
+                        }
                     },
                     "401": {
                         "description": "Unauthorized",
@@ -645,7 +613,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -653,97 +621,114 @@ const docTemplate = `{
                 }
             }
         },
-        "/duel/public/all": {
-            "get": {
-                "description": "Public list of duels with filtering/sorting/pagination (no user-specific flags like 'joined').",
-                "consumes": [
-                    "application/json"
+        "/auth/sessions/{id}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
                 ],
+                "description": "Ends the session, its refresh and access tokens are rejected from now on.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "duel-public"
+                    "auth"
                 ],
-                "summary": "List duels (public)",
+                "summary": "Revoke session",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "format": "int64",
-                        "default": 10,
-                        "description": "Page size",
-                        "name": "opts.pagination.page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "default": 1,
-                        "description": "Page number (starts at 1)",
-                        "name": "opts.pagination.page_num",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "default": "created_at",
-                        "description": "Order by field",
-                        "name": "opts.order.order_by",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "desc",
-                            "asc"
-                        ],
                         "type": "string",
-                        "default": "desc",
-                        "description": "Order type",
-                        "name": "opts.order.order_type",
-                        "in": "query"
+                        "description": "Session family ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Session revoked"
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter column",
-                        "name": "opts.filters[0].column",
-                        "in": "query"
+                    "400": {
+                        "description": "Invalid session ID",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter operator",
-                        "name": "opts.filters[0].operator",
-                        "in": "query"
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
                     },
-                    {
-                        "type": "string",
-                        "description": "Filter value",
-                        "name": "opts.filters[0].value",
-                        "in": "query"
+                    "404": {
+                        "description": "Session not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
                     },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sign-in-wallet": {
+            "post": {
+                "description": "Authenticates a user using a connected crypto wallet (e.g., Solana Phantom). The secret is the base64 signature of the message issued by /auth/challenge, each challenge can be used once. On success returns the user profile and a new JWT token pair.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Sign in with crypto wallet",
+                "parameters": [
                     {
-                        "type": "boolean",
-                        "description": "Use OR between filters",
-                        "name": "opts.filters[0].where_or",
-                        "in": "query"
+                        "description": "Wallet sign-in payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.AuthWithWallet"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Duels",
+                        "description": "Authenticated successfully",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/duels-api_internal_model.DuelShow"
+                            "type": "object",
+                            "properties": {
+                                "jwt_info": {
+                                    "$ref": "#/definitions/duels-api_pkg_jwt.TokenPair"
+                                },
+                                "user": {
+                                    "$ref": "#/definitions/duels-api_internal_model.User"
+                                }
                             }
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid wallet data/signature",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -751,9 +736,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/duel/public/count": {
-            "get": {
-                "description": "Public count of duels with optional filtering.",
+        "/comments": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Comments the duel or replies to a comment if parent_id is set. Replies to replies join the thread of the top-level comment.\nThe comment is pushed to WebSocket subscribers of the duel topic and the author of the parent comment is notified.",
                 "consumes": [
                     "application/json"
                 ],
@@ -761,50 +751,53 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "duel-public"
+                    "comment"
                 ],
-                "summary": "Count duels (public)",
+                "summary": "Create comment",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Filter column",
-                        "name": "opts.filters[0].column",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter operator",
-                        "name": "opts.filters[0].operator",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter value",
-                        "name": "opts.filters[0].value",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "Use OR between filters",
-                        "name": "opts.filters[0].where_or",
-                        "in": "query"
+                        "description": "Comment",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.CreateCommentReq"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Total count",
+                    "201": {
+                        "description": "Created comment",
                         "schema": {
-                            "type": "integer"
+                            "$ref": "#/definitions/duels-api_internal_model.Comment"
                         }
                     },
                     "400": {
-                        "description": "Bad request",
+                        "description": "Invalid text or parent comment",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Duel or parent comment not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many comments - rate limit exceeded",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -812,19 +805,16 @@ const docTemplate = `{
                 }
             }
         },
-        "/duel/public/{id}": {
+        "/comments/duel/{id}": {
             "get": {
-                "description": "Returns a duel by ID without authentication.",
-                "consumes": [
-                    "application/json"
-                ],
+                "description": "Returns top-level comments of the duel, the latest first. The pinned comment is returned separately on the first page.\nDeleted and removed comments keep their place in threads with an empty text, status is 0 active, 1 deleted by the author and 2 removed by a moderator.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "duel-public"
+                    "comment"
                 ],
-                "summary": "Get duel by ID (public)",
+                "summary": "Get duel comments",
                 "parameters": [
                     {
                         "type": "string",
@@ -832,17 +822,30 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Duel",
+                        "description": "Comments",
                         "schema": {
-                            "$ref": "#/definitions/duels-api_internal_model.Duel"
+                            "$ref": "#/definitions/duels-api_internal_model.CommentPage"
                         }
                     },
                     "400": {
-                        "description": "Invalid duel ID",
+                        "description": "Invalid cursor",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -854,7 +857,7 @@ const docTemplate = `{
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -862,14 +865,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/duel/{id}": {
-            "get": {
+        "/comments/{id}": {
+            "put": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Returns duel details and players for the authenticated user.",
+                "description": "Replaces the text of an own comment, edited_at is set. The comment is pushed to WebSocket subscribers of the duel topic.",
                 "consumes": [
                     "application/json"
                 ],
@@ -877,38 +880,36 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "duel"
+                    "comment"
                 ],
-                "summary": "Get duel by ID (authorized)",
+                "summary": "Edit comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Duel ID (UUID)",
+                        "description": "Comment ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "New text",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.UpdateCommentReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Duel and players",
+                        "description": "Updated comment",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "duel": {
-                                    "$ref": "#/definitions/duels-api_internal_model.DuelShow"
-                                },
-                                "players": {
-                                    "type": "array",
-                                    "items": {
-                                        "$ref": "#/definitions/duels-api_internal_model.PlayerShow"
-                                    }
-                                }
-                            }
+                            "$ref": "#/definitions/duels-api_internal_model.Comment"
                         }
                     },
                     "400": {
-                        "description": "Invalid duel ID",
+                        "description": "Invalid text",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -919,115 +920,67 @@ const docTemplate = `{
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
+                    "403": {
+                        "description": "Comment belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
                     "404": {
-                        "description": "Duel not found",
+                        "description": "Comment not found",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "500": {
-                        "description": "Internal error",
+                        "description": "Internal server error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     }
                 }
-            }
-        },
-        "/notification": {
-            "get": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve all notifications for the authenticated user",
+                "description": "Deletes an own comment. Its replies stay in the thread, the redacted comment is pushed to WebSocket subscribers of the duel topic.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "comment"
                 ],
-                "summary": "Get all notifications",
+                "summary": "Delete comment",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "default": 10,
-                        "description": "Number of items per page",
-                        "name": "opts.pagination.page_size",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "format": "int64",
-                        "default": 1,
-                        "description": "Page number (starting from 1)",
-                        "name": "opts.pagination.page_num",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "default": "created_at",
-                        "description": "Field to order by",
-                        "name": "opts.order.order_by",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "desc",
-                            "asc"
-                        ],
-                        "type": "string",
-                        "default": "\"\"",
-                        "description": "Order type",
-                        "name": "opts.order.order_type",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "First filter column name",
-                        "name": "opts.filters[0].column",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "First filter operator",
-                        "name": "opts.filters[0].operator",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "First filter value",
-                        "name": "opts.filters[0].value",
-                        "in": "query"
-                    },
-                    {
-                        "type": "boolean",
-                        "description": "First filter OR condition",
-                        "name": "opts.filters[0].where_or",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Authorization Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "Comment ID (UUID)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "List of notifications",
+                    "204": {
+                        "description": "Comment deleted"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/duels-api_internal_model.Notification"
-                            }
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - Invalid or missing claims",
+                    "403": {
+                        "description": "Comment belongs to another user",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -1039,42 +992,50 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
+            }
+        },
+        "/comments/{id}/moderation": {
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Marks all notifications as read for the authenticated user",
+                "description": "Removes any comment as a moderator. Its replies stay in the thread, the redacted comment is pushed to WebSocket subscribers of the duel topic.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "admin"
                 ],
-                "summary": "Mark all notifications as read",
+                "summary": "Remove comment (admin)",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "Comment ID (UUID)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Updated notifications",
+                    "204": {
+                        "description": "Comment removed"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/duels-api_internal_model.Notification"
-                            }
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - Invalid or missing claims",
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -1088,44 +1049,54 @@ const docTemplate = `{
                 }
             }
         },
-        "/notification/unread": {
-            "get": {
+        "/comments/{id}/pin": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Retrieve the number of unread notifications for the authenticated user",
+                "description": "Pins a top-level comment of an own duel, the previously pinned comment is unpinned.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "comment"
                 ],
-                "summary": "Get unread notifications count",
+                "summary": "Pin comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "Comment ID (UUID)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Unread notifications count",
+                    "204": {
+                        "description": "Comment pinned"
+                    },
+                    "400": {
+                        "description": "Comment is a reply or deleted",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "notifications": {
-                                    "type": "integer"
-                                }
-                            }
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - Invalid or missing claims",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "403": {
+                        "description": "Only the duel owner can pin comments",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Comment not found",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -1137,54 +1108,48 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        },
-        "/notification/{id}": {
-            "put": {
+            },
+            "delete": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Mark a single notification as read by ID",
+                "description": "Unpins a comment of an own duel.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "notification"
+                    "comment"
                 ],
-                "summary": "Mark a notification as read",
+                "summary": "Unpin comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "string",
-                        "description": "Notification ID (UUID)",
+                        "description": "Comment ID (UUID)",
                         "name": "id",
                         "in": "path",
                         "required": true
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Updated notification",
+                    "204": {
+                        "description": "Comment unpinned"
+                    },
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
-                            "$ref": "#/definitions/duels-api_internal_model.Notification"
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request data",
+                    "403": {
+                        "description": "Only the duel owner can pin comments",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - Invalid or missing claims",
+                    "404": {
+                        "description": "Comment not found",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -1198,50 +1163,53 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/": {
+        "/comments/{id}/replies": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Returns the authenticated user's profile data based on their JWT token.",
+                "description": "Returns replies of the thread the comment belongs to in the order they were written. parent_id of a reply is the comment it answers.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "comment"
                 ],
-                "summary": "Get current user profile",
+                "summary": "Get comment replies",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "Comment ID (UUID)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "next_cursor of the previous page",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 20,
+                        "description": "Page size, up to 100",
+                        "name": "limit",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved user data",
+                        "description": "Replies",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "user": {
-                                    "$ref": "#/definitions/duels-api_internal_model.User"
-                                }
-                            }
+                            "$ref": "#/definitions/duels-api_internal_model.CommentPage"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized - missing or invalid token",
+                    "400": {
+                        "description": "Invalid cursor",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Comment not found",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -1255,66 +1223,62 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/profile-picture": {
-            "put": {
+        "/crypto-duel/solana": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Allows an authenticated user to upload a new profile image.\nThe uploaded image will replace the existing profile picture and return its new public URL.",
+                "description": "Accepts the init transaction submitted by the client from an external wallet. The backend validates on-chain data and persists the duel in background, reporting each stage over WebSocket.\nUploaded images in image_url and bg_url must be uploaded by the caller.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "duel"
                 ],
-                "summary": "Set user profile picture",
+                "summary": "Create crypto duel (external Solana wallet)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Authorization Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "type": "file",
-                        "description": "Profile image file",
-                        "name": "image",
-                        "in": "formData",
-                        "required": true
+                        "description": "Create duel request",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.CreateDuelReq"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Profile picture successfully updated",
+                    "202": {
+                        "description": "Pending operation, duel_id is the id of the duel to be created",
                         "schema": {
-                            "type": "object",
-                            "properties": {
-                                "image_url": {
-                                    "type": "string"
-                                }
-                            }
+                            "$ref": "#/definitions/duels-api_internal_model.TrackedSignature"
                         }
                     },
                     "400": {
-                        "description": "Invalid request data or missing image file",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - invalid or missing token",
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many requests - rate limit exceeded",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "500": {
-                        "description": "Internal server error during image upload",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -1322,50 +1286,127 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/stats": {
-            "get": {
+        "/crypto-duel/solana/join": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Aggregated duel stats for the authenticated user (participated, wins/losses, earned/lost, etc.)",
+                "description": "Accepts the join transaction submitted by the client from an external wallet. User participation is recorded in background, each stage is reported over WebSocket.",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "duel"
                 ],
-                "summary": "Get user duel stats",
+                "summary": "Join crypto duel (external Solana wallet)",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Authorization Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
+                        "description": "Duel ID, answer (0/1), optional tx hash",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.JoinDuelReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "202": {
+                        "description": "Pending operation",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.TrackedSignature"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Duel not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/crypto-duel/solana/join/sign-tx": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Builds a base64-encoded Solana join transaction for the client to sign in an external wallet.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duel"
+                ],
+                "summary": "Build unsigned join transaction for a crypto duel",
+                "parameters": [
+                    {
+                        "description": "Duel ID and answer (0/1)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.JoinDuelReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Successfully retrieved user duel stats",
+                        "description": "Unsigned base64 transaction",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "stats": {
-                                    "$ref": "#/definitions/duels-api_internal_model.UserStats"
+                                "tx": {
+                                    "type": "string"
                                 }
                             }
                         }
                     },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
                     "401": {
-                        "description": "Unauthorized - missing or invalid token",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "500": {
-                        "description": "Internal server error",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -1373,51 +1414,103 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/upload-images": {
-            "put": {
+        "/crypto-duel/solana/operations/{id}": {
+            "get": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Allows an authenticated user to upload one or more image files.\nEach image is stored, and the service returns an array of accessible URLs.\nUpload rate is limited via middleware to prevent spam (max 3 per minute).",
-                "consumes": [
-                    "multipart/form-data"
-                ],
+                "description": "Returns the current stage of a pending create or join operation. Fallback for clients without WebSocket connection.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "duel"
                 ],
-                "summary": "Upload user images",
+                "summary": "Get crypto duel operation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Authorization Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
+                        "description": "Operation ID (UUID)",
+                        "name": "id",
+                        "in": "path",
                         "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Operation",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.TrackedSignature"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid operation ID",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Operation not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
                     },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/crypto-duel/solana/resolve": {
+            "put": {
+                "security": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "file"
-                        },
-                        "collectionFormat": "csv",
-                        "description": "Array of images to upload",
-                        "name": "images",
-                        "in": "formData",
-                        "required": true
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Allows the duel owner to resolve the duel and trigger payouts. Returns transaction hashes of on-chain transfers.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duel"
+                ],
+                "summary": "Resolve crypto duel by owner (payouts)",
+                "parameters": [
+                    {
+                        "description": "Duel ID and final answer (0/1)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.DuelResolveReq"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Images uploaded successfully, returns list of image URLs",
+                        "description": "Distribution transaction hashes",
                         "schema": {
                             "type": "object",
                             "properties": {
-                                "image_urls": {
+                                "tx_hashes": {
                                     "type": "array",
                                     "items": {
                                         "type": "string"
@@ -1427,25 +1520,19 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request data or missing image files",
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "401": {
-                        "description": "Unauthorized - missing or invalid token",
-                        "schema": {
-                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
-                        }
-                    },
-                    "429": {
-                        "description": "Too many uploads - rate limit exceeded",
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "500": {
-                        "description": "Internal server error during file save",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
@@ -1453,14 +1540,14 @@ const docTemplate = `{
                 }
             }
         },
-        "/user/username": {
-            "put": {
+        "/crypto-duel/solana/sign-tx": {
+            "post": {
                 "security": [
                     {
                         "BearerAuth": []
                     }
                 ],
-                "description": "Updates the username for the currently authenticated user. The new username must be valid and not already taken.",
+                "description": "Builds a base64-encoded Solana init transaction. The client signs and sends it using an external wallet (e.g., Phantom/Solflare).\nUploaded images in image_url and bg_url must be uploaded by the caller.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1468,120 +1555,2595 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "user"
+                    "duel"
                 ],
-                "summary": "Change the username of the authenticated user",
+                "summary": "Build unsigned init transaction for creating a crypto duel",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Authorization Bearer token",
-                        "name": "Authorization",
-                        "in": "header",
-                        "required": true
-                    },
-                    {
-                        "description": "Username change payload",
+                        "description": "Duel parameters",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/duels-api_internal_model.UsernameChange"
+                            "$ref": "#/definitions/duels-api_internal_model.CreateDuelReq"
                         }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "Username changed successfully"
-                    },
-                    "400": {
-                        "description": "Invalid request parameters or invalid username",
+                    "200": {
+                        "description": "Unsigned base64 transaction",
                         "schema": {
-                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                            "type": "object",
+                            "properties": {
+                                "tx": {
+                                    "type": "string"
+                                }
+                            }
                         }
                     },
-                    "401": {
-                        "description": "Authentication required or invalid token",
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
-                    "409": {
-                        "description": "This username is already taken",
+                    "401": {
+                        "description": "Unauthorized",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     },
                     "500": {
-                        "description": "Internal server error during username change",
+                        "description": "Internal error",
                         "schema": {
                             "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "duels-api_internal_model.AuthWithWallet": {
-            "type": "object",
-            "required": [
-                "address",
-                "secret"
-            ],
-            "properties": {
-                "address": {
-                    "type": "string"
-                },
-                "secret": {
-                    "type": "string"
-                }
-            }
-        },
-        "duels-api_internal_model.CreateCryptoDuelResp": {
-            "type": "object",
-            "properties": {
-                "duel": {
-                    "$ref": "#/definitions/duels-api_internal_model.Duel"
-                },
-                "result": {
-                    "$ref": "#/definitions/duels-api_internal_model.JoinSolanaRoomResp"
-                }
-            }
         },
-        "duels-api_internal_model.CreateDuelReq": {
-            "type": "object",
-            "properties": {
-                "answer": {
+        "/duel/all": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns duels with user-specific flags (e.g., joined, your_answer). Requires authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duel"
+                ],
+                "summary": "List duels (authorized, with participation flags)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "opts.pagination.page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "default": 1,
+                        "description": "Page number (starts at 1)",
+                        "name": "opts.pagination.page_num",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order by field",
+                        "name": "opts.order.order_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "desc",
+                            "asc"
+                        ],
+                        "type": "string",
+                        "description": "Order type",
+                        "name": "opts.order.order_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter column",
+                        "name": "opts.filters[0].column",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter operator",
+                        "name": "opts.filters[0].operator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter value",
+                        "name": "opts.filters[0].value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Use OR between filters",
+                        "name": "opts.filters[0].where_or",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Duels with participation flags",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/duels-api_internal_model.DuelShow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/duel/feed": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns active duels created by users the authenticated user follows, the newest first unless ordered otherwise.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duel"
+                ],
+                "summary": "Followed creators feed",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "opts.pagination.page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "default": 1,
+                        "description": "Page number (starts at 1)",
+                        "name": "opts.pagination.page_num",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order by field",
+                        "name": "opts.order.order_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "desc",
+                            "asc"
+                        ],
+                        "type": "string",
+                        "description": "Order type",
+                        "name": "opts.order.order_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter column",
+                        "name": "opts.filters[0].column",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter operator",
+                        "name": "opts.filters[0].operator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter value",
+                        "name": "opts.filters[0].value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Use OR between filters",
+                        "name": "opts.filters[0].where_or",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Duels of followed creators",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/duels-api_internal_model.DuelShow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/duel/my": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns duels created by the authenticated user. Supports filtering/sorting/pagination.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duel"
+                ],
+                "summary": "List my duels",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "opts.pagination.page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "default": 1,
+                        "description": "Page number (starts at 1)",
+                        "name": "opts.pagination.page_num",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Order by field",
+                        "name": "opts.order.order_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "desc",
+                            "asc"
+                        ],
+                        "type": "string",
+                        "description": "Order type",
+                        "name": "opts.order.order_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter column",
+                        "name": "opts.filters[0].column",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter operator",
+                        "name": "opts.filters[0].operator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter value",
+                        "name": "opts.filters[0].value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Use OR between filters",
+                        "name": "opts.filters[0].where_or",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "My duels",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/duels-api_internal_model.DuelShow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/duel/public/all": {
+            "get": {
+                "description": "Public list of duels with filtering/sorting/pagination (no user-specific flags like 'joined').",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duel-public"
+                ],
+                "summary": "List duels (public)",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "default": 10,
+                        "description": "Page size",
+                        "name": "opts.pagination.page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "default": 1,
+                        "description": "Page number (starts at 1)",
+                        "name": "opts.pagination.page_num",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Order by field",
+                        "name": "opts.order.order_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "desc",
+                            "asc"
+                        ],
+                        "type": "string",
+                        "default": "desc",
+                        "description": "Order type",
+                        "name": "opts.order.order_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter column",
+                        "name": "opts.filters[0].column",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter operator",
+                        "name": "opts.filters[0].operator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter value",
+                        "name": "opts.filters[0].value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Use OR between filters",
+                        "name": "opts.filters[0].where_or",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Duels",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/duels-api_internal_model.DuelShow"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/duel/public/count": {
+            "get": {
+                "description": "Public count of duels with optional filtering.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duel-public"
+                ],
+                "summary": "Count duels (public)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter column",
+                        "name": "opts.filters[0].column",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter operator",
+                        "name": "opts.filters[0].operator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter value",
+                        "name": "opts.filters[0].value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Use OR between filters",
+                        "name": "opts.filters[0].where_or",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Total count",
+                        "schema": {
+                            "type": "integer"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad request",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/duel/public/{id}": {
+            "get": {
+                "description": "Returns a duel by ID without authentication.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duel-public"
+                ],
+                "summary": "Get duel by ID (public)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Duel ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Duel",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.Duel"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid duel ID",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Duel not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/duel/{id}": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    },
+                    {
+                        "ApiKeyAuth": []
+                    }
+                ],
+                "description": "Returns duel details and players for the authenticated user.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "duel"
+                ],
+                "summary": "Get duel by ID (authorized)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Duel ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Duel and players",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "duel": {
+                                    "$ref": "#/definitions/duels-api_internal_model.DuelShow"
+                                },
+                                "players": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/duels-api_internal_model.PlayerShow"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid duel ID",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Duel not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/events": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Streams the events of the authenticated user as Server-Sent Events, the same envelopes as delivered over /ws.\nThe SSE id of an event is its event_id, reconnecting with the Last-Event-ID header (or the last_event_id query param) replays the events missed since then within the retention window.\nIf some of the missed events were trimmed already, a reset event (type 14) is sent before the retained ones and the client must reload its state.\nA heartbeat comment is sent every 15 seconds.\nThe stream ends when the access token expires, where a WebSocket connection is closed with 4002, or when the session is revoked (4003). Refreshing tokens does not end it, reconnect with a fresh access token and Last-Event-ID.",
+                "produces": [
+                    "text/event-stream"
+                ],
+                "tags": [
+                    "events"
+                ],
+                "summary": "Stream events (SSE)",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "event_id of the last received event",
+                        "name": "Last-Event-ID",
+                        "in": "header"
+                    },
+                    {
+                        "type": "string",
+                        "description": "event_id of the last received event, for clients that cannot set headers",
+                        "name": "last_event_id",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Event stream",
+                        "schema": {
+                            "type": "string"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid last event id",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many connections",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "503": {
+                        "description": "Server is shutting down",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboard": {
+            "get": {
+                "description": "Returns players ranked by the metric over the period. Leaderboards are precomputed every 10 minutes, refreshed_at is the time of the last refresh.\nWin rate ranks players with a minimum number of resolved duels, commission ranks duel creators.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard"
+                ],
+                "summary": "Get leaderboard",
+                "parameters": [
+                    {
+                        "enum": [
+                            "daily",
+                            "weekly",
+                            "monthly",
+                            "all_time"
+                        ],
+                        "type": "string",
+                        "default": "all_time",
+                        "description": "Rolling window over duel resolution time",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "net_profit",
+                            "wins",
+                            "win_rate",
+                            "volume",
+                            "commission"
+                        ],
+                        "type": "string",
+                        "default": "net_profit",
+                        "description": "Ranking metric",
+                        "name": "metric",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size, up to 100",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (starts at 1)",
+                        "name": "page_num",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Leaderboard",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.Leaderboard"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period or metric",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/leaderboard/me": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the rank of the authenticated user by the metric over the period.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "leaderboard"
+                ],
+                "summary": "Get my rank",
+                "parameters": [
+                    {
+                        "enum": [
+                            "daily",
+                            "weekly",
+                            "monthly",
+                            "all_time"
+                        ],
+                        "type": "string",
+                        "default": "all_time",
+                        "description": "Rolling window over duel resolution time",
+                        "name": "period",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "net_profit",
+                            "wins",
+                            "win_rate",
+                            "volume",
+                            "commission"
+                        ],
+                        "type": "string",
+                        "default": "net_profit",
+                        "description": "Ranking metric",
+                        "name": "metric",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Rank of the user",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.LeaderboardEntry"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid period or metric",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "User is not ranked",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve all notifications for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Get all notifications",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "default": 10,
+                        "description": "Number of items per page",
+                        "name": "opts.pagination.page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "format": "int64",
+                        "default": 1,
+                        "description": "Page number (starting from 1)",
+                        "name": "opts.pagination.page_num",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "created_at",
+                        "description": "Field to order by",
+                        "name": "opts.order.order_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "desc",
+                            "asc"
+                        ],
+                        "type": "string",
+                        "default": "\"\"",
+                        "description": "Order type",
+                        "name": "opts.order.order_type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First filter column name",
+                        "name": "opts.filters[0].column",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First filter operator",
+                        "name": "opts.filters[0].operator",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "First filter value",
+                        "name": "opts.filters[0].value",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "First filter OR condition",
+                        "name": "opts.filters[0].where_or",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Authorization Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "List of notifications",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/duels-api_internal_model.Notification"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing claims",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Marks all notifications as read for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Mark all notifications as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated notifications",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/duels-api_internal_model.Notification"
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing claims",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification/unread": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Retrieve the number of unread notifications for the authenticated user",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Get unread notifications count",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Unread notifications count",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "notifications": {
+                                    "type": "integer"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing claims",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/notification/{id}": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Mark a single notification as read by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "notification"
+                ],
+                "summary": "Mark a notification as read",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Notification ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Updated notification",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.Notification"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - Invalid or missing claims",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Schedules a season, seasons must not overlap. Prize split is the percent of the prize pool per rank starting at the first place, it must not exceed 100 in total.\nPrizes are paid in USDC from the admin wallet once the season ends.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin"
+                ],
+                "summary": "Create season (admin)",
+                "parameters": [
+                    {
+                        "description": "Season params",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.CreateSeasonReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Season",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.Season"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid season params",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "403": {
+                        "description": "Admin access required",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "409": {
+                        "description": "Season overlaps with another season",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/current": {
+            "get": {
+                "description": "Returns the running season. Players earn season points for joining and winning duels while it runs.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "season"
+                ],
+                "summary": "Get current season",
+                "responses": {
+                    "200": {
+                        "description": "Current season",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.Season"
+                        }
+                    },
+                    "404": {
+                        "description": "No season is running",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/past": {
+            "get": {
+                "description": "Returns ended seasons, the latest first. Status is 0 before settlement, 1 while prizes are paid out and 2 once all prizes are paid.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "season"
+                ],
+                "summary": "Get past seasons",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size, up to 100",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (starts at 1)",
+                        "name": "page_num",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Past seasons",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/duels-api_internal_model.Season"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{id}/results": {
+            "get": {
+                "description": "Returns the prizes of an ended season by rank. Prize status is 0 pending, 1 paid and 2 failed, failed transfers are retried.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "season"
+                ],
+                "summary": "Get season results",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Season ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Season results",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.SeasonResults"
+                        }
+                    },
+                    "400": {
+                        "description": "Season has not ended yet",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Season not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/seasons/{id}/standings": {
+            "get": {
+                "description": "Returns players ranked by season points, ties are broken by wins and then by who reached the points first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "season"
+                ],
+                "summary": "Get season standings",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Season ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 50,
+                        "description": "Page size, up to 100",
+                        "name": "page_size",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number (starts at 1)",
+                        "name": "page_num",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Season standings",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.SeasonStandings"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request params",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Season not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns the authenticated user's profile data based on their JWT token.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get current user profile",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved user data",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "user": {
+                                    "$ref": "#/definitions/duels-api_internal_model.User"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/privacy": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Hides stats or duel history from the public profile, created duels stay visible.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update profile privacy",
+                "parameters": [
+                    {
+                        "description": "Privacy toggles",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.UpdatePrivacyReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Privacy settings updated"
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Sets the bio (up to 280 characters) and social links (http or https urls) shown on the public profile, empty values clear them.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Update public profile",
+                "parameters": [
+                    {
+                        "description": "Profile fields",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.UpdateProfileReq"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Profile updated"
+                    },
+                    "400": {
+                        "description": "Invalid bio or social links",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/profile-picture": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allows an authenticated user to upload a new profile image.\nThe uploaded image will replace the existing profile picture and return its new public URL.\nJPEG, PNG and SVG images are accepted by content, they are cropped to a 512px square and re-encoded without metadata, a 128px thumbnail is stored next to it.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Set user profile picture",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Profile image file",
+                        "name": "image",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Profile picture successfully updated",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "image_url": {
+                                    "type": "string"
+                                },
+                                "thumbnail_url": {
+                                    "type": "string"
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or missing image file",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid or missing token",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error during image upload",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/stats": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Aggregated duel stats for the authenticated user (participated, wins/losses, earned/lost, etc.)",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get user duel stats",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Successfully retrieved user duel stats",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "stats": {
+                                    "$ref": "#/definitions/duels-api_internal_model.UserStats"
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/upload-images": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Allows an authenticated user to upload one or more image files.\nEach image is stored, and the service returns an array of accessible URLs.\nJPEG, PNG and SVG images are accepted by content, they are scaled to fit 1600px and re-encoded without metadata, a 480px thumbnail is stored for each of them.\nUpload rate is limited via middleware to prevent spam (max 3 per minute).\nImages not used by a duel are deleted after UPLOAD_ORPHAN_TTL.",
+                "consumes": [
+                    "multipart/form-data"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Upload user images",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "file"
+                        },
+                        "collectionFormat": "csv",
+                        "description": "Array of images to upload",
+                        "name": "images",
+                        "in": "formData",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Images uploaded successfully, returns list of image URLs",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "image_urls": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "string"
+                                    }
+                                },
+                                "thumbnail_urls": {
+                                    "type": "array",
+                                    "items": {
+                                        "type": "string"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request data or missing image files",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "429": {
+                        "description": "Too many uploads - rate limit exceeded",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error during file save",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/username": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Updates the username for the currently authenticated user. The new username must be valid and not already taken.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Change the username of the authenticated user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Authorization Bearer token",
+                        "name": "Authorization",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "description": "Username change payload",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.UsernameChange"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Username changed successfully"
+                    },
+                    "400": {
+                        "description": "Invalid request parameters or invalid username",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Authentication required or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "409": {
+                        "description": "This username is already taken",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error during username change",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/wallets": {
+            "get": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Returns wallets linked to the authenticated user, the primary wallet first.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get linked wallets",
+                "responses": {
+                    "200": {
+                        "description": "Linked wallets",
+                        "schema": {
+                            "type": "object",
+                            "properties": {
+                                "wallets": {
+                                    "type": "array",
+                                    "items": {
+                                        "$ref": "#/definitions/duels-api_internal_model.UserWallet"
+                                    }
+                                }
+                            }
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Links another wallet to the authenticated user. The wallet proves ownership by signing the message issued by /auth/challenge.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Link wallet",
+                "parameters": [
+                    {
+                        "description": "Wallet address, challenge nonce and signature",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.AuthWithWallet"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Wallet linked",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.UserWallet"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request body",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - invalid token, challenge or signature",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "409": {
+                        "description": "Wallet is already linked",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/wallets/{address}": {
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Unlinks a wallet from the authenticated user. The primary wallet can not be unlinked.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Unlink wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Wallet unlinked"
+                    },
+                    "400": {
+                        "description": "Primary wallet can not be unlinked",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet is not linked",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/user/wallets/{address}/primary": {
+            "put": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Makes the linked wallet primary. The primary wallet is the default payer and payout address.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Switch primary wallet",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Wallet address",
+                        "name": "address",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "Primary wallet switched"
+                    },
+                    "401": {
+                        "description": "Unauthorized - missing or invalid token",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "Wallet is not linked",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Returns the public profile of a user: bio, social links, follower counts, XP level, achievements, stats, created duels and recent results.\nStats and recent results are null if the user hides them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get public user profile by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Public profile",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.PublicProfile"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/follow": {
+            "post": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "description": "Duels created by the followed user appear in the feed and their followers are notified about them. Following a user twice is not an error.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Follow user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "User followed"
+                    },
+                    "400": {
+                        "description": "Invalid user ID or following yourself",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Unfollow user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "User unfollowed"
+                    },
+                    "400": {
+                        "description": "Invalid user ID",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "404": {
+                        "description": "User is not followed",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{username}": {
+            "get": {
+                "description": "Returns the public profile of a user: bio, social links, follower counts, XP level, achievements, stats, created duels and recent results.\nStats and recent results are null if the user hides them.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "user"
+                ],
+                "summary": "Get public user profile by username",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Username",
+                        "name": "username",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Public profile",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_internal_model.PublicProfile"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal server error",
+                        "schema": {
+                            "$ref": "#/definitions/duels-api_pkg_apperrors.ErrorPublic"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "duels-api_internal_model.APIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issued_by": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix is the public part of the key, it helps to tell keys apart",
+                    "type": "string"
+                },
+                "rate_limit": {
+                    "description": "RateLimit is the number of requests allowed per minute",
+                    "type": "integer"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.Achievement": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "xp": {
+                    "description": "XP is awarded on unlock on top of the XP of the event",
+                    "type": "integer"
+                }
+            }
+        },
+        "duels-api_internal_model.AdminCreateAPIKeyReq": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate_limit": {
+                    "description": "RateLimit per minute, the default limit is used if zero",
+                    "type": "integer"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.AuthWithWallet": {
+            "type": "object",
+            "required": [
+                "address",
+                "nonce",
+                "secret"
+            ],
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "nonce": {
+                    "type": "string"
+                },
+                "secret": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.Comment": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duel_id": {
+                    "type": "string"
+                },
+                "edited_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "pinned_at": {
+                    "type": "string"
+                },
+                "replies_count": {
+                    "type": "integer"
+                },
+                "root_id": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "text": {
+                    "type": "string"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.CommentPage": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/duels-api_internal_model.Comment"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "pinned": {
+                    "description": "Pinned is the pinned comment of the duel, it is set on the first page of threads only",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/duels-api_internal_model.Comment"
+                        }
+                    ]
+                }
+            }
+        },
+        "duels-api_internal_model.CreateAPIKeyReq": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "rate_limit": {
+                    "description": "RateLimit per minute, the default limit is used if zero",
+                    "type": "integer"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "duels-api_internal_model.CreateCommentReq": {
+            "type": "object",
+            "properties": {
+                "duel_id": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.CreateDuelReq": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "integer"
+                },
+                "bg_url": {
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "integer"
+                },
+                "duel_info": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "duel_price": {
+                    "type": "number"
+                },
+                "event_date": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "description": "Wallet is the linked wallet paying for the duel, the primary wallet if empty",
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.CreateSeasonReq": {
+            "type": "object",
+            "properties": {
+                "ends_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prize_pool": {
+                    "type": "number"
+                },
+                "prize_split": {
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "starts_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.Duel": {
+            "type": "object",
+            "properties": {
+                "bg_url": {
+                    "type": "string"
+                },
+                "cancellation_reason": {
+                    "type": "string"
+                },
+                "commission": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "creator_commission": {
+                    "description": "CreatorCommission is the commission paid to the owner once the duel is resolved",
+                    "type": "number"
+                },
+                "duel_info": {
+                    "type": "object",
+                    "additionalProperties": {}
+                },
+                "duel_price": {
+                    "type": "number"
+                },
+                "event_date": {
+                    "type": "string"
+                },
+                "final_result": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "owner_id": {
+                    "type": "string"
+                },
+                "players_count": {
+                    "type": "integer"
+                },
+                "question": {
+                    "type": "string"
+                },
+                "refunded_players_count": {
                     "type": "integer"
                 },
-                "bg_url": {
+                "resolved_at": {
                     "type": "string"
                 },
-                "commission": {
+                "room_number": {
+                    "description": "todo bigint",
                     "type": "integer"
                 },
-                "duel_info": {
-                    "type": "object",
-                    "additionalProperties": {}
-                },
-                "duel_price": {
-                    "type": "number"
+                "status": {
+                    "type": "integer"
                 },
-                "event_date": {
+                "updated_at": {
                     "type": "string"
                 },
-                "image_url": {
+                "username": {
                     "type": "string"
                 },
-                "question": {
-                    "type": "string"
+                "winners_count": {
+                    "type": "integer"
+                }
+            }
+        },
+        "duels-api_internal_model.DuelResolveReq": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "integer"
                 },
-                "tx_hash": {
+                "duel_id": {
                     "type": "string"
                 }
             }
         },
-        "duels-api_internal_model.Duel": {
+        "duels-api_internal_model.DuelShow": {
             "type": "object",
             "properties": {
                 "bg_url": {
@@ -1596,6 +4158,10 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "creator_commission": {
+                    "description": "CreatorCommission is the commission paid to the owner once the duel is resolved",
+                    "type": "number"
+                },
                 "duel_info": {
                     "type": "object",
                     "additionalProperties": {}
@@ -1615,9 +4181,21 @@ const docTemplate = `{
                 "image_url": {
                     "type": "string"
                 },
+                "joined": {
+                    "type": "boolean"
+                },
+                "no_count": {
+                    "type": "integer"
+                },
                 "owner_id": {
                     "type": "string"
                 },
+                "owner_image_url": {
+                    "type": "string"
+                },
+                "player_status": {
+                    "type": "integer"
+                },
                 "players_count": {
                     "type": "integer"
                 },
@@ -1627,7 +4205,11 @@ const docTemplate = `{
                 "refunded_players_count": {
                     "type": "integer"
                 },
+                "resolved_at": {
+                    "type": "string"
+                },
                 "room_number": {
+                    "description": "todo bigint",
                     "type": "integer"
                 },
                 "status": {
@@ -1639,238 +4221,627 @@ const docTemplate = `{
                 "username": {
                     "type": "string"
                 },
+                "viewers": {
+                    "description": "Viewers is the number of users viewing the duel right now, it is set for duels in process",
+                    "type": "integer"
+                },
                 "winners_count": {
                     "type": "integer"
+                },
+                "yes_count": {
+                    "type": "integer"
+                },
+                "your_answer": {
+                    "type": "integer"
                 }
             }
         },
-        "duels-api_internal_model.DuelResolveReq": {
+        "duels-api_internal_model.IssuedAPIKey": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "issued_by": {
+                    "type": "string"
+                },
+                "key": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "prefix": {
+                    "description": "Prefix is the public part of the key, it helps to tell keys apart",
+                    "type": "string"
+                },
+                "rate_limit": {
+                    "description": "RateLimit is the number of requests allowed per minute",
+                    "type": "integer"
+                },
+                "revoked_at": {
+                    "type": "string"
+                },
+                "scopes": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.JoinDuelReq": {
+            "type": "object",
+            "properties": {
+                "answer": {
+                    "type": "integer"
+                },
+                "duel_id": {
+                    "type": "string"
+                },
+                "external_source": {
+                    "type": "string"
+                },
+                "invited_by": {
+                    "type": "string"
+                },
+                "tx_hash": {
+                    "type": "string"
+                },
+                "wallet": {
+                    "description": "Wallet is the linked wallet paying for the join, the primary wallet if empty",
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.Leaderboard": {
+            "type": "object",
+            "properties": {
+                "entries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/duels-api_internal_model.LeaderboardEntry"
+                    }
+                },
+                "metric": {
+                    "$ref": "#/definitions/duels-api_internal_model.LeaderboardMetric"
+                },
+                "period": {
+                    "$ref": "#/definitions/duels-api_internal_model.LeaderboardPeriod"
+                },
+                "refreshed_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.LeaderboardEntry": {
+            "type": "object",
+            "properties": {
+                "commission": {
+                    "type": "number"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "net_profit": {
+                    "type": "number"
+                },
+                "participated": {
+                    "type": "integer"
+                },
+                "rank": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "number"
+                },
+                "volume": {
+                    "type": "number"
+                },
+                "win_rate": {
+                    "type": "number"
+                },
+                "wins": {
+                    "type": "integer"
+                }
+            }
+        },
+        "duels-api_internal_model.LeaderboardMetric": {
+            "type": "string",
+            "enum": [
+                "net_profit",
+                "wins",
+                "win_rate",
+                "volume",
+                "commission"
+            ],
+            "x-enum-varnames": [
+                "LeaderboardMetricNetProfit",
+                "LeaderboardMetricWins",
+                "LeaderboardMetricWinRate",
+                "LeaderboardMetricVolume",
+                "LeaderboardMetricCommission"
+            ]
+        },
+        "duels-api_internal_model.LeaderboardPeriod": {
+            "type": "string",
+            "enum": [
+                "daily",
+                "weekly",
+                "monthly",
+                "all_time"
+            ],
+            "x-enum-varnames": [
+                "LeaderboardPeriodDaily",
+                "LeaderboardPeriodWeekly",
+                "LeaderboardPeriodMonthly",
+                "LeaderboardPeriodAllTime"
+            ]
+        },
+        "duels-api_internal_model.Notification": {
+            "type": "object",
+            "properties": {
+                "created_at": {
+                    "type": "string"
+                },
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_read": {
+                    "type": "boolean"
+                },
+                "notification_type": {
+                    "type": "integer"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.PlayerShow": {
             "type": "object",
             "properties": {
                 "answer": {
                     "type": "integer"
                 },
-                "duel_id": {
+                "created_at": {
+                    "type": "string"
+                },
+                "duel_id": {
+                    "type": "string"
+                },
+                "final_status": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "is_winner": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string"
+                },
+                "username": {
+                    "type": "string"
+                },
+                "wallet_address": {
+                    "type": "string"
+                },
+                "win_amount": {
+                    "type": "number"
+                }
+            }
+        },
+        "duels-api_internal_model.PublicProfile": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/duels-api_internal_model.UserAchievement"
+                    }
+                },
+                "created_duels": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/duels-api_internal_model.DuelShow"
+                    }
+                },
+                "followers_count": {
+                    "type": "integer"
+                },
+                "following_count": {
+                    "type": "integer"
+                },
+                "history_hidden": {
+                    "type": "boolean"
+                },
+                "progress": {
+                    "$ref": "#/definitions/duels-api_internal_model.UserProgress"
+                },
+                "recent_results": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/duels-api_internal_model.DuelShow"
+                    }
+                },
+                "stats": {
+                    "$ref": "#/definitions/duels-api_internal_model.UserStats"
+                },
+                "stats_hidden": {
+                    "type": "boolean"
+                },
+                "user": {
+                    "$ref": "#/definitions/duels-api_internal_model.PublicUser"
+                }
+            }
+        },
+        "duels-api_internal_model.PublicUser": {
+            "type": "object",
+            "properties": {
+                "bio": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "image_url": {
+                    "type": "string"
+                },
+                "social_links": {
+                    "$ref": "#/definitions/duels-api_internal_model.SocialLinks"
+                },
+                "username": {
                     "type": "string"
                 }
             }
         },
-        "duels-api_internal_model.DuelShow": {
+        "duels-api_internal_model.Season": {
             "type": "object",
             "properties": {
-                "bg_url": {
+                "created_at": {
                     "type": "string"
                 },
-                "cancellation_reason": {
+                "ends_at": {
                     "type": "string"
                 },
-                "commission": {
-                    "type": "integer"
-                },
-                "created_at": {
+                "id": {
                     "type": "string"
                 },
-                "duel_info": {
-                    "type": "object",
-                    "additionalProperties": {}
+                "name": {
+                    "type": "string"
                 },
-                "duel_price": {
+                "prize_pool": {
                     "type": "number"
                 },
-                "event_date": {
+                "prize_split": {
+                    "description": "PrizeSplit is the percent of the prize pool per rank, starting at the first place",
+                    "type": "array",
+                    "items": {
+                        "type": "number"
+                    }
+                },
+                "settled_at": {
                     "type": "string"
                 },
-                "final_result": {
+                "starts_at": {
+                    "type": "string"
+                },
+                "status": {
                     "type": "integer"
+                }
+            }
+        },
+        "duels-api_internal_model.SeasonPrize": {
+            "type": "object",
+            "properties": {
+                "amount": {
+                    "type": "number"
                 },
-                "id": {
+                "created_at": {
                     "type": "string"
                 },
-                "image_url": {
+                "paid_at": {
                     "type": "string"
                 },
-                "joined": {
-                    "type": "boolean"
+                "points": {
+                    "type": "integer"
                 },
-                "no_count": {
+                "rank": {
                     "type": "integer"
                 },
-                "owner_id": {
+                "season_id": {
                     "type": "string"
                 },
-                "owner_image_url": {
+                "status": {
+                    "type": "integer"
+                },
+                "tx_signature": {
                     "type": "string"
                 },
-                "player_status": {
-                    "type": "integer"
+                "user_id": {
+                    "type": "string"
                 },
-                "players_count": {
-                    "type": "integer"
+                "username": {
+                    "type": "string"
                 },
-                "question": {
+                "wallet": {
                     "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.SeasonResults": {
+            "type": "object",
+            "properties": {
+                "prizes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/duels-api_internal_model.SeasonPrize"
+                    }
                 },
-                "refunded_players_count": {
+                "season": {
+                    "$ref": "#/definitions/duels-api_internal_model.Season"
+                }
+            }
+        },
+        "duels-api_internal_model.SeasonStanding": {
+            "type": "object",
+            "properties": {
+                "image_url": {
+                    "type": "string"
+                },
+                "participated": {
                     "type": "integer"
                 },
-                "room_number": {
+                "points": {
                     "type": "integer"
                 },
-                "status": {
+                "rank": {
                     "type": "integer"
                 },
-                "updated_at": {
+                "user_id": {
                     "type": "string"
                 },
                 "username": {
                     "type": "string"
                 },
-                "winners_count": {
-                    "type": "integer"
-                },
-                "yes_count": {
-                    "type": "integer"
-                },
-                "your_answer": {
+                "wins": {
                     "type": "integer"
                 }
             }
         },
-        "duels-api_internal_model.JoinCryptoDuelResp": {
+        "duels-api_internal_model.SeasonStandings": {
             "type": "object",
             "properties": {
-                "player": {
-                    "$ref": "#/definitions/duels-api_internal_model.Player"
+                "season": {
+                    "$ref": "#/definitions/duels-api_internal_model.Season"
                 },
-                "result": {
-                    "$ref": "#/definitions/duels-api_internal_model.JoinSolanaRoomResp"
+                "standings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/duels-api_internal_model.SeasonStanding"
+                    }
                 }
             }
         },
-        "duels-api_internal_model.JoinDuelReq": {
+        "duels-api_internal_model.Session": {
             "type": "object",
             "properties": {
-                "answer": {
-                    "type": "integer"
+                "created_at": {
+                    "type": "string"
                 },
-                "duel_id": {
+                "current": {
+                    "type": "boolean"
+                },
+                "expires_at": {
                     "type": "string"
                 },
-                "external_source": {
+                "family_id": {
+                    "description": "FamilyID is kept across refreshes of the session, sessions are listed and revoked by it",
                     "type": "string"
                 },
-                "invited_by": {
+                "id": {
                     "type": "string"
                 },
-                "tx_hash": {
+                "ip": {
+                    "type": "string"
+                },
+                "last_seen_ip": {
+                    "type": "string"
+                },
+                "last_seen_user_agent": {
+                    "type": "string"
+                },
+                "last_used_at": {
+                    "type": "string"
+                },
+                "user_agent": {
                     "type": "string"
                 }
             }
         },
-        "duels-api_internal_model.JoinSolanaRoomResp": {
+        "duels-api_internal_model.SignInChallengeReq": {
             "type": "object",
             "properties": {
-                "tx_hash": {
+                "address": {
                     "type": "string"
                 }
             }
         },
-        "duels-api_internal_model.Notification": {
+        "duels-api_internal_model.SignInChallengeResp": {
             "type": "object",
             "properties": {
-                "created_at": {
+                "address": {
                     "type": "string"
                 },
-                "data": {
-                    "type": "array",
-                    "items": {
-                        "type": "integer"
-                    }
+                "domain": {
+                    "type": "string"
                 },
-                "id": {
+                "expiration_time": {
                     "type": "string"
                 },
-                "is_read": {
-                    "type": "boolean"
+                "issued_at": {
+                    "type": "string"
                 },
-                "notification_type": {
-                    "type": "integer"
+                "message": {
+                    "type": "string"
                 },
-                "user_id": {
+                "nonce": {
                     "type": "string"
                 }
             }
         },
-        "duels-api_internal_model.Player": {
+        "duels-api_internal_model.SocialLinks": {
             "type": "object",
             "properties": {
-                "answer": {
-                    "type": "integer"
-                },
-                "created_at": {
+                "discord": {
                     "type": "string"
                 },
-                "duel_id": {
+                "telegram": {
                     "type": "string"
                 },
-                "final_status": {
-                    "type": "integer"
-                },
-                "id": {
+                "twitter": {
                     "type": "string"
                 },
-                "is_winner": {
-                    "type": "boolean"
-                },
-                "user_id": {
+                "website": {
                     "type": "string"
-                },
-                "win_amount": {
-                    "type": "number"
                 }
             }
         },
-        "duels-api_internal_model.PlayerShow": {
+        "duels-api_internal_model.TrackedSignature": {
             "type": "object",
             "properties": {
-                "answer": {
-                    "type": "integer"
-                },
                 "created_at": {
                     "type": "string"
                 },
                 "duel_id": {
                     "type": "string"
                 },
-                "final_status": {
-                    "type": "integer"
+                "error": {
+                    "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "image_url": {
+                "purpose": {
+                    "type": "string",
+                    "enum": [
+                        "join",
+                        "create",
+                        "reward",
+                        "refund",
+                        "commission",
+                        "close"
+                    ]
+                },
+                "signature": {
                     "type": "string"
                 },
-                "is_winner": {
-                    "type": "boolean"
+                "slot": {
+                    "type": "integer"
+                },
+                "stage": {
+                    "type": "string",
+                    "enum": [
+                        "submitted",
+                        "confirmed",
+                        "validated",
+                        "recorded",
+                        "failed"
+                    ]
+                },
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "confirmed",
+                        "failed"
+                    ]
+                },
+                "updated_at": {
+                    "type": "string"
                 },
                 "user_id": {
                     "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.UpdateCommentReq": {
+            "type": "object",
+            "properties": {
+                "text": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.UpdatePrivacyReq": {
+            "type": "object",
+            "properties": {
+                "hide_history": {
+                    "type": "boolean"
                 },
-                "username": {
+                "hide_stats": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "duels-api_internal_model.UpdateProfileReq": {
+            "type": "object",
+            "properties": {
+                "bio": {
                     "type": "string"
                 },
-                "win_amount": {
-                    "type": "number"
+                "social_links": {
+                    "$ref": "#/definitions/duels-api_internal_model.SocialLinks"
                 }
             }
         },
         "duels-api_internal_model.User": {
             "type": "object",
             "properties": {
+                "bio": {
+                    "type": "string"
+                },
                 "created_at": {
                     "type": "string"
                 },
+                "hide_history": {
+                    "type": "boolean"
+                },
+                "hide_stats": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -1880,11 +4851,45 @@ const docTemplate = `{
                 "public_address": {
                     "type": "string"
                 },
+                "social_links": {
+                    "$ref": "#/definitions/duels-api_internal_model.SocialLinks"
+                },
                 "updated_at": {
                     "type": "string"
                 },
                 "username": {
                     "type": "string"
+                },
+                "win_streak": {
+                    "type": "integer"
+                },
+                "xp": {
+                    "type": "integer"
+                }
+            }
+        },
+        "duels-api_internal_model.UserAchievement": {
+            "type": "object",
+            "properties": {
+                "achievement": {
+                    "type": "string"
+                },
+                "unlocked_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_internal_model.UserProgress": {
+            "type": "object",
+            "properties": {
+                "level": {
+                    "type": "integer"
+                },
+                "next_level_xp": {
+                    "type": "integer"
+                },
+                "xp": {
+                    "type": "integer"
                 }
             }
         },
@@ -1917,6 +4922,26 @@ const docTemplate = `{
                 }
             }
         },
+        "duels-api_internal_model.UserWallet": {
+            "type": "object",
+            "properties": {
+                "address": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_primary": {
+                    "type": "boolean"
+                },
+                "user_id": {
+                    "type": "string"
+                }
+            }
+        },
         "duels-api_internal_model.UsernameChange": {
             "type": "object",
             "properties": {
@@ -1936,6 +4961,48 @@ const docTemplate = `{
                 }
             }
         },
+        "duels-api_pkg_jwt.JWK": {
+            "type": "object",
+            "properties": {
+                "alg": {
+                    "type": "string"
+                },
+                "crv": {
+                    "description": "EdDSA keys",
+                    "type": "string"
+                },
+                "e": {
+                    "type": "string"
+                },
+                "kid": {
+                    "type": "string"
+                },
+                "kty": {
+                    "type": "string"
+                },
+                "n": {
+                    "description": "RSA keys",
+                    "type": "string"
+                },
+                "use": {
+                    "type": "string"
+                },
+                "x": {
+                    "type": "string"
+                }
+            }
+        },
+        "duels-api_pkg_jwt.JWKSet": {
+            "type": "object",
+            "properties": {
+                "keys": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/duels-api_pkg_jwt.JWK"
+                    }
+                }
+            }
+        },
         "duels-api_pkg_jwt.TokenPair": {
             "type": "object",
             "properties": {
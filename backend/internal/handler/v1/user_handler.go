@@ -1,6 +1,7 @@
 package v1
 
 import (
+	"duels-api/internal/handler/middleware"
 	"duels-api/internal/model"
 	_ "duels-api/internal/model"
 	"duels-api/internal/service"
@@ -25,17 +26,35 @@ func NewUserHandler(
 }
 
 func (h *UserHandler) RegisterRoutes(app *fiber.App, auth *AuthHandler) {
+	app.Get("/achievements", h.GetAchievements)
+
+	usersGroup := app.Group("/users")
+	{
+		usersGroup.Get("/:id<guid>", h.GetPublicProfileByID)
+		usersGroup.Get("/:username", h.GetPublicProfileByUsername)
+
+		usersGroup.Post("/:id<guid>/follow", auth.AuthMiddleware, h.Follow)
+		usersGroup.Delete("/:id<guid>/follow", auth.AuthMiddleware, h.Unfollow)
+	}
+
 	userGroup := app.Group("/user")
 
 	userGroup.Use(auth.AuthMiddleware)
 	{
 		userGroup.Get("/", h.GetUser)
-		userGroup.Put("/profile-picture", h.SetProfilePicture)
+		userGroup.Put("/profile-picture", auth.RateLimit(middleware.LimitPolicyUpload), h.SetProfilePicture)
 
 		userGroup.Put("/username", h.ChangeUsername)
-		userGroup.Put("/upload-images", h.UploadImage, auth.UploadUserImageMiddleware)
+		userGroup.Put("/profile", h.UpdateProfile)
+		userGroup.Put("/privacy", h.UpdatePrivacy)
+		userGroup.Put("/upload-images", auth.RateLimit(middleware.LimitPolicyUpload), h.UploadImage)
 
 		userGroup.Get("/stats", h.GetStats)
+
+		userGroup.Get("/wallets", h.GetWallets)
+		userGroup.Post("/wallets", h.LinkWallet)
+		userGroup.Delete("/wallets/:address", h.UnlinkWallet)
+		userGroup.Put("/wallets/:address/primary", h.SetPrimaryWallet)
 	}
 }
 
@@ -71,13 +90,14 @@ func (h *UserHandler) GetUser(c fiber.Ctx) error {
 //	@Summary		Set user profile picture
 //	@Description	Allows an authenticated user to upload a new profile image.
 //	@Description	The uploaded image will replace the existing profile picture and return its new public URL.
+//	@Description	JPEG, PNG and SVG images are accepted by content, they are cropped to a 512px square and re-encoded without metadata, a 128px thumbnail is stored next to it.
 //	@Tags			user
 //	@Accept			multipart/form-data
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			Authorization	header		string						true	"Authorization Bearer token"
 //	@Param			image			formData	file						true	"Profile image file"
-//	@Success		200				{object}	object{image_url=string}	"Profile picture successfully updated"
+//	@Success		200				{object}	object{image_url=string,thumbnail_url=string}	"Profile picture successfully updated"
 //	@Failure		400				{object}	apperrors.ErrorPublic		"Invalid request data or missing image file"
 //	@Failure		401				{object}	apperrors.ErrorPublic		"Unauthorized - invalid or missing token"
 //	@Failure		500				{object}	apperrors.ErrorPublic		"Internal server error during image upload"
@@ -99,7 +119,8 @@ func (h *UserHandler) SetProfilePicture(c fiber.Ctx) error {
 	}
 
 	return c.JSON(fiber.Map{
-		"image_url": imageURL,
+		"image_url":     imageURL,
+		"thumbnail_url": service.ThumbnailKey(imageURL),
 	})
 }
 
@@ -143,14 +164,16 @@ func (h *UserHandler) ChangeUsername(c fiber.Ctx) error {
 //	@Summary		Upload user images
 //	@Description	Allows an authenticated user to upload one or more image files.
 //	@Description	Each image is stored, and the service returns an array of accessible URLs.
+//	@Description	JPEG, PNG and SVG images are accepted by content, they are scaled to fit 1600px and re-encoded without metadata, a 480px thumbnail is stored for each of them.
 //	@Description	Upload rate is limited via middleware to prevent spam (max 3 per minute).
+//	@Description	Images not used by a duel are deleted after UPLOAD_ORPHAN_TTL.
 //	@Tags			user
 //	@Accept			multipart/form-data
 //	@Produce		json
 //	@Security		BearerAuth
 //	@Param			Authorization	header		string							true	"Authorization Bearer token"
 //	@Param			images			formData	[]file							true	"Array of images to upload"
-//	@Success		200				{object}	object{image_urls=[]string}		"Images uploaded successfully, returns list of image URLs"
+//	@Success		200				{object}	object{image_urls=[]string,thumbnail_urls=[]string}		"Images uploaded successfully, returns list of image URLs"
 //	@Failure		400				{object}	apperrors.ErrorPublic			"Invalid request data or missing image files"
 //	@Failure		401				{object}	apperrors.ErrorPublic			"Unauthorized - missing or invalid token"
 //	@Failure		429				{object}	apperrors.ErrorPublic			"Too many uploads - rate limit exceeded"
@@ -167,13 +190,24 @@ func (h *UserHandler) UploadImage(c fiber.Ctx) error {
 		return apperrors.BadRequest("no images provided")
 	}
 
-	fileNames, err := h.FileService.SaveUserFiles(files)
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	fileNames, err := h.FileService.SaveUserFiles(c.Context(), claims.UserID, files)
 	if err != nil {
 		return err
 	}
 
+	thumbnails := make([]string, len(fileNames))
+	for i, fileName := range fileNames {
+		thumbnails[i] = service.ThumbnailKey(fileName)
+	}
+
 	return c.JSON(fiber.Map{
-		"image_urls": fileNames,
+		"image_urls":     fileNames,
+		"thumbnail_urls": thumbnails,
 	})
 }
 
@@ -0,0 +1,196 @@
+package v1
+
+import (
+	"duels-api/internal/model"
+	"duels-api/pkg/apperrors"
+	auth "duels-api/pkg/jwt"
+
+	"github.com/gofiber/fiber/v3"
+	"github.com/google/uuid"
+)
+
+// GetPublicProfileByID godoc
+//
+//	@Summary		Get public user profile by ID
+//	@Description	Returns the public profile of a user: bio, social links, follower counts, XP level, achievements, stats, created duels and recent results.
+//	@Description	Stats and recent results are null if the user hides them.
+//	@Tags			user
+//	@Produce		json
+//	@Param			id	path		string					true	"User ID (UUID)"
+//	@Success		200	{object}	model.PublicProfile		"Public profile"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"User not found"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/users/{id} [get]
+func (h *UserHandler) GetPublicProfileByID(c fiber.Ctx) error {
+	userID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid user ID", err)
+	}
+
+	profile, err := h.UserService.GetPublicProfileByID(c.Context(), userID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(profile)
+}
+
+// GetPublicProfileByUsername godoc
+//
+//	@Summary		Get public user profile by username
+//	@Description	Returns the public profile of a user: bio, social links, follower counts, XP level, achievements, stats, created duels and recent results.
+//	@Description	Stats and recent results are null if the user hides them.
+//	@Tags			user
+//	@Produce		json
+//	@Param			username	path		string					true	"Username"
+//	@Success		200			{object}	model.PublicProfile		"Public profile"
+//	@Failure		404			{object}	apperrors.ErrorPublic	"User not found"
+//	@Failure		500			{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/users/{username} [get]
+func (h *UserHandler) GetPublicProfileByUsername(c fiber.Ctx) error {
+	profile, err := h.UserService.GetPublicProfileByUsername(c.Context(), c.Params("username"))
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(profile)
+}
+
+// UpdateProfile godoc
+//
+//	@Summary		Update public profile
+//	@Description	Sets the bio (up to 280 characters) and social links (http or https urls) shown on the public profile, empty values clear them.
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		model.UpdateProfileReq	true	"Profile fields"
+//	@Success		204		{object}	nil						"Profile updated"
+//	@Failure		400		{object}	apperrors.ErrorPublic	"Invalid bio or social links"
+//	@Failure		401		{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/user/profile [put]
+func (h *UserHandler) UpdateProfile(c fiber.Ctx) error {
+	var req model.UpdateProfileReq
+	if err := c.Bind().JSON(&req); err != nil {
+		return apperrors.BadRequest("invalid request params")
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	if err := h.UserService.UpdateProfile(c.Context(), claims.UserID, &req); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// UpdatePrivacy godoc
+//
+//	@Summary		Update profile privacy
+//	@Description	Hides stats or duel history from the public profile, created duels stay visible.
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		model.UpdatePrivacyReq	true	"Privacy toggles"
+//	@Success		204		{object}	nil						"Privacy settings updated"
+//	@Failure		400		{object}	apperrors.ErrorPublic	"Invalid request"
+//	@Failure		401		{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/user/privacy [put]
+func (h *UserHandler) UpdatePrivacy(c fiber.Ctx) error {
+	var req model.UpdatePrivacyReq
+	if err := c.Bind().JSON(&req); err != nil {
+		return apperrors.BadRequest("invalid request params")
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	if err := h.UserService.UpdatePrivacy(c.Context(), claims.UserID, &req); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Follow godoc
+//
+//	@Summary		Follow user
+//	@Description	Duels created by the followed user appear in the feed and their followers are notified about them. Following a user twice is not an error.
+//	@Tags			user
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"User ID (UUID)"
+//	@Success		204	{object}	nil						"User followed"
+//	@Failure		400	{object}	apperrors.ErrorPublic	"Invalid user ID or following yourself"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"User not found"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/users/{id}/follow [post]
+func (h *UserHandler) Follow(c fiber.Ctx) error {
+	followeeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid user ID", err)
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	if err = h.UserService.Follow(c.Context(), claims.UserID, followeeID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// Unfollow godoc
+//
+//	@Summary		Unfollow user
+//	@Tags			user
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			id	path		string					true	"User ID (UUID)"
+//	@Success		204	{object}	nil						"User unfollowed"
+//	@Failure		400	{object}	apperrors.ErrorPublic	"Invalid user ID"
+//	@Failure		401	{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		404	{object}	apperrors.ErrorPublic	"User is not followed"
+//	@Failure		500	{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/users/{id}/follow [delete]
+func (h *UserHandler) Unfollow(c fiber.Ctx) error {
+	followeeID, err := uuid.Parse(c.Params("id"))
+	if err != nil {
+		return apperrors.BadRequest("invalid user ID", err)
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	if err = h.UserService.Unfollow(c.Context(), claims.UserID, followeeID); err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// GetAchievements godoc
+//
+//	@Summary		List achievements
+//	@Description	Returns all achievements with the XP awarded on unlock, profiles refer to them by code.
+//	@Tags			user
+//	@Produce		json
+//	@Success		200	{array}	model.Achievement	"Achievements"
+//	@Router			/achievements [get]
+func (h *UserHandler) GetAchievements(c fiber.Ctx) error {
+	return c.JSON(model.AchievementList())
+}
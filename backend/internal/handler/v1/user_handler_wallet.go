@@ -0,0 +1,123 @@
+package v1
+
+import (
+	"duels-api/internal/model"
+	"duels-api/pkg/apperrors"
+	auth "duels-api/pkg/jwt"
+
+	"github.com/gofiber/fiber/v3"
+)
+
+// GetWallets godoc
+//
+//	@Summary		Get linked wallets
+//	@Description	Returns wallets linked to the authenticated user, the primary wallet first.
+//	@Tags			user
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Success		200	{object}	object{wallets=[]model.UserWallet}	"Linked wallets"
+//	@Failure		401	{object}	apperrors.ErrorPublic				"Unauthorized - missing or invalid token"
+//	@Failure		500	{object}	apperrors.ErrorPublic				"Internal server error"
+//	@Router			/user/wallets [get]
+func (h *UserHandler) GetWallets(c fiber.Ctx) error {
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	wallets, err := h.UserService.GetWallets(c.Context(), claims.UserID)
+	if err != nil {
+		return err
+	}
+
+	return c.JSON(fiber.Map{"wallets": wallets})
+}
+
+// LinkWallet godoc
+//
+//	@Summary		Link wallet
+//	@Description	Links another wallet to the authenticated user. The wallet proves ownership by signing the message issued by /auth/challenge.
+//	@Tags			user
+//	@Accept			json
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			request	body		model.AuthWithWallet	true	"Wallet address, challenge nonce and signature"
+//	@Success		201		{object}	model.UserWallet		"Wallet linked"
+//	@Failure		400		{object}	apperrors.ErrorPublic	"Invalid request body"
+//	@Failure		401		{object}	apperrors.ErrorPublic	"Unauthorized - invalid token, challenge or signature"
+//	@Failure		409		{object}	apperrors.ErrorPublic	"Wallet is already linked"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/user/wallets [post]
+func (h *UserHandler) LinkWallet(c fiber.Ctx) error {
+	var req model.AuthWithWallet
+	if err := c.Bind().JSON(&req); err != nil {
+		return apperrors.BadRequest("invalid request body")
+	}
+
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	wallet, err := h.UserService.LinkWallet(c.Context(), claims.UserID, req)
+	if err != nil {
+		return err
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(wallet)
+}
+
+// UnlinkWallet godoc
+//
+//	@Summary		Unlink wallet
+//	@Description	Unlinks a wallet from the authenticated user. The primary wallet can not be unlinked.
+//	@Tags			user
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			address	path		string					true	"Wallet address"
+//	@Success		204		{object}	nil						"Wallet unlinked"
+//	@Failure		400		{object}	apperrors.ErrorPublic	"Primary wallet can not be unlinked"
+//	@Failure		401		{object}	apperrors.ErrorPublic	"Unauthorized - missing or invalid token"
+//	@Failure		404		{object}	apperrors.ErrorPublic	"Wallet is not linked"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/user/wallets/{address} [delete]
+func (h *UserHandler) UnlinkWallet(c fiber.Ctx) error {
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	err := h.UserService.UnlinkWallet(c.Context(), claims.UserID, c.Params("address"))
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
+
+// SetPrimaryWallet godoc
+//
+//	@Summary		Switch primary wallet
+//	@Description	Makes the linked wallet primary. The primary wallet is the default payer and payout address.
+//	@Tags			user
+//	@Produce		json
+//	@Security		BearerAuth
+//	@Param			address	path		string					true	"Wallet address"
+//	@Success		204		{object}	nil						"Primary wallet switched"
+//	@Failure		401		{object}	apperrors.ErrorPublic	"Unauthorized - missing or invalid token"
+//	@Failure		404		{object}	apperrors.ErrorPublic	"Wallet is not linked"
+//	@Failure		500		{object}	apperrors.ErrorPublic	"Internal server error"
+//	@Router			/user/wallets/{address}/primary [put]
+func (h *UserHandler) SetPrimaryWallet(c fiber.Ctx) error {
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	err := h.UserService.SetPrimaryWallet(c.Context(), claims.UserID, c.Params("address"))
+	if err != nil {
+		return err
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
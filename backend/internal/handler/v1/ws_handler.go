@@ -1,8 +1,10 @@
 package v1
 
 import (
+	"bufio"
 	"duels-api/config"
 	"duels-api/internal/client/ws"
+	"duels-api/internal/service"
 	"duels-api/internal/storage/cache"
 	"duels-api/pkg/apperrors"
 	"strings"
@@ -11,28 +13,32 @@ import (
 	auth "duels-api/pkg/jwt"
 	"github.com/fasthttp/websocket"
 	"github.com/gofiber/fiber/v3"
-	"github.com/google/uuid"
+	"github.com/gofiber/fiber/v3/middleware/expvar"
 	"github.com/valyala/fasthttp"
 	"go.uber.org/zap"
 )
 
 type WSHandler struct {
-	EventPubSub  *cache.EventPubSub
+	Hub          *ws.Hub
+	JWTService   *service.JWTService
 	AllowOrigins string
 }
 
 func NewWSHandler(
-	eventPubSub *cache.EventPubSub,
+	hub *ws.Hub,
+	jwtService *service.JWTService,
 	c *config.Config,
 ) *WSHandler {
 	return &WSHandler{
-		EventPubSub:  eventPubSub,
+		Hub:          hub,
+		JWTService:   jwtService,
 		AllowOrigins: c.HTTP.AllowOrigins,
 	}
 }
 
 func (h *WSHandler) RegisterRoutes(app *fiber.App, auth *AuthHandler) {
-	wsGroup := app.Group("/ws", auth.AuthMiddlewareQuery)
+	// the connection is authenticated by its first message, tokens are kept out of urls and logs
+	wsGroup := app.Group("/ws")
 	{
 		upgrader := websocket.FastHTTPUpgrader{
 			HandshakeTimeout: 10 * time.Second,
@@ -43,18 +49,28 @@ func (h *WSHandler) RegisterRoutes(app *fiber.App, auth *AuthHandler) {
 		}
 		wsGroup.Get("/", h.NewWS(upgrader))
 	}
+
+	app.Get("/events", auth.AuthMiddleware, h.Events)
+
+	// gauges of the hub and the runtime
+	app.Get("/debug/vars", auth.AuthMiddleware, auth.AdminMiddleware, expvar.New())
 }
 
+// NewWS upgrades the connection, the first message must be {"action":"auth","token":"<access token>"}
+// and is answered with the expiry of the token, {"action":"refresh","token":"<access token>"} extends
+// the connection before it expires. Connections are closed with 4002 when the token expires without
+// a refresh and with 4003 when the session is revoked, rotating the tokens over HTTP does not close them.
+// The user receives own events and may subscribe to public topics with {"action":"subscribe","topic":"duel:<id>"}
+// and unsubscribe with {"action":"unsubscribe","topic":"duel:<id>"}, topic events carry the topic they belong to.
+// Own events carry event_id, reconnecting with last_event_id replays the events missed since then.
+// If some of them were trimmed already, a reset event (type 14) is sent first and the client must reload its state
 func (h *WSHandler) NewWS(upgrader websocket.FastHTTPUpgrader) fiber.Handler {
 	return func(c fiber.Ctx) error {
-		claims, ok := c.Locals("claims").(auth.TokenClaims)
-		if !ok {
-			return apperrors.Unauthorized("claims not found")
+		lastEventID := c.Query("last_event_id")
+		if lastEventID != "" && !cache.ValidEventID(lastEventID) {
+			return apperrors.BadRequest("invalid last_event_id")
 		}
 
-		// Ensure the hub has the pub/sub set
-		ws.Stream().SetEventPubSub(h.EventPubSub)
-
 		err := upgrader.Upgrade(
 			c.RequestCtx(),
 			func(conn *websocket.Conn) {
@@ -63,7 +79,7 @@ func (h *WSHandler) NewWS(upgrader websocket.FastHTTPUpgrader) fiber.Handler {
 					zap.L().Error("error closing websocket", zap.Error(err))
 				}()
 
-				h.Conn(conn, claims.UserID)
+				h.Hub.Accept(conn, lastEventID, h.JWTService.ParseAccessToken)
 			})
 		if err != nil {
 			return fiber.ErrUpgradeRequired
@@ -73,6 +89,48 @@ func (h *WSHandler) NewWS(upgrader websocket.FastHTTPUpgrader) fiber.Handler {
 	}
 }
 
-func (h *WSHandler) Conn(conn *websocket.Conn, userID uuid.UUID) {
-	ws.Stream().Conn(conn, userID)
+// Events godoc
+//
+//	@Summary		Stream events (SSE)
+//	@Description	Streams the events of the authenticated user as Server-Sent Events, the same envelopes as delivered over /ws.
+//	@Description	The SSE id of an event is its event_id, reconnecting with the Last-Event-ID header (or the last_event_id query param) replays the events missed since then within the retention window.
+//	@Description	If some of the missed events were trimmed already, a reset event (type 14) is sent before the retained ones and the client must reload its state.
+//	@Description	A heartbeat comment is sent every 15 seconds.
+//	@Description	The stream ends when the access token expires, where a WebSocket connection is closed with 4002, or when the session is revoked (4003). Refreshing tokens does not end it, reconnect with a fresh access token and Last-Event-ID.
+//	@Tags			events
+//	@Produce		text/event-stream
+//	@Security		BearerAuth
+//	@Param			Last-Event-ID	header		string					false	"event_id of the last received event"
+//	@Param			last_event_id	query		string					false	"event_id of the last received event, for clients that cannot set headers"
+//	@Success		200				{string}	string					"Event stream"
+//	@Failure		400				{object}	apperrors.ErrorPublic	"Invalid last event id"
+//	@Failure		401				{object}	apperrors.ErrorPublic	"Unauthorized"
+//	@Failure		429				{object}	apperrors.ErrorPublic	"Too many connections"
+//	@Failure		503				{object}	apperrors.ErrorPublic	"Server is shutting down"
+//	@Router			/events [get]
+func (h *WSHandler) Events(c fiber.Ctx) error {
+	claims, ok := c.Locals("claims").(auth.TokenClaims)
+	if !ok {
+		return apperrors.Unauthorized("claims not found")
+	}
+
+	lastEventID := c.Get("Last-Event-ID", c.Query("last_event_id"))
+	if lastEventID != "" && !cache.ValidEventID(lastEventID) {
+		return apperrors.BadRequest("invalid last event id")
+	}
+
+	client, err := h.Hub.Register(claims, lastEventID)
+	if err != nil {
+		return err
+	}
+
+	c.Set(fiber.HeaderContentType, "text/event-stream")
+	c.Set(fiber.HeaderCacheControl, "no-cache")
+	c.Set(fiber.HeaderConnection, "keep-alive")
+	// disables response buffering of nginx
+	c.Set("X-Accel-Buffering", "no")
+
+	return c.SendStreamWriter(func(w *bufio.Writer) {
+		h.Hub.ServeSSE(w, client)
+	})
 }
@@ -0,0 +1,129 @@
+package model
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	AchievementFirstJoin        = "first_join"
+	AchievementFirstDuelCreated = "first_duel_created"
+	AchievementFirstWin         = "first_win"
+	AchievementWinStreak10      = "win_streak_10"
+	AchievementDuel100Players   = "duel_100_players"
+)
+
+type Achievement struct {
+	Code        string `json:"code"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// XP is awarded on unlock on top of the XP of the event
+	XP uint64 `json:"xp"`
+}
+
+var Achievements = map[string]Achievement{
+	AchievementFirstJoin: {
+		Code:        AchievementFirstJoin,
+		Title:       "First Prediction",
+		Description: "Joined a duel for the first time",
+		XP:          50,
+	},
+	AchievementFirstDuelCreated: {
+		Code:        AchievementFirstDuelCreated,
+		Title:       "Host",
+		Description: "Created a duel for the first time",
+		XP:          50,
+	},
+	AchievementFirstWin: {
+		Code:        AchievementFirstWin,
+		Title:       "First Win",
+		Description: "Won a duel for the first time",
+		XP:          100,
+	},
+	AchievementWinStreak10: {
+		Code:        AchievementWinStreak10,
+		Title:       "Unstoppable",
+		Description: "Won 10 duels in a row",
+		XP:          1000,
+	},
+	AchievementDuel100Players: {
+		Code:        AchievementDuel100Players,
+		Title:       "Crowd Puller",
+		Description: "Created a duel 100 players joined",
+		XP:          500,
+	},
+}
+
+// AchievementList returns the achievements sorted by code
+func AchievementList() []Achievement {
+	list := make([]Achievement, 0, len(Achievements))
+	for _, achievement := range Achievements {
+		list = append(list, achievement)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Code < list[j].Code
+	})
+
+	return list
+}
+
+// DuelEventXP is awarded to the user of the event
+var DuelEventXP = map[DuelEventType]uint64{
+	DuelEventCreated: 20,
+	DuelEventJoined:  10,
+	DuelEventWon:     50,
+	DuelEventLost:    5,
+}
+
+type UserAchievement struct {
+	bun.BaseModel `bun:"table:user_achievements,alias:ua" json:"-"`
+
+	UserID      uuid.UUID `bun:"user_id,pk,type:uuid" json:"-"`
+	Achievement string    `bun:"achievement,pk" json:"achievement"`
+	UnlockedAt  time.Time `bun:"unlocked_at,notnull,default:current_timestamp" json:"unlocked_at"`
+}
+
+func NewUserAchievement(userID uuid.UUID, achievement string) *UserAchievement {
+	return &UserAchievement{
+		UserID:      userID,
+		Achievement: achievement,
+		UnlockedAt:  time.Now().UTC(),
+	}
+}
+
+// xpPerLevel is the XP of the second level, reaching level n takes xpPerLevel*(n-1)^2 XP
+const xpPerLevel = 100
+
+type UserProgress struct {
+	XP          uint64 `json:"xp"`
+	Level       uint64 `json:"level"`
+	NextLevelXP uint64 `json:"next_level_xp"`
+}
+
+func NewUserProgress(xp uint64) UserProgress {
+	level := Level(xp)
+
+	return UserProgress{
+		XP:          xp,
+		Level:       level,
+		NextLevelXP: xpPerLevel * level * level,
+	}
+}
+
+func Level(xp uint64) uint64 {
+	level := uint64(math.Sqrt(float64(xp)/xpPerLevel)) + 1
+	// float rounding must not skip a level boundary
+	for xpPerLevel*level*level <= xp {
+		level++
+	}
+	for level > 1 && xpPerLevel*(level-1)*(level-1) > xp {
+		level--
+	}
+
+	return level
+}
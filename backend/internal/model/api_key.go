@@ -0,0 +1,132 @@
+package model
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"duels-api/pkg/apperrors"
+	"encoding/hex"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	APIKeyScopeReadDuels    = "duels:read"
+	APIKeyScopeSignJoinTx   = "duels:join"
+	APIKeyScopeResolveDuels = "duels:resolve"
+)
+
+var APIKeyScopes = []string{
+	APIKeyScopeReadDuels,
+	APIKeyScopeSignJoinTx,
+	APIKeyScopeResolveDuels,
+}
+
+const (
+	APIKeyHeader = "X-API-Key"
+
+	apiKeyPrefix      = "dk_"
+	apiKeySecretBytes = 32
+	apiKeyPrefixLen   = 8
+	APIKeyMaxNameLen  = 64
+)
+
+// APIKey authenticates bots and integrators on behalf of the user.
+// Only the hash of the key is stored, the key itself is shown once on creation.
+type APIKey struct {
+	bun.BaseModel `bun:"table:api_keys,alias:ak" json:"-"`
+
+	ID       uuid.UUID `bun:",pk,type:uuid" json:"id"`
+	UserID   uuid.UUID `bun:"user_id,type:uuid,notnull" json:"user_id"`
+	IssuedBy uuid.UUID `bun:"issued_by,type:uuid,notnull" json:"issued_by"`
+	Name     string    `bun:"name,type:varchar(64),notnull" json:"name"`
+	// Prefix is the public part of the key, it helps to tell keys apart
+	Prefix string   `bun:"prefix,type:varchar(16),notnull" json:"prefix"`
+	Hash   string   `bun:"key_hash,type:varchar(64),notnull" json:"-"`
+	Scopes []string `bun:"scopes,array,notnull" json:"scopes"`
+	// RateLimit is the number of requests allowed per minute
+	RateLimit uint32 `bun:"rate_limit,type:integer,notnull" json:"rate_limit"`
+
+	LastUsedAt *time.Time `bun:"last_used_at" json:"last_used_at"`
+	ExpiresAt  *time.Time `bun:"expires_at" json:"expires_at"`
+	RevokedAt  *time.Time `bun:"revoked_at" json:"revoked_at"`
+	CreatedAt  time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+}
+
+type CreateAPIKeyReq struct {
+	Name   string   `json:"name"`
+	Scopes []string `json:"scopes"`
+	// RateLimit per minute, the default limit is used if zero
+	RateLimit uint32     `json:"rate_limit"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+type AdminCreateAPIKeyReq struct {
+	CreateAPIKeyReq
+	UserID uuid.UUID `json:"user_id"`
+}
+
+type IssuedAPIKey struct {
+	*APIKey
+	Key string `json:"key"`
+}
+
+// NewAPIKey generates a key for the user and returns it with its model
+func NewAPIKey(userID, issuedBy uuid.UUID, req *CreateAPIKeyReq) (*IssuedAPIKey, error) {
+	secret := make([]byte, apiKeySecretBytes)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, apperrors.Internal("failed to generate api key", err)
+	}
+
+	id, err := uuid.NewV7()
+	if err != nil {
+		return nil, apperrors.Internal("failed to generate api key id", err)
+	}
+
+	key := apiKeyPrefix + hex.EncodeToString(secret)
+
+	return &IssuedAPIKey{
+		APIKey: &APIKey{
+			ID:        id,
+			UserID:    userID,
+			IssuedBy:  issuedBy,
+			Name:      req.Name,
+			Prefix:    key[:len(apiKeyPrefix)+apiKeyPrefixLen],
+			Hash:      HashAPIKey(key),
+			Scopes:    req.Scopes,
+			RateLimit: req.RateLimit,
+			ExpiresAt: req.ExpiresAt,
+			CreatedAt: time.Now().UTC(),
+		},
+		Key: key,
+	}, nil
+}
+
+// HashAPIKey hashes the key for lookup, keys are random enough to not need a slow hash
+func HashAPIKey(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(sum[:])
+}
+
+func IsAPIKey(key string) bool {
+	return strings.HasPrefix(key, apiKeyPrefix)
+}
+
+func ValidAPIKeyScope(scope string) bool {
+	return slices.Contains(APIKeyScopes, scope)
+}
+
+func (k *APIKey) HasScope(scope string) bool {
+	return slices.Contains(k.Scopes, scope)
+}
+
+func (k *APIKey) Active(now time.Time) bool {
+	if k.RevokedAt != nil {
+		return false
+	}
+
+	return k.ExpiresAt == nil || now.Before(*k.ExpiresAt)
+}
@@ -0,0 +1,152 @@
+package model
+
+import (
+	"encoding/base64"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	CommentStatusActive uint8 = iota
+	// CommentStatusDeleted comments were deleted by the author
+	CommentStatusDeleted
+	// CommentStatusRemoved comments were removed by a moderator
+	CommentStatusRemoved
+)
+
+const CommentMaxLength = 1000
+
+// Comment is a top-level comment of a duel or a reply. Replies to replies join
+// the thread of the top-level comment, RootID is the thread and ParentID is the
+// comment replied to
+type Comment struct {
+	bun.BaseModel `bun:"table:comments,alias:c" json:"-"`
+
+	ID           uuid.UUID  `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	DuelID       uuid.UUID  `bun:"duel_id,type:uuid,notnull" json:"duel_id"`
+	UserID       uuid.UUID  `bun:"user_id,type:uuid,notnull" json:"user_id"`
+	ParentID     *uuid.UUID `bun:"parent_id,type:uuid" json:"parent_id"`
+	RootID       *uuid.UUID `bun:"root_id,type:uuid" json:"root_id"`
+	Text         string     `bun:"text,notnull" json:"text"`
+	Status       uint8      `bun:"status,notnull,default:0" json:"status"`
+	RepliesCount uint64     `bun:"replies_count,notnull,default:0" json:"replies_count"`
+	PinnedAt     *time.Time `bun:"pinned_at" json:"pinned_at"`
+	EditedAt     *time.Time `bun:"edited_at" json:"edited_at"`
+	CreatedAt    time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+
+	Username string `bun:"username,scanonly" json:"username"`
+	ImageUrl string `bun:"image_url,scanonly" json:"image_url"`
+}
+
+func NewComment(duelID, userID uuid.UUID, parent *Comment, text string) *Comment {
+	comment := &Comment{
+		ID:        uuid.New(),
+		DuelID:    duelID,
+		UserID:    userID,
+		Text:      text,
+		Status:    CommentStatusActive,
+		CreatedAt: time.Now().UTC(),
+	}
+
+	if parent != nil {
+		rootID := parent.ThreadID()
+		comment.ParentID = &parent.ID
+		comment.RootID = &rootID
+	}
+
+	return comment
+}
+
+// ThreadID returns the id of the top-level comment of the thread
+func (c *Comment) ThreadID() uuid.UUID {
+	if c.RootID != nil {
+		return *c.RootID
+	}
+	return c.ID
+}
+
+func (c *Comment) Active() bool {
+	return c.Status == CommentStatusActive
+}
+
+// Redact hides the text of deleted and removed comments, they are kept to preserve threads
+func (c *Comment) Redact() {
+	if !c.Active() {
+		c.Text = ""
+	}
+}
+
+// NormalizeCommentText trims the text and reports whether it is a valid comment
+func NormalizeCommentText(text string) (string, bool) {
+	text = strings.TrimSpace(text)
+
+	if text == "" || utf8.RuneCountInString(text) > CommentMaxLength {
+		return "", false
+	}
+
+	return text, true
+}
+
+type CreateCommentReq struct {
+	DuelID   uuid.UUID  `json:"duel_id"`
+	ParentID *uuid.UUID `json:"parent_id"`
+	Text     string     `json:"text"`
+}
+
+type UpdateCommentReq struct {
+	Text string `json:"text"`
+}
+
+type CommentsReq struct {
+	// Cursor is the next_cursor of the previous page, empty for the first page
+	Cursor string `query:"cursor"`
+	Limit  int    `query:"limit"`
+}
+
+type CommentPage struct {
+	// Pinned is the pinned comment of the duel, it is set on the first page of threads only
+	Pinned     *Comment  `json:"pinned,omitempty"`
+	Comments   []Comment `json:"comments"`
+	NextCursor string    `json:"next_cursor"`
+}
+
+// CommentCursor is a position in a list of comments ordered by creation time
+type CommentCursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
+}
+
+func NewCommentCursor(c *Comment) string {
+	raw := strconv.FormatInt(c.CreatedAt.UnixMicro(), 10) + ":" + c.ID.String()
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func ParseCommentCursor(cursor string) (*CommentCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, err
+	}
+
+	micros, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, errors.New("malformed comment cursor")
+	}
+
+	unix, err := strconv.ParseInt(micros, 10, 64)
+	if err != nil {
+		return nil, err
+	}
+
+	commentID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &CommentCursor{CreatedAt: time.UnixMicro(unix).UTC(), ID: commentID}, nil
+}
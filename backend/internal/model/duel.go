@@ -53,6 +53,9 @@ type Duel struct {
 
 	FinalResult        *uint8 `bun:"final_result,type:integer" json:"final_result"`
 	CancellationReason string `bun:"cancellation_reason,type:text" json:"cancellation_reason"`
+	// CreatorCommission is the commission paid to the owner once the duel is resolved
+	CreatorCommission float64    `bun:"creator_commission,type:numeric(15,9),notnull,default:0" json:"creator_commission"`
+	ResolvedAt        *time.Time `bun:"resolved_at" json:"resolved_at"`
 
 	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
 	UpdatedAt time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
@@ -105,6 +108,8 @@ type DuelShow struct {
 	Joined        bool   `bun:",column:joined" json:"joined"`
 	YourAnswer    *uint8 `bun:",column:your_answer" json:"your_answer"`
 	PlayerStatus  uint8  `bun:",column:player_status" json:"player_status"`
+	// Viewers is the number of users viewing the duel right now, it is set for duels in process
+	Viewers *uint64 `bun:"-" json:"viewers,omitempty"`
 }
 
 type CreateDuelReq struct {
@@ -119,7 +124,9 @@ type CreateDuelReq struct {
 
 	Answer uint8 `json:"answer"`
 
-	Hash string `json:"tx_hash"`
+	// Wallet is the linked wallet paying for the duel, the primary wallet if empty
+	Wallet string `json:"wallet"`
+	Hash   string `json:"tx_hash"`
 }
 
 type JoinDuelReq struct {
@@ -127,7 +134,9 @@ type JoinDuelReq struct {
 	Answer         uint8     `json:"answer"`
 	InvitedBy      string    `json:"invited_by"`
 	ExternalSource string    `json:"external_source"`
-	Hash           string    `json:"tx_hash"`
+	// Wallet is the linked wallet paying for the join, the primary wallet if empty
+	Wallet string `json:"wallet"`
+	Hash   string `json:"tx_hash"`
 }
 
 type DuelResolveReq struct {
@@ -181,16 +190,6 @@ type JoinSolanaRoomResp struct {
 	TxHash string `json:"tx_hash"`
 }
 
-type CreateCryptoDuelResp struct {
-	Duel   *Duel               `json:"duel"`
-	Result *JoinSolanaRoomResp `json:"result"`
-}
-
-type JoinCryptoDuelResp struct {
-	Player *Player             `json:"player"`
-	Result *JoinSolanaRoomResp `json:"result"`
-}
-
 func AutoCancelReq(duel *Duel) *DuelCancelReq {
 	cancellationReason := SamePredictionCancellationReason
 	if duel.PlayersCount <= 1 {
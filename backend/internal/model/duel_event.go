@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type DuelEventType uint8
+
+const (
+	DuelEventCreated DuelEventType = iota + 1
+	DuelEventJoined
+	DuelEventPlayersJoined
+	DuelEventWon
+	DuelEventLost
+)
+
+// DuelEvent is something that happened to a user in a duel, achievements
+// and seasons are driven by these events
+type DuelEvent struct {
+	Type   DuelEventType
+	UserID uuid.UUID
+	DuelID uuid.UUID
+	// PlayersCount is set for DuelEventPlayersJoined
+	PlayersCount uint64
+	// WinStreak is set for DuelEventWon and DuelEventLost, it includes the duel
+	WinStreak uint64
+}
+
+// Rewards a duel event is credited with, each of them once
+const (
+	DuelEventRewardXP        = "xp"
+	DuelEventRewardSeason    = "season"
+	DuelEventRewardWinStreak = "win_streak"
+)
+
+// DuelEventCredit records that the reward of the event was credited to the user
+type DuelEventCredit struct {
+	bun.BaseModel `bun:"table:duel_event_credits,alias:dec" json:"-"`
+
+	UserID     uuid.UUID     `bun:"user_id,pk,type:uuid"`
+	DuelID     uuid.UUID     `bun:"duel_id,pk,type:uuid"`
+	EventType  DuelEventType `bun:"event_type,pk,type:smallint"`
+	Reward     string        `bun:"reward,pk,type:varchar(16)"`
+	CreditedAt time.Time     `bun:"credited_at,notnull,default:current_timestamp"`
+}
+
+func NewDuelEventCredit(event *DuelEvent, reward string) *DuelEventCredit {
+	return &DuelEventCredit{
+		UserID:     event.UserID,
+		DuelID:     event.DuelID,
+		EventType:  event.Type,
+		Reward:     reward,
+		CreditedAt: time.Now().UTC(),
+	}
+}
@@ -1,13 +1,36 @@
 package model
 
-import "github.com/goccy/go-json"
+import (
+	"time"
+
+	"github.com/goccy/go-json"
+)
 
 const (
 	EventInsufficientFunds uint8 = 1
+	EventOperationStage    uint8 = 2
+	EventDuelComment       uint8 = 3
+	EventDuelJoined        uint8 = 4
+	EventDuelResolved      uint8 = 5
+	EventDuelCancelled     uint8 = 6
+	EventSubscribed        uint8 = 7
+	EventUnsubscribed      uint8 = 8
+	EventError             uint8 = 9
+	EventAuthenticated     uint8 = 10
+	EventDuelViewers       uint8 = 11
+	EventCommentUpdated    uint8 = 12
+	// EventCommentHidden carries a redacted comment deleted by its author or removed by a moderator
+	EventCommentHidden uint8 = 13
+	// EventReplayReset is sent instead of a partial replay when events after last_event_id were
+	// trimmed, the client must reload its state. Retained events are replayed after it
+	EventReplayReset uint8 = 14
 )
 
 type Event struct {
 	Type uint8 `json:"type"`
+	// Topic is set for events delivered to topic subscribers
+	Topic   string `json:"topic,omitempty"`
+	Payload any    `json:"payload,omitempty"`
 }
 
 func (e *Event) MarshalBinary() ([]byte, error) {
@@ -21,3 +44,17 @@ func (e *Event) UnmarshalBinary(data []byte) error {
 func NewEvent(t uint8) *Event {
 	return &Event{Type: t}
 }
+
+func NewEventWithPayload(t uint8, payload any) *Event {
+	return &Event{Type: t, Payload: payload}
+}
+
+func NewTopicEvent(topic string, t uint8, payload any) *Event {
+	return &Event{Type: t, Topic: topic, Payload: payload}
+}
+
+// AuthenticatedEvent confirms the token of a connection, it has to be
+// refreshed before it expires or the connection is closed
+type AuthenticatedEvent struct {
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// Follow makes duels created by the followee appear in the follower's feed
+type Follow struct {
+	bun.BaseModel `bun:"table:follows,alias:f" json:"-"`
+
+	FollowerID uuid.UUID `bun:"follower_id,pk,type:uuid" json:"follower_id"`
+	FolloweeID uuid.UUID `bun:"followee_id,pk,type:uuid" json:"followee_id"`
+	CreatedAt  time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+}
+
+func NewFollow(followerID, followeeID uuid.UUID) *Follow {
+	return &Follow{
+		FollowerID: followerID,
+		FolloweeID: followeeID,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+type FollowCounts struct {
+	Followers uint64 `bun:"followers" json:"followers_count"`
+	Following uint64 `bun:"following" json:"following_count"`
+}
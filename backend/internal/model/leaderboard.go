@@ -0,0 +1,113 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type LeaderboardPeriod string
+
+const (
+	LeaderboardPeriodDaily   LeaderboardPeriod = "daily"
+	LeaderboardPeriodWeekly  LeaderboardPeriod = "weekly"
+	LeaderboardPeriodMonthly LeaderboardPeriod = "monthly"
+	LeaderboardPeriodAllTime LeaderboardPeriod = "all_time"
+)
+
+// LeaderboardPeriods are rolling windows over duel resolution time, zero is all time
+var LeaderboardPeriods = map[LeaderboardPeriod]time.Duration{
+	LeaderboardPeriodDaily:   24 * time.Hour,
+	LeaderboardPeriodWeekly:  7 * 24 * time.Hour,
+	LeaderboardPeriodMonthly: 30 * 24 * time.Hour,
+	LeaderboardPeriodAllTime: 0,
+}
+
+func (p LeaderboardPeriod) Valid() bool {
+	_, ok := LeaderboardPeriods[p]
+	return ok
+}
+
+type LeaderboardMetric string
+
+const (
+	LeaderboardMetricNetProfit  LeaderboardMetric = "net_profit"
+	LeaderboardMetricWins       LeaderboardMetric = "wins"
+	LeaderboardMetricWinRate    LeaderboardMetric = "win_rate"
+	LeaderboardMetricVolume     LeaderboardMetric = "volume"
+	LeaderboardMetricCommission LeaderboardMetric = "commission"
+)
+
+// leaderboardRankColumns maps metrics to the columns of leaderboard_stats
+var leaderboardRankColumns = map[LeaderboardMetric]string{
+	LeaderboardMetricNetProfit:  "net_profit_rank",
+	LeaderboardMetricWins:       "wins_rank",
+	LeaderboardMetricWinRate:    "win_rate_rank",
+	LeaderboardMetricVolume:     "volume_rank",
+	LeaderboardMetricCommission: "commission_rank",
+}
+
+func (m LeaderboardMetric) Valid() bool {
+	_, ok := leaderboardRankColumns[m]
+	return ok
+}
+
+// RankColumn returns the column the metric is ranked by, the value column is named by the metric itself
+func (m LeaderboardMetric) RankColumn() string {
+	return leaderboardRankColumns[m]
+}
+
+// LeaderboardStats is a precomputed row of the leaderboard, ranks are nil
+// if the user does not qualify for the metric in the period
+type LeaderboardStats struct {
+	bun.BaseModel `bun:"table:leaderboard_stats,alias:ls" json:"-"`
+
+	Period LeaderboardPeriod `bun:"period,pk" json:"period"`
+	UserID uuid.UUID         `bun:"user_id,pk,type:uuid" json:"user_id"`
+
+	Participated uint64   `bun:"participated" json:"participated"`
+	Wins         uint64   `bun:"wins" json:"wins"`
+	NetProfit    float64  `bun:"net_profit" json:"net_profit"`
+	WinRate      *float64 `bun:"win_rate" json:"win_rate"`
+	Volume       float64  `bun:"volume" json:"volume"`
+	Commission   float64  `bun:"commission" json:"commission"`
+
+	NetProfitRank  *uint64 `bun:"net_profit_rank" json:"net_profit_rank"`
+	WinsRank       *uint64 `bun:"wins_rank" json:"wins_rank"`
+	WinRateRank    *uint64 `bun:"win_rate_rank" json:"win_rate_rank"`
+	VolumeRank     *uint64 `bun:"volume_rank" json:"volume_rank"`
+	CommissionRank *uint64 `bun:"commission_rank" json:"commission_rank"`
+
+	RefreshedAt time.Time `bun:"refreshed_at" json:"refreshed_at"`
+}
+
+// LeaderboardEntry is a row of the leaderboard ranked by a single metric
+type LeaderboardEntry struct {
+	Rank     uint64    `bun:"rank" json:"rank"`
+	Value    float64   `bun:"value" json:"value"`
+	UserID   uuid.UUID `bun:"user_id" json:"user_id"`
+	Username string    `bun:"username" json:"username"`
+	ImageUrl string    `bun:"image_url" json:"image_url"`
+
+	Participated uint64   `bun:"participated" json:"participated"`
+	Wins         uint64   `bun:"wins" json:"wins"`
+	NetProfit    float64  `bun:"net_profit" json:"net_profit"`
+	WinRate      *float64 `bun:"win_rate" json:"win_rate"`
+	Volume       float64  `bun:"volume" json:"volume"`
+	Commission   float64  `bun:"commission" json:"commission"`
+}
+
+type LeaderboardReq struct {
+	Period   LeaderboardPeriod `query:"period"`
+	Metric   LeaderboardMetric `query:"metric"`
+	PageSize int               `query:"page_size"`
+	PageNum  int               `query:"page_num"`
+}
+
+type Leaderboard struct {
+	Period      LeaderboardPeriod  `json:"period"`
+	Metric      LeaderboardMetric  `json:"metric"`
+	Entries     []LeaderboardEntry `json:"entries"`
+	RefreshedAt *time.Time         `json:"refreshed_at"`
+}
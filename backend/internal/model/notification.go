@@ -20,6 +20,14 @@ const (
 	NotificationDuelPlayersJoined
 
 	NotificationDuelEndingSoon
+
+	NotificationFollowedDuelCreated
+
+	NotificationAchievementUnlocked
+
+	NotificationSeasonPrizePaid
+
+	NotificationCommentReply
 )
 
 const (
@@ -130,6 +138,54 @@ func (n *DuelEndingSoonNotification) Marshal() ([]byte, error) {
 	return json.Marshal(n)
 }
 
+type FollowedDuelCreatedNotification struct {
+	DuelID          uuid.UUID `json:"duel_id"`
+	DuelName        string    `json:"duel_name"`
+	CreatorID       uuid.UUID `json:"creator_id"`
+	CreatorUsername string    `json:"creator_username"`
+}
+
+func (n *FollowedDuelCreatedNotification) Marshal() ([]byte, error) {
+	return json.Marshal(n)
+}
+
+type AchievementUnlockedNotification struct {
+	Achievement string `json:"achievement"`
+	Title       string `json:"title"`
+	XP          uint64 `json:"xp"`
+	Level       uint64 `json:"level"`
+}
+
+func (n *AchievementUnlockedNotification) Marshal() ([]byte, error) {
+	return json.Marshal(n)
+}
+
+type SeasonPrizePaidNotification struct {
+	SeasonID    uuid.UUID `json:"season_id"`
+	SeasonName  string    `json:"season_name"`
+	Rank        uint64    `json:"rank"`
+	Amount      float64   `json:"amount"`
+	TxSignature string    `json:"tx_signature"`
+}
+
+func (n *SeasonPrizePaidNotification) Marshal() ([]byte, error) {
+	return json.Marshal(n)
+}
+
+type CommentReplyNotification struct {
+	DuelID         uuid.UUID `json:"duel_id"`
+	DuelName       string    `json:"duel_name"`
+	CommentID      uuid.UUID `json:"comment_id"`
+	ParentID       uuid.UUID `json:"parent_id"`
+	AuthorID       uuid.UUID `json:"author_id"`
+	AuthorUsername string    `json:"author_username"`
+	Text           string    `json:"text"`
+}
+
+func (n *CommentReplyNotification) Marshal() ([]byte, error) {
+	return json.Marshal(n)
+}
+
 type DuelResolveNotificationParams struct {
 	WinnerIDs         []uuid.UUID
 	Duel              *Duel
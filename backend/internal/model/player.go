@@ -18,14 +18,15 @@ const (
 type Player struct {
 	bun.BaseModel `bun:"table:players,alias:players" json:"-"`
 
-	ID          uuid.UUID `bun:",pk,type:uuid,default:uuid_generate_v4()" json:"id"`
-	UserID      uuid.UUID `bun:"type:uuid" json:"user_id"`
-	DuelID      uuid.UUID `bun:"type:uuid" json:"duel_id"`
-	WinAmount   float64   `bun:"type:int" json:"win_amount"`
-	Answer      uint8     `bun:"type:int" json:"answer"`
-	FinalStatus uint8     `bun:"type:smallint" json:"final_status"`
-	IsWinner    bool      `bun:"type:bool" json:"is_winner"`
-	CreatedAt   time.Time `bun:",column:created_at,notnull,default:current_timestamp" json:"created_at"`
+	ID            uuid.UUID `bun:",pk,type:uuid,default:uuid_generate_v4()" json:"id"`
+	UserID        uuid.UUID `bun:"type:uuid" json:"user_id"`
+	DuelID        uuid.UUID `bun:"type:uuid" json:"duel_id"`
+	WalletAddress string    `bun:"type:varchar(100),nullzero" json:"wallet_address"`
+	WinAmount     float64   `bun:"type:int" json:"win_amount"`
+	Answer        uint8     `bun:"type:int" json:"answer"`
+	FinalStatus   uint8     `bun:"type:smallint" json:"final_status"`
+	IsWinner      bool      `bun:"type:bool" json:"is_winner"`
+	CreatedAt     time.Time `bun:",column:created_at,notnull,default:current_timestamp" json:"created_at"`
 }
 
 type PlayerWithAddress struct {
@@ -0,0 +1,223 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// SeasonStatusActive seasons collect points until they end
+	SeasonStatusActive uint8 = iota
+	// SeasonStatusSettling seasons have their prizes computed and are being paid out
+	SeasonStatusSettling
+	SeasonStatusSettled
+)
+
+const (
+	SeasonPrizeStatusPending uint8 = iota
+	SeasonPrizeStatusPaid
+	// SeasonPrizeStatusFailed prizes have no transfer that may still land, they are sent again
+	SeasonPrizeStatusFailed
+	// SeasonPrizeStatusSending prizes are claimed by a payout, once their transfer signature
+	// is stored they are never sent again until the transfer is known to have failed
+	SeasonPrizeStatusSending
+)
+
+// TransferOutcome is what is known about a sent transfer
+type TransferOutcome uint8
+
+const (
+	// TransferPending transfers may still land
+	TransferPending TransferOutcome = iota
+	TransferConfirmed
+	// TransferFailed transfers landed with an error or can never land, sending again is safe
+	TransferFailed
+)
+
+// DuelEventSeasonPoints are awarded to the user of the event while a season is running
+var DuelEventSeasonPoints = map[DuelEventType]uint64{
+	DuelEventJoined: 10,
+	DuelEventWon:    30,
+}
+
+// MaxSeasonPrizeRanks limits the number of ranks sharing the prize pool
+const MaxSeasonPrizeRanks = 100
+
+type Season struct {
+	bun.BaseModel `bun:"table:seasons,alias:s" json:"-"`
+
+	ID        uuid.UUID `bun:"id,pk,type:uuid,default:gen_random_uuid()" json:"id"`
+	Name      string    `bun:"name,notnull" json:"name"`
+	StartsAt  time.Time `bun:"starts_at,notnull" json:"starts_at"`
+	EndsAt    time.Time `bun:"ends_at,notnull" json:"ends_at"`
+	PrizePool float64   `bun:"prize_pool,type:numeric(15,9),notnull" json:"prize_pool"`
+	// PrizeSplit is the percent of the prize pool per rank, starting at the first place
+	PrizeSplit []float64  `bun:"prize_split,type:jsonb,notnull" json:"prize_split"`
+	Status     uint8      `bun:"status,notnull,default:0" json:"status"`
+	SettledAt  *time.Time `bun:"settled_at" json:"settled_at"`
+	CreatedAt  time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+}
+
+func (s *Season) Running(now time.Time) bool {
+	return !now.Before(s.StartsAt) && now.Before(s.EndsAt)
+}
+
+// PrizeAmount returns the prize of the rank rounded to USDC precision
+func (s *Season) PrizeAmount(rank int) float64 {
+	if rank < 1 || rank > len(s.PrizeSplit) {
+		return 0
+	}
+
+	micro := s.PrizePool * s.PrizeSplit[rank-1] / 100 * 1_000_000
+
+	return float64(uint64(micro)) / 1_000_000
+}
+
+type CreateSeasonReq struct {
+	Name       string    `json:"name"`
+	StartsAt   time.Time `json:"starts_at"`
+	EndsAt     time.Time `json:"ends_at"`
+	PrizePool  float64   `json:"prize_pool"`
+	PrizeSplit []float64 `json:"prize_split"`
+}
+
+func (r *CreateSeasonReq) Valid() bool {
+	if r.Name == "" || len(r.Name) > 64 {
+		return false
+	}
+
+	if !r.EndsAt.After(r.StartsAt) || r.PrizePool < 0 {
+		return false
+	}
+
+	if len(r.PrizeSplit) > MaxSeasonPrizeRanks {
+		return false
+	}
+
+	var total float64
+	for _, percent := range r.PrizeSplit {
+		if percent <= 0 {
+			return false
+		}
+		total += percent
+	}
+
+	return total <= 100
+}
+
+func NewSeason(req *CreateSeasonReq) *Season {
+	return &Season{
+		ID:         uuid.New(),
+		Name:       req.Name,
+		StartsAt:   req.StartsAt.UTC(),
+		EndsAt:     req.EndsAt.UTC(),
+		PrizePool:  req.PrizePool,
+		PrizeSplit: req.PrizeSplit,
+		Status:     SeasonStatusActive,
+		CreatedAt:  time.Now().UTC(),
+	}
+}
+
+type SeasonPoints struct {
+	bun.BaseModel `bun:"table:season_points,alias:sp" json:"-"`
+
+	SeasonID     uuid.UUID `bun:"season_id,pk,type:uuid" json:"season_id"`
+	UserID       uuid.UUID `bun:"user_id,pk,type:uuid" json:"user_id"`
+	Points       uint64    `bun:"points,notnull" json:"points"`
+	Participated uint64    `bun:"participated,notnull" json:"participated"`
+	Wins         uint64    `bun:"wins,notnull" json:"wins"`
+	UpdatedAt    time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+// SeasonStanding is a row of the season standings, ties are broken by wins and
+// then by who reached the points first
+type SeasonStanding struct {
+	Rank          uint64    `bun:"rank" json:"rank"`
+	UserID        uuid.UUID `bun:"user_id" json:"user_id"`
+	Username      string    `bun:"username" json:"username"`
+	ImageUrl      string    `bun:"image_url" json:"image_url"`
+	PublicAddress string    `bun:"public_address" json:"-"`
+	Points        uint64    `bun:"points" json:"points"`
+	Participated  uint64    `bun:"participated" json:"participated"`
+	Wins          uint64    `bun:"wins" json:"wins"`
+}
+
+type SeasonPrize struct {
+	bun.BaseModel `bun:"table:season_prizes,alias:spz" json:"-"`
+
+	SeasonID    uuid.UUID  `bun:"season_id,pk,type:uuid" json:"season_id"`
+	UserID      uuid.UUID  `bun:"user_id,pk,type:uuid" json:"user_id"`
+	Rank        uint64     `bun:"rank,notnull" json:"rank"`
+	Points      uint64     `bun:"points,notnull" json:"points"`
+	Amount      float64    `bun:"amount,type:numeric(15,9),notnull" json:"amount"`
+	Wallet      string     `bun:"wallet,notnull" json:"wallet"`
+	Status      uint8      `bun:"status,notnull,default:0" json:"status"`
+	TxSignature *string    `bun:"tx_signature" json:"tx_signature"`
+	Error       *string    `bun:"error" json:"-"`
+	CreatedAt   time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	PaidAt      *time.Time `bun:"paid_at" json:"paid_at"`
+
+	// ClaimedBy identifies the payout attempt that holds the prize while it is sending
+	ClaimedBy string     `bun:"claimed_by,type:varchar(36),nullzero" json:"-"`
+	ClaimedAt *time.Time `bun:"claimed_at" json:"-"`
+	// LastValidBlockHeight is the height after which the stored transfer can never land
+	LastValidBlockHeight uint64 `bun:"last_valid_block_height,nullzero" json:"-"`
+
+	Username string `bun:"username,scanonly" json:"username"`
+}
+
+func NewSeasonPrize(season *Season, standing *SeasonStanding) SeasonPrize {
+	return SeasonPrize{
+		SeasonID:  season.ID,
+		UserID:    standing.UserID,
+		Rank:      standing.Rank,
+		Points:    standing.Points,
+		Amount:    season.PrizeAmount(int(standing.Rank)),
+		Wallet:    standing.PublicAddress,
+		Status:    SeasonPrizeStatusPending,
+		CreatedAt: time.Now().UTC(),
+		Username:  standing.Username,
+	}
+}
+
+// Claimable reports whether a payout may send a new transfer for the prize. A sending prize
+// is claimable only when its payout stopped before storing a transfer and the lease is over
+func (p *SeasonPrize) Claimable(now time.Time, lease time.Duration) bool {
+	switch p.Status {
+	case SeasonPrizeStatusPending, SeasonPrizeStatusFailed:
+		return true
+	case SeasonPrizeStatusSending:
+		return p.TxSignature == nil && p.ClaimedAt != nil && p.ClaimedAt.Add(lease).Before(now)
+	default:
+		return false
+	}
+}
+
+// StatusAfter returns the status of a sending prize once the outcome of its transfer is known
+func (p *SeasonPrize) StatusAfter(outcome TransferOutcome) uint8 {
+	switch outcome {
+	case TransferConfirmed:
+		return SeasonPrizeStatusPaid
+	case TransferFailed:
+		return SeasonPrizeStatusFailed
+	default:
+		return SeasonPrizeStatusSending
+	}
+}
+
+type SeasonPageReq struct {
+	PageSize int `query:"page_size"`
+	PageNum  int `query:"page_num"`
+}
+
+type SeasonStandings struct {
+	Season    *Season          `json:"season"`
+	Standings []SeasonStanding `json:"standings"`
+}
+
+type SeasonResults struct {
+	Season *Season       `json:"season"`
+	Prizes []SeasonPrize `json:"prizes"`
+}
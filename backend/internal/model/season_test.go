@@ -0,0 +1,61 @@
+package model
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSeasonPrizeClaimable(t *testing.T) {
+	const lease = 5 * time.Minute
+	now := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+	signature := "sig"
+	expired := now.Add(-lease - time.Second)
+	recent := now.Add(-time.Second)
+
+	tests := []struct {
+		name  string
+		prize SeasonPrize
+		want  bool
+	}{
+		{"pending", SeasonPrize{Status: SeasonPrizeStatusPending}, true},
+		{"failed", SeasonPrize{Status: SeasonPrizeStatusFailed, TxSignature: &signature}, true},
+		{"paid", SeasonPrize{Status: SeasonPrizeStatusPaid, TxSignature: &signature}, false},
+		{"sending within lease", SeasonPrize{Status: SeasonPrizeStatusSending, ClaimedAt: &recent}, false},
+		{"sending after lease", SeasonPrize{Status: SeasonPrizeStatusSending, ClaimedAt: &expired}, true},
+		{
+			"sending with stored transfer after lease",
+			SeasonPrize{Status: SeasonPrizeStatusSending, ClaimedAt: &expired, TxSignature: &signature},
+			false,
+		},
+		{"sending without claim time", SeasonPrize{Status: SeasonPrizeStatusSending}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.prize.Claimable(now, lease); got != tt.want {
+				t.Errorf("Claimable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSeasonPrizeStatusAfter(t *testing.T) {
+	tests := []struct {
+		name    string
+		outcome TransferOutcome
+		want    uint8
+	}{
+		{"confirmed", TransferConfirmed, SeasonPrizeStatusPaid},
+		{"failed", TransferFailed, SeasonPrizeStatusFailed},
+		{"pending", TransferPending, SeasonPrizeStatusSending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			prize := SeasonPrize{Status: SeasonPrizeStatusSending}
+			if got := prize.StatusAfter(tt.outcome); got != tt.want {
+				t.Errorf("StatusAfter() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
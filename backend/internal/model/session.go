@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"github.com/goccy/go-json"
+	"github.com/google/uuid"
+)
+
+// SessionMeta describes the device a session was started from
+type SessionMeta struct {
+	UserAgent string `json:"user_agent"`
+	IP        string `json:"ip"`
+}
+
+// Session is a refresh session of the user, its id changes on each token refresh.
+// SessionMeta keeps the device of the sign in, the last seen fields follow refreshes
+type Session struct {
+	SessionMeta
+
+	LastSeenUserAgent string `json:"last_seen_user_agent"`
+	LastSeenIP        string `json:"last_seen_ip"`
+
+	ID uuid.UUID `json:"id"`
+	// FamilyID is kept across refreshes of the session, sessions are listed and revoked by it
+	FamilyID   uuid.UUID `json:"family_id"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+	ExpiresAt  time.Time `json:"expires_at"`
+	Current    bool      `json:"current"`
+}
+
+func (s *Session) MarshalBinary() ([]byte, error) {
+	return json.Marshal(s)
+}
+
+func (s *Session) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, s)
+}
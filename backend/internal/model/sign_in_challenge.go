@@ -0,0 +1,80 @@
+package model
+
+import (
+	"crypto/rand"
+	"duels-api/pkg/apperrors"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-json"
+)
+
+const (
+	SignInStatement = "Sign in to Duels"
+	SignInVersion   = "1"
+
+	signInNonceBytes = 16
+)
+
+// SignInChallenge is a single-use Sign-In With Solana message issued to a wallet
+type SignInChallenge struct {
+	Domain         string    `json:"domain"`
+	Address        string    `json:"address"`
+	Nonce          string    `json:"nonce"`
+	IssuedAt       time.Time `json:"issued_at"`
+	ExpirationTime time.Time `json:"expiration_time"`
+}
+
+type SignInChallengeReq struct {
+	Address string `json:"address"`
+}
+
+type SignInChallengeResp struct {
+	*SignInChallenge
+	Message string `json:"message"`
+}
+
+func NewSignInChallenge(domain, address string, ttl time.Duration) (*SignInChallenge, error) {
+	nonce := make([]byte, signInNonceBytes)
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, apperrors.Internal("failed to generate sign in nonce", err)
+	}
+
+	now := time.Now().UTC().Truncate(time.Second)
+	return &SignInChallenge{
+		Domain:         domain,
+		Address:        address,
+		Nonce:          hex.EncodeToString(nonce),
+		IssuedAt:       now,
+		ExpirationTime: now.Add(ttl),
+	}, nil
+}
+
+// Message returns the text the wallet signs, its layout follows the SIWS message format
+func (c *SignInChallenge) Message() string {
+	var b strings.Builder
+
+	_, _ = fmt.Fprintf(&b, "%s wants you to sign in with your Solana account:\n", c.Domain)
+	_, _ = fmt.Fprintf(&b, "%s\n\n", c.Address)
+	_, _ = fmt.Fprintf(&b, "%s\n\n", SignInStatement)
+	_, _ = fmt.Fprintf(&b, "Version: %s\n", SignInVersion)
+	_, _ = fmt.Fprintf(&b, "Nonce: %s\n", c.Nonce)
+	_, _ = fmt.Fprintf(&b, "Issued At: %s\n", c.IssuedAt.Format(time.RFC3339))
+	_, _ = fmt.Fprintf(&b, "Expiration Time: %s", c.ExpirationTime.Format(time.RFC3339))
+
+	return b.String()
+}
+
+func (c *SignInChallenge) Expired() bool {
+	return !time.Now().Before(c.ExpirationTime)
+}
+
+func (c *SignInChallenge) MarshalBinary() ([]byte, error) {
+	return json.Marshal(c)
+}
+
+func (c *SignInChallenge) UnmarshalBinary(data []byte) error {
+	return json.Unmarshal(data, c)
+}
@@ -0,0 +1,72 @@
+package model
+
+import (
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const topicDuelPrefix = "duel:"
+
+// DuelTopic is the topic of the public events of the duel
+func DuelTopic(duelID uuid.UUID) string {
+	return topicDuelPrefix + duelID.String()
+}
+
+// ValidTopic reports whether clients may subscribe to the topic
+func ValidTopic(topic string) bool {
+	_, ok := TopicDuelID(topic)
+	return ok
+}
+
+// TopicDuelID returns the id of the duel the topic belongs to
+func TopicDuelID(topic string) (uuid.UUID, bool) {
+	id, ok := strings.CutPrefix(topic, topicDuelPrefix)
+	if !ok {
+		return uuid.Nil, false
+	}
+
+	duelID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, false
+	}
+
+	return duelID, true
+}
+
+// DuelOdds are the public counters of a duel, the pool is in USDC
+type DuelOdds struct {
+	DuelID       uuid.UUID `bun:"duel_id" json:"duel_id"`
+	PlayersCount uint64    `bun:"players_count" json:"players_count"`
+	YesCount     uint64    `bun:"yes_count" json:"yes_count"`
+	NoCount      uint64    `bun:"no_count" json:"no_count"`
+	Pool         float64   `bun:"pool" json:"pool"`
+}
+
+type DuelJoinedEvent struct {
+	DuelOdds
+	UserID   uuid.UUID `json:"user_id"`
+	Username string    `json:"username"`
+}
+
+type DuelResolvedEvent struct {
+	DuelID       uuid.UUID `json:"duel_id"`
+	FinalResult  uint8     `json:"final_result"`
+	WinnersCount uint64    `json:"winners_count"`
+}
+
+type DuelCancelledEvent struct {
+	DuelID             uuid.UUID `json:"duel_id"`
+	Status             uint8     `json:"status"`
+	CancellationReason string    `json:"cancellation_reason"`
+}
+
+// DuelViewersEvent is the number of users viewing the duel across instances
+type DuelViewersEvent struct {
+	DuelID  uuid.UUID `json:"duel_id"`
+	Viewers uint64    `json:"viewers"`
+}
+
+type ErrorEvent struct {
+	Message string `json:"message"`
+}
@@ -0,0 +1,135 @@
+package model
+
+import (
+	"duels-api/pkg/apperrors"
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	SignaturePurposeJoin uint8 = iota + 1
+	SignaturePurposeCreate
+	SignaturePurposeReward
+	SignaturePurposeRefund
+	SignaturePurposeCommission
+	SignaturePurposeClose
+)
+
+const (
+	SignatureStatusPending uint8 = iota
+	SignatureStatusConfirmed
+	SignatureStatusFailed
+)
+
+// Stages of a tracked signature reported to its user
+const (
+	SignatureStageSubmitted uint8 = iota
+	SignatureStageConfirmed
+	SignatureStageValidated
+	SignatureStageRecorded
+	SignatureStageFailed
+)
+
+var signaturePurposeNames = map[uint8]string{
+	SignaturePurposeJoin:       "join",
+	SignaturePurposeCreate:     "create",
+	SignaturePurposeReward:     "reward",
+	SignaturePurposeRefund:     "refund",
+	SignaturePurposeCommission: "commission",
+	SignaturePurposeClose:      "close",
+}
+
+var signatureStatusNames = map[uint8]string{
+	SignatureStatusPending:   "pending",
+	SignatureStatusConfirmed: "confirmed",
+	SignatureStatusFailed:    "failed",
+}
+
+var signatureStageNames = map[uint8]string{
+	SignatureStageSubmitted: "submitted",
+	SignatureStageConfirmed: "confirmed",
+	SignatureStageValidated: "validated",
+	SignatureStageRecorded:  "recorded",
+	SignatureStageFailed:    "failed",
+}
+
+// TrackedSignature is a submitted transaction whose on-chain outcome
+// must be handled even if the process restarts before it lands
+type TrackedSignature struct {
+	bun.BaseModel `bun:"table:tracked_signatures,alias:ts" json:"-"`
+
+	ID        uuid.UUID       `bun:",pk,type:uuid,default:uuid_generate_v4()" json:"id"`
+	Signature string          `bun:"signature,type:varchar(88),notnull" json:"signature"`
+	Purpose   uint8           `bun:"purpose,type:smallint,notnull" json:"purpose" swaggertype:"string" enums:"join,create,reward,refund,commission,close"`
+	Status    uint8           `bun:"status,type:smallint,notnull,default:0" json:"status" swaggertype:"string" enums:"pending,confirmed,failed"`
+	Stage     uint8           `bun:"stage,type:smallint,notnull,default:0" json:"stage" swaggertype:"string" enums:"submitted,confirmed,validated,recorded,failed"`
+	DuelID    uuid.UUID       `bun:"duel_id,type:uuid,nullzero" json:"duel_id"`
+	UserID    uuid.UUID       `bun:"user_id,type:uuid,nullzero" json:"user_id"`
+	Data      json.RawMessage `bun:"data,type:jsonb,nullzero" json:"-"`
+	Slot      uint64          `bun:"slot,type:bigint,notnull,default:0" json:"slot"`
+	Error     string          `bun:"error,type:text,nullzero" json:"error"`
+	// ClaimedBy is the instance watching the signature until ClaimedUntil, only it runs the handlers
+	ClaimedBy    string    `bun:"claimed_by,type:varchar(36),nullzero" json:"-"`
+	ClaimedUntil time.Time `bun:"claimed_until,nullzero" json:"-"`
+	CreatedAt    time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	UpdatedAt    time.Time `bun:"updated_at,notnull,default:current_timestamp" json:"updated_at"`
+}
+
+func NewTrackedSignature(
+	signature string,
+	purpose uint8,
+	duelID uuid.UUID,
+	userID uuid.UUID,
+	data any,
+) (*TrackedSignature, error) {
+	var raw json.RawMessage
+	if data != nil {
+		var err error
+		raw, err = json.Marshal(data)
+		if err != nil {
+			return nil, apperrors.Internal("failed to marshal tracked signature data", err)
+		}
+	}
+
+	now := time.Now()
+	return &TrackedSignature{
+		ID:        uuid.New(),
+		Signature: signature,
+		Purpose:   purpose,
+		Status:    SignatureStatusPending,
+		Stage:     SignatureStageSubmitted,
+		DuelID:    duelID,
+		UserID:    userID,
+		Data:      raw,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}, nil
+}
+
+// MarshalJSON serializes purpose, status and stage by name
+func (t TrackedSignature) MarshalJSON() ([]byte, error) {
+	type trackedSignature TrackedSignature
+
+	return json.Marshal(struct {
+		trackedSignature
+		Purpose string `json:"purpose"`
+		Status  string `json:"status"`
+		Stage   string `json:"stage"`
+	}{
+		trackedSignature: trackedSignature(t),
+		Purpose:          signaturePurposeNames[t.Purpose],
+		Status:           signatureStatusNames[t.Status],
+		Stage:            signatureStageNames[t.Stage],
+	})
+}
+
+func (t *TrackedSignature) UnmarshalData(v any) error {
+	if err := json.Unmarshal(t.Data, v); err != nil {
+		return apperrors.Internal("failed to unmarshal tracked signature data", err)
+	}
+
+	return nil
+}
@@ -9,6 +9,7 @@ const (
 	TransactionTypeDuelRefund     uint8 = 2
 	TransactionTypeDuelCommission uint8 = 3
 	TransactionTypeDuelReward     uint8 = 4
+	TransactionTypeSeasonPrize    uint8 = 5
 )
 
 type TransactionType struct {
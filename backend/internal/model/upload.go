@@ -0,0 +1,37 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	// UploadStatePending is an upload nothing refers to yet, it is deleted after a while
+	UploadStatePending = uint8(iota)
+	// UploadStateBound is an upload referenced by a profile or a duel
+	UploadStateBound
+)
+
+// Upload registers a file uploaded by the user, Key is the value stored in image urls
+type Upload struct {
+	bun.BaseModel `bun:"table:uploads,alias:up" json:"-"`
+
+	ID        uuid.UUID  `bun:",pk,type:uuid" json:"id"`
+	UserID    uuid.UUID  `bun:"user_id,type:uuid,notnull" json:"user_id"`
+	Key       string     `bun:"object_key,type:varchar(255),notnull" json:"key"`
+	State     uint8      `bun:"state,type:smallint,notnull,default:0" json:"state"`
+	CreatedAt time.Time  `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+	BoundAt   *time.Time `bun:"bound_at" json:"bound_at"`
+}
+
+func NewUpload(userID uuid.UUID, key string) *Upload {
+	return &Upload{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Key:       key,
+		State:     UploadStatePending,
+		CreatedAt: time.Now().UTC(),
+	}
+}
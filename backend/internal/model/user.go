@@ -14,6 +14,12 @@ type User struct {
 	Username      mtype.Username `bun:",type:varchar(17),notnull" json:"username"`
 	ImageUrl      string         `bun:",type:varchar(100)" json:"image_url"`
 	PublicAddress string         `bun:",type:varchar(100),unique,nullzero" json:"public_address"`
+	Bio           string         `bun:",type:varchar(280),notnull,default:''" json:"bio"`
+	SocialLinks   SocialLinks    `bun:",type:jsonb,notnull,default:'{}'" json:"social_links"`
+	HideStats     bool           `bun:",notnull,default:false" json:"hide_stats"`
+	HideHistory   bool           `bun:",notnull,default:false" json:"hide_history"`
+	XP            uint64         `bun:"xp,notnull,default:0" json:"xp"`
+	WinStreak     uint64         `bun:",notnull,default:0" json:"win_streak"`
 	CreatedAt     time.Time      `bun:",notnull,default:current_timestamp" json:"created_at"`
 	UpdatedAt     time.Time      `bun:",notnull,default:current_timestamp" json:"updated_at"`
 }
@@ -34,6 +40,7 @@ func NewUser(
 
 type AuthWithWallet struct {
 	Address string `json:"address" binding:"required"`
+	Nonce   string `json:"nonce" binding:"required"`
 	Secret  string `json:"secret" binding:"required"`
 }
 
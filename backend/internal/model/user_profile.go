@@ -0,0 +1,92 @@
+package model
+
+import (
+	"duels-api/pkg/mtype"
+	"net/url"
+	"time"
+	"unicode/utf8"
+
+	"github.com/google/uuid"
+)
+
+const (
+	MaxBioLength        = 280
+	MaxSocialLinkLength = 200
+)
+
+// SocialLinks are shown on the public profile, every link is an http(s) url
+type SocialLinks struct {
+	Twitter  string `json:"twitter,omitempty"`
+	Telegram string `json:"telegram,omitempty"`
+	Discord  string `json:"discord,omitempty"`
+	Website  string `json:"website,omitempty"`
+}
+
+func (l SocialLinks) Valid() bool {
+	for _, link := range []string{l.Twitter, l.Telegram, l.Discord, l.Website} {
+		if link == "" {
+			continue
+		}
+
+		if len(link) > MaxSocialLinkLength {
+			return false
+		}
+
+		parsed, err := url.Parse(link)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return false
+		}
+	}
+
+	return true
+}
+
+type UpdateProfileReq struct {
+	Bio         string      `json:"bio"`
+	SocialLinks SocialLinks `json:"social_links"`
+}
+
+func (r *UpdateProfileReq) Valid() bool {
+	return utf8.RuneCountInString(r.Bio) <= MaxBioLength && r.SocialLinks.Valid()
+}
+
+type UpdatePrivacyReq struct {
+	HideStats   bool `json:"hide_stats"`
+	HideHistory bool `json:"hide_history"`
+}
+
+// PublicUser is the part of the user visible to everyone
+type PublicUser struct {
+	ID          uuid.UUID      `json:"id"`
+	Username    mtype.Username `json:"username"`
+	ImageUrl    string         `json:"image_url"`
+	Bio         string         `json:"bio"`
+	SocialLinks SocialLinks    `json:"social_links"`
+	CreatedAt   time.Time      `json:"created_at"`
+}
+
+func NewPublicUser(user *User) PublicUser {
+	return PublicUser{
+		ID:          user.ID,
+		Username:    user.Username,
+		ImageUrl:    user.ImageUrl,
+		Bio:         user.Bio,
+		SocialLinks: user.SocialLinks,
+		CreatedAt:   user.CreatedAt,
+	}
+}
+
+// PublicProfile is the profile shown to other players, stats and recent
+// results are nil if the user hides them
+type PublicProfile struct {
+	FollowCounts
+
+	User          PublicUser        `json:"user"`
+	Progress      UserProgress      `json:"progress"`
+	Achievements  []UserAchievement `json:"achievements"`
+	Stats         *UserStats        `json:"stats"`
+	CreatedDuels  []DuelShow        `json:"created_duels"`
+	RecentResults []DuelShow        `json:"recent_results"`
+	StatsHidden   bool              `json:"stats_hidden"`
+	HistoryHidden bool              `json:"history_hidden"`
+}
@@ -0,0 +1,30 @@
+package model
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+// UserWallet is a wallet whose ownership the user proved by signing a challenge.
+// The primary wallet is mirrored to User.PublicAddress.
+type UserWallet struct {
+	bun.BaseModel `bun:"table:user_wallets,alias:uw" json:"-"`
+
+	ID        uuid.UUID `bun:",pk,type:uuid,default:uuid_generate_v4()" json:"id"`
+	UserID    uuid.UUID `bun:"user_id,type:uuid,notnull" json:"user_id"`
+	Address   string    `bun:"address,type:varchar(100),notnull" json:"address"`
+	IsPrimary bool      `bun:"is_primary,notnull,default:false" json:"is_primary"`
+	CreatedAt time.Time `bun:"created_at,notnull,default:current_timestamp" json:"created_at"`
+}
+
+func NewUserWallet(userID uuid.UUID, address string, isPrimary bool) *UserWallet {
+	return &UserWallet{
+		ID:        uuid.New(),
+		UserID:    userID,
+		Address:   address,
+		IsPrimary: isPrimary,
+		CreatedAt: time.Now().UTC(),
+	}
+}
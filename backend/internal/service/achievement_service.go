@@ -0,0 +1,191 @@
+package service
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/internal/storage/repository"
+	"duels-api/pkg/apperrors"
+	repo "duels-api/pkg/repository"
+
+	"github.com/uptrace/bun"
+)
+
+// achievementRule unlocks the achievement when an event of the type passes the check
+type achievementRule struct {
+	Achievement string
+	Event       model.DuelEventType
+	Check       func(event *model.DuelEvent) bool
+}
+
+func always(*model.DuelEvent) bool { return true }
+
+var achievementRules = []achievementRule{
+	{
+		Achievement: model.AchievementFirstJoin,
+		Event:       model.DuelEventJoined,
+		Check:       always,
+	},
+	{
+		Achievement: model.AchievementFirstDuelCreated,
+		Event:       model.DuelEventCreated,
+		Check:       always,
+	},
+	{
+		Achievement: model.AchievementFirstWin,
+		Event:       model.DuelEventWon,
+		Check:       always,
+	},
+	{
+		Achievement: model.AchievementWinStreak10,
+		Event:       model.DuelEventWon,
+		Check: func(event *model.DuelEvent) bool {
+			return event.WinStreak >= 10
+		},
+	},
+	{
+		Achievement: model.AchievementDuel100Players,
+		Event:       model.DuelEventPlayersJoined,
+		Check: func(event *model.DuelEvent) bool {
+			return event.PlayersCount >= 100
+		},
+	},
+}
+
+type AchievementService struct {
+	UserRepository        *repository.UserRepository
+	AchievementRepository *repository.AchievementRepository
+	CreditRepository      *repository.DuelEventCreditRepository
+	NotificationService   *NotificationService
+	TransactionManager    *repo.TransactionManager
+}
+
+func NewAchievementService(
+	userRepository *repository.UserRepository,
+	achievementRepository *repository.AchievementRepository,
+	creditRepository *repository.DuelEventCreditRepository,
+	notificationService *NotificationService,
+	transactionManager *repo.TransactionManager,
+) *AchievementService {
+	return &AchievementService{
+		UserRepository:        userRepository,
+		AchievementRepository: achievementRepository,
+		CreditRepository:      creditRepository,
+		NotificationService:   notificationService,
+		TransactionManager:    transactionManager,
+	}
+}
+
+// Handle awards the XP of the event and unlocks the achievements whose rules
+// pass, every unlock is pushed to the user as a notification. The XP of an event
+// and of an achievement is credited once, however many times the event is handled
+func (s *AchievementService) Handle(ctx context.Context, event *model.DuelEvent) error {
+	var (
+		unlocked []model.Achievement
+		total    uint64
+	)
+
+	err := s.TransactionManager.WithinTransaction(ctx,
+		func(ctx context.Context, tx bun.Tx) error {
+			xp := model.DuelEventXP[event.Type]
+			if xp > 0 {
+				credited, err := s.CreditRepository.WithTx(tx).Credit(ctx, model.NewDuelEventCredit(event, model.DuelEventRewardXP))
+				if err != nil {
+					return apperrors.Internal("failed to credit duel event", err)
+				}
+
+				if !credited {
+					xp = 0
+				}
+			}
+
+			for _, rule := range achievementRules {
+				if rule.Event != event.Type || !rule.Check(event) {
+					continue
+				}
+
+				ok, err := s.AchievementRepository.WithTx(tx).Unlock(ctx, model.NewUserAchievement(event.UserID, rule.Achievement))
+				if err != nil {
+					return apperrors.Internal("failed to unlock achievement", err)
+				}
+
+				if ok {
+					achievement := model.Achievements[rule.Achievement]
+					unlocked = append(unlocked, achievement)
+					xp += achievement.XP
+				}
+			}
+
+			if xp == 0 {
+				return nil
+			}
+
+			var err error
+			total, err = s.UserRepository.WithTx(tx).AddXP(ctx, event.UserID, xp)
+			if err != nil {
+				return apperrors.Internal("failed to add user xp", err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	for _, achievement := range unlocked {
+		notification, err := model.NewNotification(
+			event.UserID,
+			model.NotificationAchievementUnlocked,
+			&model.AchievementUnlockedNotification{
+				Achievement: achievement.Code,
+				Title:       achievement.Title,
+				XP:          achievement.XP,
+				Level:       model.Level(total),
+			},
+		)
+		if err != nil {
+			return err
+		}
+
+		if err = s.NotificationService.Publish(ctx, notification); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// UpdateWinStreak extends the streak of the user on a won duel and resets it on a lost one,
+// once per duel. It returns the streak after the duel
+func (s *AchievementService) UpdateWinStreak(ctx context.Context, event *model.DuelEvent) (uint64, error) {
+	var streak uint64
+
+	err := s.TransactionManager.WithinTransaction(ctx,
+		func(ctx context.Context, tx bun.Tx) error {
+			credited, err := s.CreditRepository.WithTx(tx).Credit(ctx, model.NewDuelEventCredit(event, model.DuelEventRewardWinStreak))
+			if err != nil {
+				return apperrors.Internal("failed to credit duel event", err)
+			}
+
+			if !credited {
+				user, err := s.UserRepository.WithTx(tx).GetByID(ctx, event.UserID)
+				if err != nil {
+					return apperrors.Internal("failed to get user", err)
+				}
+
+				streak = user.WinStreak
+				return nil
+			}
+
+			streak, err = s.UserRepository.WithTx(tx).UpdateWinStreak(ctx, event.UserID, event.Type == model.DuelEventWon)
+			if err != nil {
+				return apperrors.Internal("failed to update win streak", err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return 0, err
+	}
+
+	return streak, nil
+}
@@ -0,0 +1,239 @@
+package service
+
+import (
+	"context"
+	"duels-api/config"
+	"duels-api/internal/model"
+	"duels-api/internal/storage/cache"
+	"duels-api/internal/storage/repository"
+	"duels-api/pkg/apperrors"
+	repo "duels-api/pkg/repository"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+type APIKeyService struct {
+	Repository       *repository.APIKeyRepository
+	WalletRepository *repository.UserWalletRepository
+	UserRepository   *repository.UserRepository
+	Usage            *cache.APIKeyUsage
+
+	adminAddresses   []string
+	defaultRateLimit uint32
+	maxRateLimit     uint32
+	maxPerUser       int
+}
+
+func NewAPIKeyService(
+	c *config.Config,
+	apiKeyRepository *repository.APIKeyRepository,
+	walletRepository *repository.UserWalletRepository,
+	userRepository *repository.UserRepository,
+	usage *cache.APIKeyUsage,
+) *APIKeyService {
+	return &APIKeyService{
+		Repository:       apiKeyRepository,
+		WalletRepository: walletRepository,
+		UserRepository:   userRepository,
+		Usage:            usage,
+		adminAddresses:   c.Auth.AdminAddresses,
+		defaultRateLimit: c.Auth.APIKeyDefaultRateLimit,
+		maxRateLimit:     c.Auth.APIKeyMaxRateLimit,
+		maxPerUser:       c.Auth.APIKeyMaxPerUser,
+	}
+}
+
+// IsAdmin reports whether any wallet linked to the user is an admin wallet
+func (s *APIKeyService) IsAdmin(ctx context.Context, userID uuid.UUID) (bool, error) {
+	if len(s.adminAddresses) == 0 {
+		return false, nil
+	}
+
+	wallets, err := s.WalletRepository.GetUserWallets(ctx, userID)
+	if err != nil {
+		return false, apperrors.Internal("failed to get user wallets", err)
+	}
+
+	for _, wallet := range wallets {
+		if slices.Contains(s.adminAddresses, wallet.Address) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// Issue creates a key of the user, the rate limit of keys issued by users is capped
+func (s *APIKeyService) Issue(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *model.CreateAPIKeyReq,
+) (*model.IssuedAPIKey, error) {
+	if req.RateLimit > s.maxRateLimit {
+		return nil, apperrors.BadRequest("rate limit is too high")
+	}
+
+	return s.issue(ctx, userID, userID, req)
+}
+
+// IssueByAdmin creates a key for any user without the rate limit cap
+func (s *APIKeyService) IssueByAdmin(
+	ctx context.Context,
+	adminID uuid.UUID,
+	req *model.AdminCreateAPIKeyReq,
+) (*model.IssuedAPIKey, error) {
+	if req.UserID == uuid.Nil {
+		return nil, apperrors.BadRequest("user id is required")
+	}
+
+	if _, err := s.UserRepository.GetByID(ctx, req.UserID); err != nil {
+		if repo.IsErrNoRows(err) {
+			return nil, apperrors.NotFound("user not found")
+		}
+
+		return nil, apperrors.Internal("failed to get user", err)
+	}
+
+	return s.issue(ctx, req.UserID, adminID, &req.CreateAPIKeyReq)
+}
+
+func (s *APIKeyService) issue(
+	ctx context.Context,
+	userID uuid.UUID,
+	issuedBy uuid.UUID,
+	req *model.CreateAPIKeyReq,
+) (*model.IssuedAPIKey, error) {
+	if err := s.validateCreateReq(req); err != nil {
+		return nil, err
+	}
+
+	count, err := s.Repository.CountActive(ctx, userID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to count api keys", err)
+	}
+
+	if count >= s.maxPerUser {
+		return nil, apperrors.BadRequest("api keys limit is reached, revoke unused keys first")
+	}
+
+	issued, err := model.NewAPIKey(userID, issuedBy, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.Repository.Create(ctx, issued.APIKey); err != nil {
+		return nil, apperrors.Internal("failed to create api key", err)
+	}
+
+	return issued, nil
+}
+
+func (s *APIKeyService) validateCreateReq(req *model.CreateAPIKeyReq) error {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || len(req.Name) > model.APIKeyMaxNameLen {
+		return apperrors.BadRequest("name must be from 1 to 64 characters")
+	}
+
+	if len(req.Scopes) == 0 {
+		return apperrors.BadRequest("at least one scope is required")
+	}
+
+	slices.Sort(req.Scopes)
+	req.Scopes = slices.Compact(req.Scopes)
+
+	for _, scope := range req.Scopes {
+		if !model.ValidAPIKeyScope(scope) {
+			return apperrors.BadRequest("unknown scope " + scope)
+		}
+	}
+
+	if req.RateLimit == 0 {
+		req.RateLimit = s.defaultRateLimit
+	}
+
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(time.Now()) {
+		return apperrors.BadRequest("expiration time must be in the future")
+	}
+
+	return nil
+}
+
+func (s *APIKeyService) GetUserKeys(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error) {
+	keys, err := s.Repository.GetUserKeys(ctx, userID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get api keys", err)
+	}
+
+	return keys, nil
+}
+
+// Revoke revokes the key of the user, admins pass uuid.Nil to revoke any key
+func (s *APIKeyService) Revoke(ctx context.Context, userID, keyID uuid.UUID) error {
+	revoked, err := s.Repository.Revoke(ctx, keyID, userID)
+	if err != nil {
+		return apperrors.Internal("failed to revoke api key", err)
+	}
+
+	if !revoked {
+		return apperrors.NotFound("api key not found")
+	}
+
+	return nil
+}
+
+// Authenticate resolves the key and checks its scope and rate limit
+func (s *APIKeyService) Authenticate(ctx context.Context, rawKey, scope string) (*model.APIKey, error) {
+	if !model.IsAPIKey(rawKey) {
+		return nil, apperrors.Unauthorized("invalid api key")
+	}
+
+	key, err := s.Repository.GetByHash(ctx, model.HashAPIKey(rawKey))
+	if err != nil {
+		if repo.IsErrNoRows(err) {
+			return nil, apperrors.Unauthorized("invalid api key")
+		}
+
+		return nil, apperrors.Internal("failed to get api key", err)
+	}
+
+	now := time.Now().UTC()
+	if !key.Active(now) {
+		return nil, apperrors.Unauthorized("api key is revoked or expired")
+	}
+
+	if !key.HasScope(scope) {
+		return nil, apperrors.Forbidden("api key has no " + scope + " scope")
+	}
+
+	allowed, err := s.Usage.Hit(ctx, key.ID, key.RateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	if !allowed {
+		return nil, apperrors.TooManyRequests("api key rate limit exceeded")
+	}
+
+	s.touch(ctx, key, now)
+
+	return key, nil
+}
+
+// touch updates the last used time at most once per minute, failures do not reject the request
+func (s *APIKeyService) touch(ctx context.Context, key *model.APIKey, now time.Time) {
+	stale, err := s.Usage.ShouldTouch(ctx, key.ID)
+	if err == nil && stale {
+		err = s.Repository.UpdateLastUsed(ctx, key.ID, now)
+	}
+
+	if err != nil {
+		zap.L().Warn("failed to update api key last usage",
+			zap.String("key_id", key.ID.String()),
+			zap.Error(err),
+		)
+	}
+}
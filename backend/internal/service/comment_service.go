@@ -0,0 +1,415 @@
+package service
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/internal/storage/cache"
+	"duels-api/internal/storage/repository"
+	"duels-api/pkg/apperrors"
+	repo "duels-api/pkg/repository"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultCommentPageSize = 20
+	maxCommentPageSize     = 100
+	// replyPreviewLength is the length of the reply text sent in notifications
+	replyPreviewLength = 140
+)
+
+type CommentService struct {
+	CommentRepository   *repository.CommentRepository
+	DuelRepository      *repository.DuelRepository
+	NotificationService *NotificationService
+	TransactionManager  *repo.TransactionManager
+	Events              *cache.EventPubSub
+}
+
+func NewCommentService(
+	commentRepository *repository.CommentRepository,
+	duelRepository *repository.DuelRepository,
+	notificationService *NotificationService,
+	transactionManager *repo.TransactionManager,
+	events *cache.EventPubSub,
+) *CommentService {
+	return &CommentService{
+		CommentRepository:   commentRepository,
+		DuelRepository:      duelRepository,
+		NotificationService: notificationService,
+		TransactionManager:  transactionManager,
+		Events:              events,
+	}
+}
+
+func (s *CommentService) Create(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *model.CreateCommentReq,
+) (*model.Comment, error) {
+	text, ok := model.NormalizeCommentText(req.Text)
+	if !ok {
+		return nil, apperrors.BadRequest("comment must be 1 to 1000 characters long")
+	}
+
+	duel, err := s.getDuel(ctx, req.DuelID)
+	if err != nil {
+		return nil, err
+	}
+
+	var parent *model.Comment
+	if req.ParentID != nil {
+		parent, err = s.getComment(ctx, *req.ParentID)
+		if err != nil {
+			return nil, err
+		}
+
+		if parent.DuelID != duel.ID {
+			return nil, apperrors.BadRequest("parent comment belongs to another duel")
+		}
+
+		if !parent.Active() {
+			return nil, apperrors.BadRequest("cannot reply to a deleted comment")
+		}
+	}
+
+	comment := model.NewComment(duel.ID, userID, parent, text)
+
+	err = s.TransactionManager.WithinTransaction(ctx,
+		func(ctx context.Context, tx bun.Tx) error {
+			if err := s.CommentRepository.WithTx(tx).Create(ctx, comment); err != nil {
+				return err
+			}
+
+			if parent == nil {
+				return nil
+			}
+
+			return s.CommentRepository.WithTx(tx).IncrementReplies(ctx, parent.ThreadID())
+		})
+	if err != nil {
+		return nil, apperrors.Internal("failed to create comment", err)
+	}
+
+	created, err := s.CommentRepository.GetWithAuthor(ctx, comment.ID)
+	if err != nil || created == nil {
+		return nil, apperrors.Internal("failed to get created comment", err)
+	}
+
+	go s.deliverComment(context.WithoutCancel(ctx), duel, parent, created)
+
+	return created, nil
+}
+
+// Update edits the text of an own comment
+func (s *CommentService) Update(
+	ctx context.Context,
+	userID, commentID uuid.UUID,
+	req *model.UpdateCommentReq,
+) (*model.Comment, error) {
+	text, ok := model.NormalizeCommentText(req.Text)
+	if !ok {
+		return nil, apperrors.BadRequest("comment must be 1 to 1000 characters long")
+	}
+
+	comment, err := s.getOwnComment(ctx, userID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err = s.CommentRepository.UpdateText(ctx, comment.ID, text); err != nil {
+		return nil, apperrors.Internal("failed to update comment", err)
+	}
+
+	updated, err := s.CommentRepository.GetWithAuthor(ctx, comment.ID)
+	if err != nil || updated == nil {
+		return nil, apperrors.Internal("failed to get updated comment", err)
+	}
+
+	s.publishComment(ctx, model.EventCommentUpdated, updated)
+
+	return updated, nil
+}
+
+// Delete hides an own comment, its replies stay in the thread
+func (s *CommentService) Delete(ctx context.Context, userID, commentID uuid.UUID) error {
+	comment, err := s.getOwnComment(ctx, userID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.CommentRepository.UpdateStatus(ctx, comment.ID, model.CommentStatusDeleted); err != nil {
+		return apperrors.Internal("failed to delete comment", err)
+	}
+
+	comment.Status = model.CommentStatusDeleted
+	comment.Redact()
+	s.publishComment(ctx, model.EventCommentHidden, comment)
+
+	return nil
+}
+
+// Remove hides any comment on behalf of a moderator
+func (s *CommentService) Remove(ctx context.Context, commentID uuid.UUID) error {
+	comment, err := s.getComment(ctx, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.Status == model.CommentStatusRemoved {
+		return nil
+	}
+
+	if err = s.CommentRepository.UpdateStatus(ctx, comment.ID, model.CommentStatusRemoved); err != nil {
+		return apperrors.Internal("failed to remove comment", err)
+	}
+
+	comment.Status = model.CommentStatusRemoved
+	comment.Redact()
+	s.publishComment(ctx, model.EventCommentHidden, comment)
+
+	return nil
+}
+
+// Pin pins a top-level comment on behalf of the duel owner, replacing the pinned one
+func (s *CommentService) Pin(ctx context.Context, userID, commentID uuid.UUID) error {
+	comment, err := s.getPinnableComment(ctx, userID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if comment.RootID != nil {
+		return apperrors.BadRequest("only top-level comments can be pinned")
+	}
+
+	if !comment.Active() {
+		return apperrors.BadRequest("cannot pin a deleted comment")
+	}
+
+	err = s.TransactionManager.WithinTransaction(ctx,
+		func(ctx context.Context, tx bun.Tx) error {
+			return s.CommentRepository.WithTx(tx).Pin(ctx, comment)
+		})
+	if err != nil {
+		return apperrors.Internal("failed to pin comment", err)
+	}
+
+	return nil
+}
+
+func (s *CommentService) Unpin(ctx context.Context, userID, commentID uuid.UUID) error {
+	comment, err := s.getPinnableComment(ctx, userID, commentID)
+	if err != nil {
+		return err
+	}
+
+	if err = s.CommentRepository.Unpin(ctx, comment.ID); err != nil {
+		return apperrors.Internal("failed to unpin comment", err)
+	}
+
+	return nil
+}
+
+// GetThreads returns top-level comments of the duel, the latest first. The pinned
+// comment is returned separately on the first page
+func (s *CommentService) GetThreads(
+	ctx context.Context,
+	duelID uuid.UUID,
+	req *model.CommentsReq,
+) (*model.CommentPage, error) {
+	if _, err := s.getDuel(ctx, duelID); err != nil {
+		return nil, err
+	}
+
+	cursor, limit, err := commentPage(req)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.CommentRepository.GetThreads(ctx, duelID, cursor, limit)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get comments", err)
+	}
+
+	page := newCommentPage(comments, limit)
+
+	if cursor == nil {
+		page.Pinned, err = s.CommentRepository.GetPinned(ctx, duelID)
+		if err != nil {
+			return nil, apperrors.Internal("failed to get pinned comment", err)
+		}
+	}
+
+	return page, nil
+}
+
+// GetReplies returns replies of the thread the comment belongs to in the order they were written
+func (s *CommentService) GetReplies(
+	ctx context.Context,
+	commentID uuid.UUID,
+	req *model.CommentsReq,
+) (*model.CommentPage, error) {
+	comment, err := s.getComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	cursor, limit, err := commentPage(req)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.CommentRepository.GetReplies(ctx, comment.ThreadID(), cursor, limit)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get replies", err)
+	}
+
+	return newCommentPage(comments, limit), nil
+}
+
+// deliverComment pushes the new comment to the subscribers of the duel and
+// notifies the author of the parent comment
+func (s *CommentService) deliverComment(
+	ctx context.Context,
+	duel *model.Duel,
+	parent *model.Comment,
+	comment *model.Comment,
+) {
+	s.publishComment(ctx, model.EventDuelComment, comment)
+
+	if parent == nil || parent.UserID == comment.UserID {
+		return
+	}
+
+	text := []rune(comment.Text)
+	if len(text) > replyPreviewLength {
+		text = append(text[:replyPreviewLength], '…')
+	}
+
+	notification, err := model.NewNotification(
+		parent.UserID,
+		model.NotificationCommentReply,
+		&model.CommentReplyNotification{
+			DuelID:         duel.ID,
+			DuelName:       duel.Question,
+			CommentID:      comment.ID,
+			ParentID:       parent.ID,
+			AuthorID:       comment.UserID,
+			AuthorUsername: comment.Username,
+			Text:           string(text),
+		},
+	)
+	if err == nil {
+		err = s.NotificationService.Publish(ctx, notification)
+	}
+	if err != nil {
+		zap.L().Error("failed to send comment reply notification", zap.Error(err))
+	}
+}
+
+// publishComment pushes a change of the comment to the subscribers of its duel
+func (s *CommentService) publishComment(ctx context.Context, eventType uint8, comment *model.Comment) {
+	event := model.NewTopicEvent(model.DuelTopic(comment.DuelID), eventType, comment)
+	if err := s.Events.PublishTopic(ctx, event); err != nil {
+		zap.L().Error("failed to publish comment", zap.String("comment_id", comment.ID.String()), zap.Error(err))
+	}
+}
+
+func (s *CommentService) getDuel(ctx context.Context, duelID uuid.UUID) (*model.Duel, error) {
+	duel, err := s.DuelRepository.GetByID(ctx, duelID)
+	if err != nil {
+		if repo.IsErrNoRows(err) {
+			return nil, apperrors.NotFound("duel not found")
+		}
+		return nil, apperrors.Internal("failed to get duel", err)
+	}
+
+	if duel.Status == model.DuelStatusInReview {
+		return nil, apperrors.NotFound("duel not found")
+	}
+
+	return duel, nil
+}
+
+func (s *CommentService) getComment(ctx context.Context, commentID uuid.UUID) (*model.Comment, error) {
+	comment, err := s.CommentRepository.GetByID(ctx, commentID)
+	if err != nil {
+		if repo.IsErrNoRows(err) {
+			return nil, apperrors.NotFound("comment not found")
+		}
+		return nil, apperrors.Internal("failed to get comment", err)
+	}
+
+	return comment, nil
+}
+
+func (s *CommentService) getOwnComment(ctx context.Context, userID, commentID uuid.UUID) (*model.Comment, error) {
+	comment, err := s.getComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if comment.UserID != userID {
+		return nil, apperrors.Forbidden("comment belongs to another user")
+	}
+
+	if !comment.Active() {
+		return nil, apperrors.NotFound("comment not found")
+	}
+
+	return comment, nil
+}
+
+// getPinnableComment returns the comment if the user owns its duel
+func (s *CommentService) getPinnableComment(ctx context.Context, userID, commentID uuid.UUID) (*model.Comment, error) {
+	comment, err := s.getComment(ctx, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	ownerID, err := s.DuelRepository.FindOwnerIDByDuelID(ctx, comment.DuelID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get duel owner", err)
+	}
+
+	if ownerID != userID {
+		return nil, apperrors.Forbidden("only the duel owner can pin comments")
+	}
+
+	return comment, nil
+}
+
+func commentPage(req *model.CommentsReq) (*model.CommentCursor, int, error) {
+	if req.Limit <= 0 {
+		req.Limit = defaultCommentPageSize
+	}
+	req.Limit = min(req.Limit, maxCommentPageSize)
+
+	if req.Cursor == "" {
+		return nil, req.Limit, nil
+	}
+
+	cursor, err := model.ParseCommentCursor(req.Cursor)
+	if err != nil {
+		return nil, 0, apperrors.BadRequest("invalid cursor", err)
+	}
+
+	return cursor, req.Limit, nil
+}
+
+// newCommentPage redacts deleted comments and sets the cursor of the next page if the page is full
+func newCommentPage(comments []model.Comment, limit int) *model.CommentPage {
+	page := &model.CommentPage{Comments: comments}
+
+	for i := range comments {
+		comments[i].Redact()
+	}
+
+	if len(comments) == limit {
+		page.NextCursor = model.NewCommentCursor(&comments[len(comments)-1])
+	}
+
+	return page
+}
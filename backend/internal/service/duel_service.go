@@ -4,6 +4,7 @@ import (
 	"context"
 	"duels-api/config"
 	"duels-api/internal/model"
+	"duels-api/internal/storage/cache"
 	"duels-api/internal/storage/repository"
 	"duels-api/pkg/apperrors"
 	repo "duels-api/pkg/repository"
@@ -22,12 +23,20 @@ type DuelService struct {
 	TxRepository        *repository.TransactionRepository
 	DuelRepository      *repository.DuelRepository
 	PlayerRepository    *repository.PlayerRepository
+	WalletRepository    *repository.UserWalletRepository
 	HTTPShareClient     *resty.Client
 	TransactionManager  *repo.TransactionManager
 	ShareImageAPI       string
 	USDCMintAddress     solana.PublicKey
 	USDCMintDecimals    uint8
 	NotificationService *NotificationService
+	SignatureService    *SignatureService
+	FileService         *FileService
+	FollowRepository    *repository.FollowRepository
+	AchievementService  *AchievementService
+	SeasonService       *SeasonService
+	Events              *cache.EventPubSub
+	Presence            *cache.Presence
 }
 
 func NewDuelService(
@@ -37,26 +46,46 @@ func NewDuelService(
 	txRepository *repository.TransactionRepository,
 	duelRepository *repository.DuelRepository,
 	playerRepository *repository.PlayerRepository,
+	walletRepository *repository.UserWalletRepository,
 	transactionManager *repo.TransactionManager,
 	notificationService *NotificationService,
+	signatureService *SignatureService,
+	fileService *FileService,
+	followRepository *repository.FollowRepository,
+	achievementService *AchievementService,
+	seasonService *SeasonService,
+	events *cache.EventPubSub,
+	presence *cache.Presence,
 ) (*DuelService, error) {
 	USDCMintAddress, err := solana.PublicKeyFromBase58(c.App.USDCMintAddress)
 	if err != nil {
 		return nil, apperrors.Internal("invalid USDC mint address", err)
 	}
-	return &DuelService{
+	s := &DuelService{
 		WalletService:       walletService,
 		UserRepository:      userRepository,
 		TxRepository:        txRepository,
 		DuelRepository:      duelRepository,
 		PlayerRepository:    playerRepository,
+		WalletRepository:    walletRepository,
 		HTTPShareClient:     resty.New(),
 		TransactionManager:  transactionManager,
 		ShareImageAPI:       c.App.ShareImageAPI,
 		USDCMintAddress:     USDCMintAddress,
 		USDCMintDecimals:    c.App.USDCMintDecimals,
 		NotificationService: notificationService,
-	}, nil
+		SignatureService:    signatureService,
+		FileService:         fileService,
+		FollowRepository:    followRepository,
+		AchievementService:  achievementService,
+		SeasonService:       seasonService,
+		Events:              events,
+		Presence:            presence,
+	}
+
+	s.registerSignatureHandlers()
+
+	return s, nil
 }
 
 func (s *DuelService) GetAllDuelsUnauthorized(ctx context.Context, options *repo.Options) ([]model.DuelShow, error) {
@@ -65,6 +94,8 @@ func (s *DuelService) GetAllDuelsUnauthorized(ctx context.Context, options *repo
 		return nil, apperrors.Internal("failed to get duels", err)
 	}
 
+	s.setListViewers(ctx, duels)
+
 	return duels, nil
 }
 
@@ -74,6 +105,20 @@ func (s *DuelService) GetAllDuels(ctx context.Context, userID uuid.UUID, options
 		return nil, apperrors.Internal("failed to get duels", err)
 	}
 
+	s.setListViewers(ctx, duels)
+
+	return duels, nil
+}
+
+// GetFeed returns active duels of the users the user follows
+func (s *DuelService) GetFeed(ctx context.Context, userID uuid.UUID, options *repo.Options) ([]model.DuelShow, error) {
+	duels, err := s.DuelRepository.GetFeed(ctx, userID, options)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get duel feed", err)
+	}
+
+	s.setListViewers(ctx, duels)
+
 	return duels, nil
 }
 
@@ -92,15 +137,19 @@ func (s *DuelService) GetDuelByIDUnauthorized(ctx context.Context, duelID uuid.U
 		return nil, apperrors.Internal("failed to get duel", err)
 	}
 
+	s.setViewers(ctx, duel)
+
 	return duel, nil
 }
 
 func (s *DuelService) GetMyDuels(ctx context.Context, userID uuid.UUID, options *repo.Options) ([]model.DuelShow, error) {
-	duels, err := s.DuelRepository.GetUserDuels(ctx, userID, options)
+	duels, err := s.DuelRepository.GetUserDuels(ctx, userID, userID, options)
 	if err != nil {
 		return nil, apperrors.Internal("failed to get my duels", err)
 	}
 
+	s.setListViewers(ctx, duels)
+
 	return duels, nil
 }
 
@@ -110,6 +159,8 @@ func (s *DuelService) GetMyDuelsAsParticipant(ctx context.Context, userID uuid.U
 		return nil, apperrors.Internal("failed to get my duels", err)
 	}
 
+	s.setListViewers(ctx, duels)
+
 	return duels, nil
 }
 
@@ -127,44 +178,28 @@ func (s *DuelService) GetDuelByID(ctx context.Context,
 		return nil, nil, apperrors.Internal("failed to get players", err)
 	}
 
+	s.setViewers(ctx, duel)
+
 	return duel, players, nil
 }
 
+// CreateCryptoDuel accepts the init transaction sent by the user,
+// the duel is created in background once the transaction is confirmed
 func (s *DuelService) CreateCryptoDuel(
 	ctx context.Context,
 	userID uuid.UUID,
 	req *model.CreateDuelReq,
-) (*model.CreateCryptoDuelResp, error) {
-	roomNumber, err := s.WalletService.
-		validateCreateCryptoDuelSCTransaction(
-			ctx,
-			req,
-		)
-	if err != nil {
-		zap.L().Warn("transaction validation failed", zap.Error(err))
-		return nil, apperrors.BadRequest("transaction validation failed")
+) (*model.TrackedSignature, error) {
+	if err := s.validateDuelImages(ctx, userID, req); err != nil {
+		return nil, err
 	}
 
-	user, err := s.UserRepository.GetByID(ctx, userID)
+	tracked, err := model.NewTrackedSignature(req.Hash, model.SignaturePurposeCreate, uuid.New(), userID, req)
 	if err != nil {
-		return nil, apperrors.Internal("failed to get user", err)
-	}
-
-	duel := model.DuelByCreateReq(req, user)
-
-	duel.RoomNumber = roomNumber
-
-	if err = s.createAndJoinCryptoDuel(ctx, duel, user, req.Answer, req.Hash); err != nil {
 		return nil, err
 	}
 
-	if err = s.sendDuelShareImageReq(duel); err != nil {
-		zap.L().Warn("share image request failed", zap.Error(err))
-	}
-
-	return &model.CreateCryptoDuelResp{
-		Duel: duel,
-	}, nil
+	return s.SignatureService.Track(ctx, tracked)
 }
 
 func (s *DuelService) createAndJoinCryptoDuel(
@@ -172,11 +207,13 @@ func (s *DuelService) createAndJoinCryptoDuel(
 	duel *model.Duel,
 	user *model.User,
 	ownerAnswer uint8,
+	ownerWallet string,
 	txHash string,
 ) error {
 	join := &model.JoinDuelReq{
 		DuelID: duel.ID,
 		Answer: ownerAnswer,
+		Wallet: ownerWallet,
 	}
 
 	err := s.TransactionManager.WithinTransaction(ctx,
@@ -191,6 +228,12 @@ func (s *DuelService) createAndJoinCryptoDuel(
 				return apperrors.Internal("failed to join owner to duel", err)
 			}
 
+			if keys := duelUploadKeys(duel.ImageURL, duel.BgURL); len(keys) > 0 {
+				if err = s.FileService.UploadRepository.WithTx(tx).Bind(ctx, keys); err != nil {
+					return apperrors.Internal("failed to bind duel images", err)
+				}
+			}
+
 			if txHash != "" {
 				txRecord := &model.TransactionType{
 					Signature: txHash,
@@ -219,24 +262,57 @@ func (s *DuelService) createAndJoinCryptoDuel(
 		zap.L().Error("failed to send notification", zap.Error(err))
 	}
 
+	if err = s.notifyFollowers(ctx, duel); err != nil {
+		zap.L().Error("failed to notify followers", zap.Error(err))
+	}
+
+	s.handleDuelEvent(ctx, &model.DuelEvent{Type: model.DuelEventCreated, UserID: user.ID, DuelID: duel.ID})
+	s.handleDuelEvent(ctx, &model.DuelEvent{Type: model.DuelEventJoined, UserID: user.ID, DuelID: duel.ID})
+
 	return nil
 }
 
+// validateDuelImages rejects uploaded images that belong to another user
+func (s *DuelService) validateDuelImages(ctx context.Context, userID uuid.UUID, req *model.CreateDuelReq) error {
+	return s.FileService.ValidateUploads(ctx, userID, duelUploadKeys(req.ImageURL, req.BgURL)...)
+}
+
+// duelUploadKeys filters out built-in backgrounds and external images
+func duelUploadKeys(urls ...string) []string {
+	keys := make([]string, 0, len(urls))
+	for _, url := range urls {
+		if IsUploadKey(url) {
+			keys = append(keys, url)
+		}
+	}
+
+	return keys
+}
+
 func (s *DuelService) SignCreateCryptoDuelTransaction(
 	ctx context.Context,
 	userID uuid.UUID,
 	req *model.CreateDuelReq,
 ) (string, error) {
+	if err := s.validateDuelImages(ctx, userID, req); err != nil {
+		return "", err
+	}
+
 	user, err := s.UserRepository.GetByID(ctx, userID)
 	if err != nil {
 		return "", apperrors.Internal("failed to get user", err)
 	}
 
+	payer, err := s.resolvePayer(ctx, user, req.Wallet)
+	if err != nil {
+		return "", err
+	}
+
 	duel := model.DuelByCreateReq(req, user)
 
 	duel.Status = model.DuelStatusInProcess
 
-	tx, err := s.WalletService.InitAndJoinSolanaRoomWithExternalWallet(ctx, duel, user, req.Answer)
+	tx, err := s.WalletService.InitAndJoinSolanaRoomWithExternalWallet(ctx, duel, payer, req.Answer)
 	if err != nil {
 		return "", err
 	}
@@ -244,11 +320,13 @@ func (s *DuelService) SignCreateCryptoDuelTransaction(
 	return tx, nil
 }
 
+// JoinExternalWalletCryptoDuel accepts the join transaction sent by the user,
+// the player is recorded in background once the transaction is confirmed
 func (s *DuelService) JoinExternalWalletCryptoDuel(
 	ctx context.Context,
 	userID uuid.UUID,
 	req *model.JoinDuelReq,
-) (*model.JoinCryptoDuelResp, error) {
+) (*model.TrackedSignature, error) {
 	user, err := s.UserRepository.GetByID(ctx, userID)
 	if err != nil {
 		return nil, apperrors.Internal("failed to get user", err)
@@ -263,51 +341,20 @@ func (s *DuelService) JoinExternalWalletCryptoDuel(
 		return nil, err
 	}
 
-	err = s.WalletService.
-		validateJoinCryptoDuelSCTransaction(
-			ctx,
-			req.Hash,
-		)
-	if err != nil {
-		zap.L().Warn("transaction validation error", zap.Error(err))
-		return nil, apperrors.BadRequest("transaction validation failed")
-	}
-
-	var player *model.Player
-	err = s.TransactionManager.WithinTransaction(ctx,
-		func(ctx context.Context, tx bun.Tx) error {
-			player, err = s.DuelRepository.WithTx(tx).JoinDuel(ctx, userID, req, duel)
-			if err != nil {
-				return apperrors.Internal("failed to join duel", err)
-			}
-
-			txRecord := &model.TransactionType{
-				Signature: req.Hash,
-				TxType:    model.TransactionTypeDuelPrediction,
-			}
-			if err = s.TxRepository.WithTx(tx).Create(ctx, txRecord); err != nil {
-				return apperrors.Internal("failed to create transaction record", err)
-			}
-
-			return nil
-		})
+	tracked, err := model.NewTrackedSignature(req.Hash, model.SignaturePurposeJoin, duel.ID, user.ID, req)
 	if err != nil {
 		return nil, err
 	}
 
-	notification := &model.VotedForNotification{
-		DuelID:   duel.ID,
-		DuelName: duel.Question,
-		VotedFor: req.Answer,
-	}
-
-	err = s.sendVotedForNotification(ctx, user.ID, duel, notification)
-	if err != nil {
-		zap.L().Error("failed to send notification", zap.Error(err))
-	}
-
-	return &model.JoinCryptoDuelResp{Player: player}, nil
+	return s.SignatureService.Track(ctx, tracked)
+}
 
+func (s *DuelService) GetCryptoDuelOperation(
+	ctx context.Context,
+	userID uuid.UUID,
+	operationID uuid.UUID,
+) (*model.TrackedSignature, error) {
+	return s.SignatureService.GetUserSignature(ctx, userID, operationID)
 }
 
 func (s *DuelService) SignJoinCryptoDuelTransaction(
@@ -329,7 +376,12 @@ func (s *DuelService) SignJoinCryptoDuelTransaction(
 		return "", err
 	}
 
-	tx, err := s.WalletService.JoinSolanaRoomWithExternalWallet(ctx, duel, user, req.Answer)
+	payer, err := s.resolvePayer(ctx, user, req.Wallet)
+	if err != nil {
+		return "", err
+	}
+
+	tx, err := s.WalletService.JoinSolanaRoomWithExternalWallet(ctx, duel, payer, req.Answer)
 	if err != nil {
 		return "", err
 	}
@@ -363,6 +415,8 @@ func (s *DuelService) ResolveCryptoDuelByOwner(
 		return nil, err
 	}
 
+	s.SignatureService.TrackSent(ctx, model.SignaturePurposeRefund, duel.ID, txHashes...)
+
 	return txHashes, nil
 }
 
@@ -463,9 +517,14 @@ func (s *DuelService) resolveCryptoDuel(
 			zap.Uint64("room_number", duel.RoomNumber))
 	}
 
+	resolvedAt := time.Now().UTC()
+
 	duel.Status = model.DuelStatusResolved
 	duel.FinalResult = &req.Answer
 	duel.WinnersCount = allDuelWinnersCount
+	duel.CreatorCommission = float64(commissionRewards.CreatorCommissionReward) / priceMultiplier
+	duel.ResolvedAt = &resolvedAt
+	duel.UpdatedAt = resolvedAt
 
 	winnerIDs := make([]uuid.UUID, 0, len(duelWinners))
 	for i := range duelWinners {
@@ -489,6 +548,16 @@ func (s *DuelService) resolveCryptoDuel(
 		return nil, apperrors.Internal("failed to resolve a duel", err)
 	}
 
+	s.publishDuelEvent(ctx, duel.ID, model.EventDuelResolved, &model.DuelResolvedEvent{
+		DuelID:       duel.ID,
+		FinalResult:  req.Answer,
+		WinnersCount: duel.WinnersCount,
+	})
+
+	s.SignatureService.TrackSent(ctx, model.SignaturePurposeReward, duel.ID, duelRewardTxHashes...)
+	s.SignatureService.TrackSent(ctx, model.SignaturePurposeCommission, duel.ID, commissionRewards.CreatorCommissionTxHash)
+	s.SignatureService.TrackSent(ctx, model.SignaturePurposeClose, duel.ID, txHash)
+
 	allTxHashes := append(duelRewardTxHashes, commissionRewards.CreatorCommissionTxHash, txHash)
 	allTxHashes = append(allTxHashes, refundedPlayersTxHashes...)
 
@@ -522,11 +591,18 @@ func (s *DuelService) rewardWithCommissions(
 		return &model.CommissionRewards{}, apperrors.Internal("failed to get duel owner by id", err)
 	}
 
+	// the commission goes to the wallet the owner created the duel with
+	ownerWallet := duelOwner.PublicAddress
+	ownerPlayer, err := s.PlayerRepository.GetByUserID(ctx, duelOwner.ID, duel.ID)
+	if err == nil && ownerPlayer.WalletAddress != "" {
+		ownerWallet = ownerPlayer.WalletAddress
+	}
+
 	creatorCommissionReward := duelParams.CalculateCryptoCommissionReward(USDCPriceMultiplier)
 
 	creatorCommissionTxHash, err := s.WalletService.RewardDuelOwnerWithCommission(
 		ctx,
-		duelOwner.PublicAddress,
+		ownerWallet,
 		creatorCommissionReward,
 		mint,
 	)
@@ -623,6 +699,22 @@ func (s *DuelService) cancelCryptoDuel(
 		return nil, err
 	}
 
+	// images of a cancelled duel are not bound anymore
+	if err = s.FileService.ReleaseUploads(ctx, duelUploadKeys(duel.ImageURL, duel.BgURL)...); err != nil {
+		zap.L().Error("failed to release duel images",
+			zap.Error(err),
+			zap.String("duel_id", duel.ID.String()))
+	}
+
+	s.publishDuelEvent(ctx, duel.ID, model.EventDuelCancelled, &model.DuelCancelledEvent{
+		DuelID:             duel.ID,
+		Status:             duel.Status,
+		CancellationReason: duel.CancellationReason,
+	})
+
+	s.SignatureService.TrackSent(ctx, model.SignaturePurposeRefund, duel.ID, txHashes...)
+	s.SignatureService.TrackSent(ctx, model.SignaturePurposeClose, duel.ID, roomClosingTxHash)
+
 	return append(txHashes, roomClosingTxHash), nil
 }
 
@@ -676,5 +768,7 @@ func (s *DuelService) partialCryptoRefund(
 		return nil, err
 	}
 
+	s.SignatureService.TrackSent(ctx, model.SignaturePurposeRefund, duel.ID, txHashes...)
+
 	return txHashes, nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"duels-api/internal/model"
 	"duels-api/pkg/apperrors"
+	repo "duels-api/pkg/repository"
 	"fmt"
 	"github.com/google/uuid"
+	"go.uber.org/zap"
 )
 
 func (s *DuelService) hasChargedDuelPriceFromUser(duelPlayersCount uint64, duelOldStatus, duelNewStatus uint8) bool {
@@ -120,46 +122,185 @@ func (s *DuelService) sendNotificationBulk(
 	return nil
 }
 
-func (s *DuelService) sendVotedForNotification(
-	ctx context.Context,
-	userID uuid.UUID,
-	duel *model.Duel,
-	notificationPayload model.NotificationPayload,
-) error {
-	err := s.sendNotification(
-		ctx,
-		userID,
-		model.NotificationVotedFor,
-		notificationPayload,
-	)
-	if err != nil {
-		return err
+// handleDuelEvent drives achievements and season points by the event, failures
+// are logged because they must never fail the duel flow
+func (s *DuelService) handleDuelEvent(ctx context.Context, event *model.DuelEvent) {
+	if err := s.AchievementService.Handle(ctx, event); err != nil {
+		zap.L().Error("failed to handle achievement event",
+			zap.Uint8("type", uint8(event.Type)),
+			zap.String("user_id", event.UserID.String()),
+			zap.Error(err),
+		)
 	}
 
-	duel.PlayersCount++
+	if err := s.SeasonService.Handle(ctx, event); err != nil {
+		zap.L().Error("failed to handle season event",
+			zap.Uint8("type", uint8(event.Type)),
+			zap.String("user_id", event.UserID.String()),
+			zap.Error(err),
+		)
+	}
+}
 
-	if duel.PlayersCount == 100 ||
-		duel.PlayersCount == 500 ||
-		duel.PlayersCount == 1000 {
+// handleDuelResolved updates win streaks of the players and handles their results
+func (s *DuelService) handleDuelResolved(ctx context.Context, duelID uuid.UUID, winnerIDs, loserIDs []uuid.UUID) {
+	handle := func(userID uuid.UUID, won bool) {
+		event := &model.DuelEvent{
+			Type:   model.DuelEventLost,
+			UserID: userID,
+			DuelID: duelID,
+		}
+		if won {
+			event.Type = model.DuelEventWon
+		}
 
-		notification := &model.DuelPlayersJoinedNotification{
-			DuelID:       duel.ID,
-			DuelName:     duel.Question,
-			PlayersCount: duel.PlayersCount,
+		streak, err := s.AchievementService.UpdateWinStreak(ctx, event)
+		if err != nil {
+			zap.L().Error("failed to update win streak", zap.String("user_id", userID.String()), zap.Error(err))
 		}
+		event.WinStreak = streak
 
-		err := s.sendNotification(
-			ctx,
-			duel.OwnerID,
-			model.NotificationDuelPlayersJoined,
-			notification,
+		s.handleDuelEvent(ctx, event)
+	}
+
+	for _, id := range winnerIDs {
+		handle(id, true)
+	}
+
+	for _, id := range loserIDs {
+		handle(id, false)
+	}
+}
+
+// publishDuelEvent broadcasts the event to the subscribers of the duel topic,
+// failures are logged because they must never fail the duel flow
+func (s *DuelService) publishDuelEvent(ctx context.Context, duelID uuid.UUID, eventType uint8, payload any) {
+	event := model.NewTopicEvent(model.DuelTopic(duelID), eventType, payload)
+
+	if err := s.Events.PublishTopic(ctx, event); err != nil {
+		zap.L().Error("failed to publish duel event",
+			zap.Uint8("type", eventType),
+			zap.String("duel_id", duelID.String()),
+			zap.Error(err),
 		)
-		if err != nil {
-			return err
+	}
+}
+
+// publishDuelJoined broadcasts the odds of the duel after the user joined it
+func (s *DuelService) publishDuelJoined(ctx context.Context, duelID uuid.UUID, user *model.User) {
+	odds, err := s.DuelRepository.GetOdds(ctx, duelID)
+	if err != nil {
+		zap.L().Error("failed to get duel odds", zap.String("duel_id", duelID.String()), zap.Error(err))
+		return
+	}
+
+	s.publishDuelEvent(ctx, duelID, model.EventDuelJoined, &model.DuelJoinedEvent{
+		DuelOdds: *odds,
+		UserID:   user.ID,
+		Username: user.Username.String(),
+	})
+}
+
+// setViewers sets the viewer counts of duels in process, failures are
+// logged because the counts are informative
+func (s *DuelService) setViewers(ctx context.Context, duels ...*model.DuelShow) {
+	duelIDs := make([]uuid.UUID, 0, len(duels))
+	for _, duel := range duels {
+		if duel != nil && duel.Status == model.DuelStatusInProcess {
+			duelIDs = append(duelIDs, duel.ID)
 		}
 	}
 
-	return nil
+	if len(duelIDs) == 0 {
+		return
+	}
+
+	counts, err := s.Presence.Count(ctx, duelIDs)
+	if err != nil {
+		zap.L().Error("failed to count duel viewers", zap.Error(err))
+		return
+	}
+
+	for _, duel := range duels {
+		if duel == nil {
+			continue
+		}
+
+		if count, ok := counts[duel.ID]; ok {
+			duel.Viewers = &count
+		}
+	}
+}
+
+func (s *DuelService) setListViewers(ctx context.Context, duels []model.DuelShow) {
+	refs := make([]*model.DuelShow, len(duels))
+	for i := range duels {
+		refs[i] = &duels[i]
+	}
+
+	s.setViewers(ctx, refs...)
+}
+
+// notifyFollowers tells the followers of the owner about the new duel
+func (s *DuelService) notifyFollowers(ctx context.Context, duel *model.Duel) error {
+	followerIDs, err := s.FollowRepository.GetFollowerIDs(ctx, duel.OwnerID)
+	if err != nil {
+		return apperrors.Internal("failed to get followers", err)
+	}
+
+	notification := &model.FollowedDuelCreatedNotification{
+		DuelID:          duel.ID,
+		DuelName:        duel.Question,
+		CreatorID:       duel.OwnerID,
+		CreatorUsername: duel.Username,
+	}
+
+	payloads := make([]*model.UserNotificationPayload, len(followerIDs))
+	for i, followerID := range followerIDs {
+		payloads[i] = &model.UserNotificationPayload{
+			UserID:       followerID,
+			Notification: notification,
+		}
+	}
+
+	return s.sendNotificationBulk(ctx, model.NotificationFollowedDuelCreated, payloads)
+}
+
+// handleDuelJoined credits the join and the players milestones of the duel,
+// the owner is notified when the duel reaches a milestone
+func (s *DuelService) handleDuelJoined(ctx context.Context, userID uuid.UUID, duel *model.Duel) {
+	s.handleDuelEvent(ctx, &model.DuelEvent{Type: model.DuelEventJoined, UserID: userID, DuelID: duel.ID})
+
+	duel.PlayersCount++
+
+	if duel.PlayersCount != 100 &&
+		duel.PlayersCount != 500 &&
+		duel.PlayersCount != 1000 {
+		return
+	}
+
+	s.handleDuelEvent(ctx, &model.DuelEvent{
+		Type:         model.DuelEventPlayersJoined,
+		UserID:       duel.OwnerID,
+		DuelID:       duel.ID,
+		PlayersCount: duel.PlayersCount,
+	})
+
+	notification := &model.DuelPlayersJoinedNotification{
+		DuelID:       duel.ID,
+		DuelName:     duel.Question,
+		PlayersCount: duel.PlayersCount,
+	}
+
+	err := s.sendNotification(
+		ctx,
+		duel.OwnerID,
+		model.NotificationDuelPlayersJoined,
+		notification,
+	)
+	if err != nil {
+		zap.L().Error("failed to send notification", zap.Error(err))
+	}
 }
 
 func (s *DuelService) collectDuelRefundNotifications(
@@ -231,6 +372,8 @@ func (s *DuelService) sendNotificationsForDuelResolve(
 		return apperrors.Internal("failed to get duel loser ids", err)
 	}
 
+	s.handleDuelResolved(ctx, params.Duel.ID, params.WinnerIDs, loserIDs)
+
 	for _, id := range loserIDs {
 		notification := &model.DuelResolveNotification{
 			DuelID:   params.Duel.ID,
@@ -291,3 +434,22 @@ func (s *DuelService) sendDuelRefundNotification(
 
 	return nil
 }
+
+// resolvePayer returns the wallet paying for the user's duel,
+// it must be one of the wallets linked to the user
+func (s *DuelService) resolvePayer(ctx context.Context, user *model.User, wallet string) (string, error) {
+	if wallet == "" || wallet == user.PublicAddress {
+		return user.PublicAddress, nil
+	}
+
+	_, err := s.WalletRepository.GetUserWallet(ctx, user.ID, wallet)
+	if err != nil {
+		if repo.IsErrNoRows(err) {
+			return "", apperrors.BadRequest("wallet is not linked to the user")
+		}
+
+		return "", apperrors.Internal("failed to get user wallet", err)
+	}
+
+	return wallet, nil
+}
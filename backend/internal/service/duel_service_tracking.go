@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/apperrors"
+
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+func (s *DuelService) registerSignatureHandlers() {
+	s.SignatureService.Handle(model.SignaturePurposeCreate, SignatureHandler{
+		OnConfirmed: s.completeCreateCryptoDuel,
+	})
+	s.SignatureService.Handle(model.SignaturePurposeJoin, SignatureHandler{
+		OnConfirmed: s.completeJoinCryptoDuel,
+	})
+
+	payout := SignatureHandler{OnFailed: s.failPayout}
+	s.SignatureService.Handle(model.SignaturePurposeReward, payout)
+	s.SignatureService.Handle(model.SignaturePurposeRefund, payout)
+	s.SignatureService.Handle(model.SignaturePurposeCommission, payout)
+
+	s.SignatureService.Handle(model.SignaturePurposeClose, SignatureHandler{
+		OnFailed: func(ctx context.Context, tracked *model.TrackedSignature) {
+			zap.L().Error("failed to close solana room",
+				zap.String("duel_id", tracked.DuelID.String()),
+				zap.String("signature", tracked.Signature),
+				zap.String("reason", tracked.Error),
+			)
+		},
+	})
+}
+
+func (s *DuelService) completeCreateCryptoDuel(ctx context.Context, tracked *model.TrackedSignature) error {
+	handled, err := s.TxRepository.Exists(ctx, &model.TransactionType{Signature: tracked.Signature})
+	if err != nil {
+		return apperrors.Internal("failed to check transaction record", err)
+	}
+	if handled {
+		return nil
+	}
+
+	req := new(model.CreateDuelReq)
+	if err = tracked.UnmarshalData(req); err != nil {
+		return err
+	}
+
+	roomNumber, payer, err := s.WalletService.validateCreateCryptoDuelSCTransaction(ctx, req)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.UserRepository.GetByID(ctx, tracked.UserID)
+	if err != nil {
+		return apperrors.Internal("failed to get user", err)
+	}
+
+	if _, err = s.resolvePayer(ctx, user, payer); err != nil {
+		return err
+	}
+
+	s.SignatureService.Advance(ctx, tracked, model.SignatureStageValidated)
+
+	duel := model.DuelByCreateReq(req, user)
+
+	duel.ID = tracked.DuelID
+	duel.RoomNumber = roomNumber
+
+	if err = s.createAndJoinCryptoDuel(ctx, duel, user, req.Answer, payer, req.Hash); err != nil {
+		return err
+	}
+
+	if err = s.sendDuelShareImageReq(duel); err != nil {
+		zap.L().Warn("share image request failed", zap.Error(err))
+	}
+
+	return nil
+}
+
+func (s *DuelService) completeJoinCryptoDuel(ctx context.Context, tracked *model.TrackedSignature) error {
+	handled, err := s.TxRepository.Exists(ctx, &model.TransactionType{Signature: tracked.Signature})
+	if err != nil {
+		return apperrors.Internal("failed to check transaction record", err)
+	}
+	if handled {
+		return nil
+	}
+
+	req := new(model.JoinDuelReq)
+	if err = tracked.UnmarshalData(req); err != nil {
+		return err
+	}
+
+	duel, err := s.DuelRepository.GetByID(ctx, req.DuelID)
+	if err != nil {
+		return apperrors.Internal("failed to get duel", err)
+	}
+
+	if err = s.isAbleToJoinDuel(ctx, duel, tracked.UserID); err != nil {
+		return err
+	}
+
+	payer, err := s.WalletService.validateJoinCryptoDuelSCTransaction(ctx, req.Hash)
+	if err != nil {
+		return err
+	}
+
+	user, err := s.UserRepository.GetByID(ctx, tracked.UserID)
+	if err != nil {
+		return apperrors.Internal("failed to get user", err)
+	}
+
+	if req.Wallet, err = s.resolvePayer(ctx, user, payer); err != nil {
+		return err
+	}
+
+	s.SignatureService.Advance(ctx, tracked, model.SignatureStageValidated)
+
+	err = s.TransactionManager.WithinTransaction(ctx,
+		func(ctx context.Context, tx bun.Tx) error {
+			_, err = s.DuelRepository.WithTx(tx).JoinDuel(ctx, tracked.UserID, req, duel)
+			if err != nil {
+				return apperrors.Internal("failed to join duel", err)
+			}
+
+			txRecord := &model.TransactionType{
+				Signature: req.Hash,
+				TxType:    model.TransactionTypeDuelPrediction,
+			}
+			if err = s.TxRepository.WithTx(tx).Create(ctx, txRecord); err != nil {
+				return apperrors.Internal("failed to create transaction record", err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return err
+	}
+
+	s.publishDuelJoined(ctx, duel.ID, user)
+	s.handleDuelJoined(ctx, tracked.UserID, duel)
+
+	notification := &model.VotedForNotification{
+		DuelID:   duel.ID,
+		DuelName: duel.Question,
+		VotedFor: req.Answer,
+	}
+
+	err = s.sendNotification(ctx, tracked.UserID, model.NotificationVotedFor, notification)
+	if err != nil {
+		zap.L().Error("failed to send notification", zap.Error(err))
+	}
+
+	return nil
+}
+
+// failPayout drops the record of a payout that never landed,
+// so the transfer is not reported as done
+func (s *DuelService) failPayout(ctx context.Context, tracked *model.TrackedSignature) {
+	zap.L().Error("payout transaction failed",
+		zap.Uint8("purpose", tracked.Purpose),
+		zap.String("duel_id", tracked.DuelID.String()),
+		zap.String("signature", tracked.Signature),
+		zap.String("reason", tracked.Error),
+	)
+
+	if err := s.TxRepository.DeleteBySignature(ctx, tracked.Signature); err != nil {
+		zap.L().Error("failed to delete failed payout record",
+			zap.String("signature", tracked.Signature),
+			zap.Error(err),
+		)
+	}
+}
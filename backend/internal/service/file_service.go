@@ -1,11 +1,17 @@
 package service
 
 import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/internal/storage/object"
 	"duels-api/internal/storage/repository"
 	"duels-api/pkg/apperrors"
+	"duels-api/pkg/imageproc"
+	"errors"
 	"io"
 	"mime/multipart"
 	"os"
+	"path"
 	"path/filepath"
 	"strings"
 
@@ -13,11 +19,12 @@ import (
 )
 
 type FileService struct {
-	FileRepository       *repository.FileRepository
+	Storage              object.Storage
+	UploadRepository     *repository.UploadRepository
 	defaultUserIconPaths map[string]struct{}
 }
 
-func NewFileService(fileRepo *repository.FileRepository) (*FileService, error) {
+func NewFileService(storage object.Storage, uploadRepository *repository.UploadRepository) (*FileService, error) {
 	files, err := os.ReadDir(MediaFilesDirectory)
 	if err != nil {
 		return nil, apperrors.Internal("failed to read default user icon's dir", err)
@@ -32,7 +39,8 @@ func NewFileService(fileRepo *repository.FileRepository) (*FileService, error) {
 
 	return &FileService{
 		defaultUserIconPaths: svgFiles,
-		FileRepository:       fileRepo,
+		Storage:              storage,
+		UploadRepository:     uploadRepository,
 	}, nil
 }
 
@@ -49,19 +57,25 @@ var allowedImageExtensions = map[string]struct{}{
 }
 
 const (
-	mediaFilesDir      = "storage"
 	userUploadMediaDir = "user_uploads"
+	thumbnailSuffix    = "_thumb"
 
 	profileIconsParentDir = "/profile-icons"
 )
 
+// SaveUserFile stores the profile picture as a square avatar with a thumbnail
 func (s *FileService) SaveUserFile(
+	ctx context.Context,
+	userID uuid.UUID,
 	file *multipart.FileHeader,
 ) (string, error) {
-	return s.saveFile(userUploadMediaDir, file)
+	return s.saveFile(ctx, userID, userUploadMediaDir, file, imageproc.ProfileAvatar)
 }
 
+// SaveUserFiles stores duel images, they are collected unless a duel refers to them in time
 func (s *FileService) SaveUserFiles(
+	ctx context.Context,
+	userID uuid.UUID,
 	files []*multipart.FileHeader,
 ) ([]string, error) {
 	if len(files) > 3 {
@@ -71,11 +85,11 @@ func (s *FileService) SaveUserFiles(
 	filesPath := make([]string, len(files))
 
 	for i, file := range files {
-		path, err := s.saveFile(userUploadMediaDir, file)
+		key, err := s.saveFile(ctx, userID, userUploadMediaDir, file, imageproc.ProfileDuelCard)
 		if err != nil {
 			return nil, err
 		}
-		filesPath[i] = path
+		filesPath[i] = key
 	}
 
 	return filesPath, nil
@@ -83,74 +97,126 @@ func (s *FileService) SaveUserFiles(
 
 const maxAllowedSize = 3 * 1024 * 1024 // 3 MB
 
+// saveFile normalizes the image by the profile and stores it with its thumbnail,
+// the returned key is resolved by /media/<key>. The upload is registered as pending.
 func (s *FileService) saveFile(
+	ctx context.Context,
+	userID uuid.UUID,
 	dir string,
 	file *multipart.FileHeader,
+	profile imageproc.Profile,
 ) (string, error) {
 	if file.Size > maxAllowedSize {
 		return "", apperrors.BadRequest("file exceeds max allowed size")
 	}
 
-	extension := strings.ToLower(filepath.Ext(file.Filename))
-	if _, ok := allowedImageExtensions[extension]; !ok {
-		return "", apperrors.BadRequest("invalid file extension")
-	}
-
 	fileContent, err := file.Open()
 	if err != nil {
 		return "", apperrors.Internal("failed to open an image", err)
 	}
 	defer func() { _ = fileContent.Close() }()
 
-	data, err := io.ReadAll(fileContent)
+	data, err := io.ReadAll(io.LimitReader(fileContent, maxAllowedSize+1))
 	if err != nil {
 		return "", apperrors.Internal("failed to read an image", err)
 	}
 
-	directory := filepath.Join(mediaFilesDir, dir)
-	_ = os.MkdirAll(directory, os.ModePerm)
+	if len(data) > maxAllowedSize {
+		return "", apperrors.BadRequest("file exceeds max allowed size")
+	}
+
+	processed, err := imageproc.Process(data, profile)
+	if err != nil {
+		return "", imageError(err)
+	}
 
-	fileName := uuid.New().String() + extension
-	savePath := filepath.Join(mediaFilesDir, dir, fileName)
+	key := path.Join("/", dir, uuid.New().String()+processed.Extension)
 
-	if err = s.FileRepository.Save(savePath, data); err != nil {
+	if err = s.Storage.Put(ctx, key, processed.Image, processed.ContentType); err != nil {
 		return "", apperrors.Internal("failed to save an image", err)
 	}
 
-	publicURL := "/" + filepath.Join(dir, fileName)
+	err = s.Storage.Put(ctx, ThumbnailKey(key), processed.Thumbnail, processed.ContentType)
+	if err != nil {
+		return "", apperrors.Internal("failed to save an image thumbnail", err)
+	}
+
+	if err = s.UploadRepository.Create(ctx, model.NewUpload(userID, key)); err != nil {
+		return "", apperrors.Internal("failed to register an upload", err)
+	}
+
+	return key, nil
+}
 
-	return publicURL, nil
+// ThumbnailKey returns the key of the thumbnail stored next to the image
+func ThumbnailKey(key string) string {
+	extension := path.Ext(key)
+	return strings.TrimSuffix(key, extension) + thumbnailSuffix + extension
+}
+
+func imageError(err error) error {
+	switch {
+	case errors.Is(err, imageproc.ErrUnsupportedFormat):
+		return apperrors.BadRequest("unsupported image format, only jpeg, png and svg are allowed")
+	case errors.Is(err, imageproc.ErrTooLarge):
+		return apperrors.BadRequest("image dimensions are too large")
+	case errors.Is(err, imageproc.ErrTooSmall):
+		return apperrors.BadRequest("image dimensions are too small")
+	default:
+		return apperrors.Internal("failed to process an image", err)
+	}
 }
 
 func (s *FileService) RemoveUserFile(
-	fileName string,
+	ctx context.Context,
+	key string,
 ) error {
-	return s.RemoveFile(userUploadMediaDir, fileName)
+	return s.RemoveFile(ctx, userUploadMediaDir, key)
 }
 
 func (s *FileService) RemoveFile(
+	ctx context.Context,
 	dir string,
-	fileName string,
+	key string,
 ) error {
-	path := filepath.Join(mediaFilesDir, fileName)
-	cleanPath := filepath.Clean(path)
+	cleanKey := path.Clean("/" + key)
 
-	expectedPrefix := filepath.Join(mediaFilesDir, dir)
-	if !strings.HasPrefix(cleanPath, expectedPrefix) {
+	expectedPrefix := path.Join("/", dir) + "/"
+	if !strings.HasPrefix(cleanKey, expectedPrefix) {
 		return apperrors.BadRequest("invalid path: attempting directory traversal")
 	}
 
-	if _, ok := allowedImageExtensions[filepath.Ext(fileName)]; !ok {
+	if _, ok := allowedImageExtensions[path.Ext(cleanKey)]; !ok {
 		return apperrors.BadRequest("invalid file extension")
 	}
 
-	if err := s.FileRepository.Remove(cleanPath); err != nil {
-		if os.IsNotExist(err) {
+	if err := s.Storage.Delete(ctx, cleanKey); err != nil {
+		if errors.Is(err, object.ErrNotFound) {
 			return apperrors.NotFound("file not found")
 		}
 
 		return apperrors.Internal("failed to delete file", err)
 	}
 
+	// files uploaded before processing was introduced have no thumbnails
+	err := s.Storage.Delete(ctx, ThumbnailKey(cleanKey))
+	if err != nil && !errors.Is(err, object.ErrNotFound) {
+		return apperrors.Internal("failed to delete file thumbnail", err)
+	}
+
+	if err = s.UploadRepository.DeleteByKey(ctx, cleanKey); err != nil {
+		return apperrors.Internal("failed to delete upload", err)
+	}
+
 	return nil
 }
+
+// FileURL returns the url the stored file can be downloaded from
+func (s *FileService) FileURL(ctx context.Context, key string) (string, error) {
+	url, err := s.Storage.URL(ctx, key)
+	if err != nil {
+		return "", apperrors.BadRequest("invalid file path", err)
+	}
+
+	return url, nil
+}
@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/internal/storage/object"
+	"duels-api/pkg/apperrors"
+	"errors"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+const orphanedUploadsBatch = 100
+
+// IsUploadKey reports whether the image url points to a file uploaded by a user
+func IsUploadKey(key string) bool {
+	return strings.HasPrefix(path.Clean("/"+key), "/"+userUploadMediaDir+"/")
+}
+
+// ValidateUploads checks that every key is a file uploaded by the user
+func (s *FileService) ValidateUploads(ctx context.Context, userID uuid.UUID, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	uploads, err := s.UploadRepository.GetByKeys(ctx, keys)
+	if err != nil {
+		return apperrors.Internal("failed to get uploads", err)
+	}
+
+	owned := make(map[string]struct{}, len(uploads))
+	for _, upload := range uploads {
+		if upload.UserID == userID {
+			owned[upload.Key] = struct{}{}
+		}
+	}
+
+	for _, key := range keys {
+		if _, ok := owned[key]; !ok {
+			return apperrors.BadRequest("image " + key + " is not uploaded by the user")
+		}
+	}
+
+	return nil
+}
+
+// BindUploads marks the files as referenced, so they are never collected
+func (s *FileService) BindUploads(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := s.UploadRepository.Bind(ctx, keys); err != nil {
+		return apperrors.Internal("failed to bind uploads", err)
+	}
+
+	return nil
+}
+
+// ReleaseUploads marks the files as unreferenced, they are collected unless something else refers to them
+func (s *FileService) ReleaseUploads(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	if err := s.UploadRepository.Release(ctx, keys); err != nil {
+		return apperrors.Internal("failed to release uploads", err)
+	}
+
+	return nil
+}
+
+// CleanupOrphanedUploads deletes files uploaded before the cutoff that nothing refers to
+func (s *FileService) CleanupOrphanedUploads(ctx context.Context, cutoff time.Time) (int, error) {
+	deleted := 0
+
+	for {
+		uploads, err := s.UploadRepository.GetOrphaned(ctx, cutoff, orphanedUploadsBatch)
+		if err != nil {
+			return deleted, apperrors.Internal("failed to get orphaned uploads", err)
+		}
+
+		for _, upload := range uploads {
+			if err = s.deleteUpload(ctx, &upload); err != nil {
+				// the upload stays registered and is retried on the next run
+				zap.L().Error("failed to delete orphaned upload",
+					zap.String("key", upload.Key),
+					zap.Error(err),
+				)
+				continue
+			}
+
+			deleted++
+		}
+
+		if len(uploads) < orphanedUploadsBatch {
+			return deleted, nil
+		}
+
+		if err = ctx.Err(); err != nil {
+			return deleted, err
+		}
+	}
+}
+
+func (s *FileService) deleteUpload(ctx context.Context, upload *model.Upload) error {
+	for _, key := range []string{upload.Key, ThumbnailKey(upload.Key)} {
+		if err := s.Storage.Delete(ctx, key); err != nil && !errors.Is(err, object.ErrNotFound) {
+			return err
+		}
+	}
+
+	return s.UploadRepository.DeleteByKey(ctx, upload.Key)
+}
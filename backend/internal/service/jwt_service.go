@@ -2,32 +2,53 @@ package service
 
 import (
 	"context"
+	"duels-api/internal/model"
 	"duels-api/internal/storage/cache"
 	"duels-api/pkg/apperrors"
 	auth "duels-api/pkg/jwt"
 	"github.com/google/uuid"
 	"strings"
+	"time"
 )
 
 type JWTService struct {
-	Storage *cache.JWTStorage
-	JWT     auth.JWTAuthenticator
+	Storage  *cache.JWTStorage
+	Denylist *cache.TokenDenylist
+	JWT      auth.JWTAuthenticator
 }
 
-func NewJWTService(storage *cache.JWTStorage, jwt auth.JWTAuthenticator) *JWTService {
+func NewJWTService(
+	storage *cache.JWTStorage,
+	denylist *cache.TokenDenylist,
+	jwt auth.JWTAuthenticator,
+) *JWTService {
 	return &JWTService{
-		Storage: storage,
-		JWT:     jwt,
+		Storage:  storage,
+		Denylist: denylist,
+		JWT:      jwt,
 	}
 }
 
-func (s *JWTService) GenerateTokenPair(ctx context.Context, claims auth.TokenClaims) (*auth.TokenPair, error) {
+func (s *JWTService) GenerateTokenPair(
+	ctx context.Context,
+	claims auth.TokenClaims,
+	meta model.SessionMeta,
+) (*auth.TokenPair, error) {
 	tokenPair, err := s.JWT.GenerateTokenPair(claims)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.Storage.Save(ctx, tokenPair.RefreshToken, claims)
+	now := time.Now().UTC()
+	session := &model.Session{
+		SessionMeta:       meta,
+		LastSeenUserAgent: meta.UserAgent,
+		LastSeenIP:        meta.IP,
+		CreatedAt:         now,
+		LastUsedAt:        now,
+	}
+
+	err = s.Storage.Save(ctx, tokenPair.RefreshToken, claims, session)
 	if err != nil {
 		return nil, err
 	}
@@ -42,8 +63,24 @@ func (s *JWTService) RefreshTokenPair(
 	ctx context.Context,
 	claims auth.TokenClaims,
 	prevSessionID uuid.UUID,
+	meta model.SessionMeta,
 ) (*auth.TokenPair, error) {
-	err := s.Storage.DeleteUserSession(ctx, claims.UserID, prevSessionID)
+	// the session keeps its sign in time and device across refreshes
+	session, err := s.Storage.GetSessionMeta(ctx, claims.UserID, prevSessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now().UTC()
+	if session == nil {
+		session = &model.Session{SessionMeta: meta, CreatedAt: now}
+	}
+	session.LastSeenUserAgent = meta.UserAgent
+	session.LastSeenIP = meta.IP
+	session.LastUsedAt = now
+
+	// rotation is not a revocation, open connections are not closed for it
+	err = s.Storage.DeleteRotatedSession(ctx, claims.UserID, prevSessionID)
 	if err != nil {
 		return nil, err
 	}
@@ -53,7 +90,7 @@ func (s *JWTService) RefreshTokenPair(
 		return nil, err
 	}
 
-	err = s.Storage.Save(ctx, tokenPair.RefreshToken, claims)
+	err = s.Storage.Save(ctx, tokenPair.RefreshToken, claims, session)
 	if err != nil {
 		return nil, err
 	}
@@ -80,8 +117,9 @@ func (s *JWTService) GetRefreshByUserID(
 func (s *JWTService) RefreshSession(
 	ctx context.Context,
 	token string,
+	meta model.SessionMeta,
 ) (*auth.TokenPair, error) {
-	claims, err := s.ParseToken(token)
+	claims, err := s.JWT.ParseToken(token, auth.TokenTypeRefresh)
 	if err != nil {
 		return nil, err
 	}
@@ -90,6 +128,22 @@ func (s *JWTService) RefreshSession(
 		return nil, apperrors.Unauthorized("invalid token claims")
 	}
 
+	reason, err := s.Denylist.Reason(ctx, claims.TokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch reason {
+	case cache.DenyReasonRotated:
+		// a rotated refresh token is presented again, one of its holders is not the user
+		if _, err = s.Storage.DeleteSessionFamily(ctx, claims.UserID, claims.FamilyID); err != nil {
+			return nil, err
+		}
+		return nil, apperrors.Unauthorized("refresh token reuse detected, session is revoked")
+	case cache.DenyReasonRevoked:
+		return nil, apperrors.Unauthorized("token is revoked")
+	}
+
 	storedRefresh, err := s.GetRefreshByUserID(ctx, claims.UserID, claims.SessionID)
 	if err != nil {
 		return nil, err
@@ -100,10 +154,15 @@ func (s *JWTService) RefreshSession(
 		return nil, apperrors.Unauthorized("refresh token does not match with stored one")
 	}
 
+	err = s.Denylist.Deny(ctx, claims.TokenID, cache.DenyReasonRotated, claims.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
 	prevSessionID := claims.SessionID
 	claims.RefreshSessionID()
 
-	tokenPair, err := s.RefreshTokenPair(ctx, *claims, prevSessionID)
+	tokenPair, err := s.RefreshTokenPair(ctx, *claims, prevSessionID, meta)
 	if err != nil {
 		return nil, err
 	}
@@ -111,6 +170,96 @@ func (s *JWTService) RefreshSession(
 	return tokenPair, nil
 }
 
-func (s *JWTService) ParseToken(token string) (*auth.TokenClaims, error) {
-	return s.JWT.ParseToken(token)
+// ParseAccessToken parses the access token and rejects
+// denied tokens and tokens of revoked sessions
+func (s *JWTService) ParseAccessToken(ctx context.Context, token string) (*auth.TokenClaims, error) {
+	claims, err := s.JWT.ParseToken(token, auth.TokenTypeAccess)
+	if err != nil {
+		return nil, err
+	}
+
+	reason, err := s.Denylist.Reason(ctx, claims.TokenID)
+	if err != nil {
+		return nil, err
+	}
+
+	if reason != "" {
+		return nil, apperrors.Unauthorized("token is revoked")
+	}
+
+	if err = s.ValidateSession(ctx, claims); err != nil {
+		return nil, err
+	}
+
+	return claims, nil
+}
+
+// ValidateSession rejects tokens of sessions that were revoked or rotated
+func (s *JWTService) ValidateSession(ctx context.Context, claims *auth.TokenClaims) error {
+	exists, err := s.Storage.SessionExists(ctx, claims.UserID, claims.SessionID)
+	if err != nil {
+		return err
+	}
+
+	if !exists {
+		return apperrors.Unauthorized("session is revoked")
+	}
+
+	return nil
+}
+
+func (s *JWTService) GetSessions(ctx context.Context, claims auth.TokenClaims) ([]model.Session, error) {
+	sessions, err := s.Storage.GetUserSessions(ctx, claims.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range sessions {
+		sessions[i].Current = sessions[i].FamilyID == claims.FamilyID
+	}
+
+	return sessions, nil
+}
+
+// RevokeSession ends the session started at sign in with the family id, whatever refresh it is at
+func (s *JWTService) RevokeSession(ctx context.Context, userID, familyID uuid.UUID) error {
+	deleted, err := s.Storage.DeleteSessionFamily(ctx, userID, familyID)
+	if err != nil {
+		return err
+	}
+
+	if !deleted {
+		return apperrors.NotFound("session not found")
+	}
+
+	return nil
+}
+
+// Logout ends the session of the claims and denies its access token right away
+func (s *JWTService) Logout(ctx context.Context, claims auth.TokenClaims) error {
+	err := s.Denylist.Deny(ctx, claims.TokenID, cache.DenyReasonRevoked, claims.ExpiresAt)
+	if err != nil {
+		return err
+	}
+
+	deleted, err := s.Storage.DeleteSessionFamily(ctx, claims.UserID, claims.FamilyID)
+	if err != nil {
+		return err
+	}
+
+	// the session of valid claims is gone only if it was revoked meanwhile
+	if !deleted {
+		return apperrors.Unauthorized("session is revoked")
+	}
+
+	return nil
+}
+
+func (s *JWTService) RevokeAllSessions(ctx context.Context, userID uuid.UUID) error {
+	return s.Storage.DeleteAllUserSessions(ctx, userID)
+}
+
+// JWKS returns the public keys other services verify our tokens with
+func (s *JWTService) JWKS() auth.JWKSet {
+	return s.JWT.JWKS()
 }
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"duels-api/config"
+	"duels-api/internal/model"
+	"duels-api/internal/storage/repository"
+	"duels-api/pkg/apperrors"
+	repo "duels-api/pkg/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+const (
+	defaultLeaderboardPageSize = 50
+	maxLeaderboardPageSize     = 100
+)
+
+type LeaderboardService struct {
+	LeaderboardRepository *repository.LeaderboardRepository
+	TransactionManager    *repo.TransactionManager
+
+	minDuels int
+}
+
+func NewLeaderboardService(
+	c *config.Config,
+	leaderboardRepository *repository.LeaderboardRepository,
+	transactionManager *repo.TransactionManager,
+) *LeaderboardService {
+	return &LeaderboardService{
+		LeaderboardRepository: leaderboardRepository,
+		TransactionManager:    transactionManager,
+		minDuels:              c.App.LeaderboardMinDuels,
+	}
+}
+
+// Refresh recomputes the leaderboards of all periods at once
+func (s *LeaderboardService) Refresh(ctx context.Context) error {
+	now := time.Now().UTC()
+
+	err := s.TransactionManager.WithinTransaction(ctx,
+		func(ctx context.Context, tx bun.Tx) error {
+			locked, err := s.LeaderboardRepository.WithTx(tx).TryLockRefresh(ctx)
+			if err != nil {
+				return err
+			}
+
+			if !locked {
+				// another replica is refreshing the same leaderboards
+				return nil
+			}
+
+			for period, window := range model.LeaderboardPeriods {
+				var since time.Time
+				if window > 0 {
+					since = now.Add(-window)
+				}
+
+				err := s.LeaderboardRepository.WithTx(tx).Refresh(ctx, period, since, s.minDuels, now)
+				if err != nil {
+					return err
+				}
+			}
+
+			return nil
+		})
+	if err != nil {
+		return apperrors.Internal("failed to refresh leaderboards", err)
+	}
+
+	return nil
+}
+
+func (s *LeaderboardService) GetLeaderboard(ctx context.Context, req *model.LeaderboardReq) (*model.Leaderboard, error) {
+	if err := validateLeaderboardReq(req); err != nil {
+		return nil, err
+	}
+
+	if req.PageSize <= 0 {
+		req.PageSize = defaultLeaderboardPageSize
+	}
+	req.PageSize = min(req.PageSize, maxLeaderboardPageSize)
+	req.PageNum = max(req.PageNum, 1)
+
+	entries, err := s.LeaderboardRepository.GetEntries(
+		ctx, req.Period, req.Metric,
+		req.PageSize, (req.PageNum-1)*req.PageSize,
+	)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get leaderboard", err)
+	}
+
+	refreshedAt, err := s.LeaderboardRepository.GetRefreshedAt(ctx, req.Period)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get leaderboard refresh time", err)
+	}
+
+	return &model.Leaderboard{
+		Period:      req.Period,
+		Metric:      req.Metric,
+		Entries:     entries,
+		RefreshedAt: refreshedAt,
+	}, nil
+}
+
+func (s *LeaderboardService) GetUserRank(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *model.LeaderboardReq,
+) (*model.LeaderboardEntry, error) {
+	if err := validateLeaderboardReq(req); err != nil {
+		return nil, err
+	}
+
+	entry, err := s.LeaderboardRepository.GetUserEntry(ctx, req.Period, req.Metric, userID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get user rank", err)
+	}
+
+	if entry == nil {
+		return nil, apperrors.NotFound("user is not ranked by the metric in the period")
+	}
+
+	return entry, nil
+}
+
+// validateLeaderboardReq defaults to the all time net profit leaderboard
+func validateLeaderboardReq(req *model.LeaderboardReq) error {
+	if req.Period == "" {
+		req.Period = model.LeaderboardPeriodAllTime
+	}
+
+	if req.Metric == "" {
+		req.Metric = model.LeaderboardMetricNetProfit
+	}
+
+	if !req.Period.Valid() {
+		return apperrors.BadRequest("invalid leaderboard period")
+	}
+
+	if !req.Metric.Valid() {
+		return apperrors.BadRequest("invalid leaderboard metric")
+	}
+
+	return nil
+}
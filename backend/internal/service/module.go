@@ -18,6 +18,12 @@ func Module() fx.Option {
 			NewWalletService,
 			NewPriorityTracker,
 			NewNotificationService,
+			NewSignatureService,
+			NewAPIKeyService,
+			NewLeaderboardService,
+			NewAchievementService,
+			NewSeasonService,
+			NewCommentService,
 		),
 		fx.Provide(
 			func(lc fx.Lifecycle, client *rpc.Client, cfg *config.Config) *sigtracker.TxTracker {
@@ -36,5 +42,14 @@ func Module() fx.Option {
 				return tracker
 			},
 		),
+		// handlers are registered by domain services, so they must be constructed before resuming.
+		// Signatures left by instances that went away are resumed by the signature cron
+		fx.Invoke(func(lc fx.Lifecycle, signatureService *SignatureService, _ *DuelService) {
+			lc.Append(fx.Hook{
+				OnStart: func(ctx context.Context) error {
+					return signatureService.Resume(ctx)
+				},
+			})
+		}),
 	)
 }
@@ -8,7 +8,6 @@ import (
 	"duels-api/pkg/apperrors"
 	repo "duels-api/pkg/repository"
 	"github.com/google/uuid"
-	"github.com/redis/go-redis/v9"
 	"time"
 )
 
@@ -17,7 +16,6 @@ type NotificationService struct {
 	NotificationRepository *repository.NotificationRepository
 	UserRepository         *repository.UserRepository
 	PlayerRepository       *repository.PlayerRepository
-	c                      *redis.Client
 	Events                 *cache.EventPubSub
 }
 
@@ -26,7 +24,6 @@ func NewNotificationService(
 	notificationRepository *repository.NotificationRepository,
 	userRepository *repository.UserRepository,
 	playerRepository *repository.PlayerRepository,
-	redisClient *redis.Client,
 	notifications *cache.EventPubSub,
 ) (*NotificationService, error) {
 
@@ -35,7 +32,6 @@ func NewNotificationService(
 		NotificationRepository: notificationRepository,
 		UserRepository:         userRepository,
 		PlayerRepository:       playerRepository,
-		c:                      redisClient,
 		Events:                 notifications,
 	}
 
@@ -105,9 +101,8 @@ func (s *NotificationService) Publish(
 		return apperrors.Internal("failed to create notification", err)
 	}
 
-	err = s.c.Publish(ctx, s.Events.UserEventKey(notification.UserID), notification).Err()
-	if err != nil {
-		return apperrors.Internal("failed to publish notification", err)
+	if err = s.Events.Publish(ctx, notification.UserID, notification); err != nil {
+		return err
 	}
 
 	return nil
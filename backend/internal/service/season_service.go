@@ -0,0 +1,440 @@
+package service
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/internal/storage/repository"
+	"duels-api/pkg/apperrors"
+	repo "duels-api/pkg/repository"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+	"go.uber.org/zap"
+)
+
+const (
+	defaultSeasonPageSize = 50
+	maxSeasonPageSize     = 100
+	// seasonPrizeClaimLease bounds how long a payout may hold a prize before storing its transfer
+	seasonPrizeClaimLease = 5 * time.Minute
+)
+
+type SeasonService struct {
+	SeasonRepository    *repository.SeasonRepository
+	CreditRepository    *repository.DuelEventCreditRepository
+	TxRepository        *repository.TransactionRepository
+	WalletService       *WalletService
+	NotificationService *NotificationService
+	TransactionManager  *repo.TransactionManager
+}
+
+func NewSeasonService(
+	seasonRepository *repository.SeasonRepository,
+	creditRepository *repository.DuelEventCreditRepository,
+	txRepository *repository.TransactionRepository,
+	walletService *WalletService,
+	notificationService *NotificationService,
+	transactionManager *repo.TransactionManager,
+) *SeasonService {
+	return &SeasonService{
+		SeasonRepository:    seasonRepository,
+		CreditRepository:    creditRepository,
+		TxRepository:        txRepository,
+		WalletService:       walletService,
+		NotificationService: notificationService,
+		TransactionManager:  transactionManager,
+	}
+}
+
+// Handle awards the season points of the event if a season is running
+func (s *SeasonService) Handle(ctx context.Context, event *model.DuelEvent) error {
+	points := model.DuelEventSeasonPoints[event.Type]
+	if points == 0 {
+		return nil
+	}
+
+	season, err := s.SeasonRepository.GetRunning(ctx, time.Now().UTC())
+	if err != nil {
+		return apperrors.Internal("failed to get running season", err)
+	}
+
+	if season == nil {
+		return nil
+	}
+
+	var participated, wins uint64
+	switch event.Type {
+	case model.DuelEventJoined:
+		participated = 1
+	case model.DuelEventWon:
+		wins = 1
+	}
+
+	// points of an event are credited once, however many times the event is handled
+	return s.TransactionManager.WithinTransaction(ctx,
+		func(ctx context.Context, tx bun.Tx) error {
+			credited, err := s.CreditRepository.WithTx(tx).Credit(ctx, model.NewDuelEventCredit(event, model.DuelEventRewardSeason))
+			if err != nil {
+				return apperrors.Internal("failed to credit duel event", err)
+			}
+
+			if !credited {
+				return nil
+			}
+
+			err = s.SeasonRepository.WithTx(tx).AddPoints(ctx, season.ID, event.UserID, points, participated, wins)
+			if err != nil {
+				return apperrors.Internal("failed to add season points", err)
+			}
+
+			return nil
+		})
+}
+
+func (s *SeasonService) Create(ctx context.Context, req *model.CreateSeasonReq) (*model.Season, error) {
+	if !req.Valid() {
+		return nil, apperrors.BadRequest("invalid season params")
+	}
+
+	season := model.NewSeason(req)
+
+	err := s.TransactionManager.WithinTransaction(ctx,
+		func(ctx context.Context, tx bun.Tx) error {
+			overlaps, err := s.SeasonRepository.WithTx(tx).Overlaps(ctx, season.StartsAt, season.EndsAt)
+			if err != nil {
+				return apperrors.Internal("failed to check season overlap", err)
+			}
+
+			if overlaps {
+				return apperrors.AlreadyExist("season overlaps with another season")
+			}
+
+			if err = s.SeasonRepository.WithTx(tx).Create(ctx, season); err != nil {
+				return apperrors.Internal("failed to create season", err)
+			}
+
+			return nil
+		})
+	if err != nil {
+		return nil, err
+	}
+
+	return season, nil
+}
+
+func (s *SeasonService) GetCurrent(ctx context.Context) (*model.Season, error) {
+	season, err := s.SeasonRepository.GetRunning(ctx, time.Now().UTC())
+	if err != nil {
+		return nil, apperrors.Internal("failed to get current season", err)
+	}
+
+	if season == nil {
+		return nil, apperrors.NotFound("no season is running")
+	}
+
+	return season, nil
+}
+
+func (s *SeasonService) GetPastSeasons(ctx context.Context, req *model.SeasonPageReq) ([]model.Season, error) {
+	limit, offset := seasonPage(req)
+
+	seasons, err := s.SeasonRepository.GetPast(ctx, time.Now().UTC(), limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get past seasons", err)
+	}
+
+	return seasons, nil
+}
+
+func (s *SeasonService) GetStandings(
+	ctx context.Context,
+	seasonID uuid.UUID,
+	req *model.SeasonPageReq,
+) (*model.SeasonStandings, error) {
+	season, err := s.getSeason(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	limit, offset := seasonPage(req)
+
+	standings, err := s.SeasonRepository.GetStandings(ctx, seasonID, limit, offset)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get season standings", err)
+	}
+
+	return &model.SeasonStandings{
+		Season:    season,
+		Standings: standings,
+	}, nil
+}
+
+// GetResults returns the prizes of an ended season, they are empty until settlement starts
+func (s *SeasonService) GetResults(ctx context.Context, seasonID uuid.UUID) (*model.SeasonResults, error) {
+	season, err := s.getSeason(ctx, seasonID)
+	if err != nil {
+		return nil, err
+	}
+
+	if season.EndsAt.After(time.Now().UTC()) {
+		return nil, apperrors.BadRequest("season has not ended yet")
+	}
+
+	prizes, err := s.SeasonRepository.GetPrizes(ctx, seasonID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get season prizes", err)
+	}
+
+	return &model.SeasonResults{
+		Season: season,
+		Prizes: prizes,
+	}, nil
+}
+
+// Settle pays out the prizes of ended seasons. Prizes are computed once from the final
+// standings, then every unpaid prize is claimed and transferred. A prize is paid once its
+// transfer is confirmed, transfers with an unknown outcome are reconciled by the next run
+// and the season is settled when all its prizes are paid
+func (s *SeasonService) Settle(ctx context.Context) error {
+	seasons, err := s.SeasonRepository.GetUnsettled(ctx, time.Now().UTC())
+	if err != nil {
+		return apperrors.Internal("failed to get unsettled seasons", err)
+	}
+
+	for i := range seasons {
+		if err = s.settleSeason(ctx, &seasons[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *SeasonService) settleSeason(ctx context.Context, season *model.Season) error {
+	if season.Status == model.SeasonStatusActive {
+		if err := s.computePrizes(ctx, season); err != nil {
+			return err
+		}
+	}
+
+	prizes, err := s.SeasonRepository.GetUnpaidPrizes(ctx, season.ID)
+	if err != nil {
+		return apperrors.Internal("failed to get unpaid season prizes", err)
+	}
+
+	var unpaid int
+	for i := range prizes {
+		paid, err := s.payPrize(ctx, season, &prizes[i])
+		if err != nil {
+			zap.L().Error("failed to pay season prize",
+				zap.String("season_id", season.ID.String()),
+				zap.String("user_id", prizes[i].UserID.String()),
+				zap.Error(err),
+			)
+		}
+		if !paid {
+			unpaid++
+		}
+	}
+
+	if unpaid > 0 {
+		return nil
+	}
+
+	settledAt := time.Now().UTC()
+	if err = s.SeasonRepository.UpdateStatus(ctx, season.ID, model.SeasonStatusSettled, &settledAt); err != nil {
+		return apperrors.Internal("failed to mark season settled", err)
+	}
+
+	return nil
+}
+
+// computePrizes ranks the final standings and moves the season to settling at once,
+// so prizes never change after the first run
+func (s *SeasonService) computePrizes(ctx context.Context, season *model.Season) error {
+	err := s.TransactionManager.WithinTransaction(ctx,
+		func(ctx context.Context, tx bun.Tx) error {
+			// a zero limit would select the whole standings
+			if len(season.PrizeSplit) == 0 {
+				return s.SeasonRepository.WithTx(tx).UpdateStatus(ctx, season.ID, model.SeasonStatusSettling, nil)
+			}
+
+			standings, err := s.SeasonRepository.WithTx(tx).GetStandings(ctx, season.ID, len(season.PrizeSplit), 0)
+			if err != nil {
+				return err
+			}
+
+			prizes := make([]model.SeasonPrize, 0, len(standings))
+			for i := range standings {
+				prize := model.NewSeasonPrize(season, &standings[i])
+				if prize.Amount > 0 {
+					prizes = append(prizes, prize)
+				}
+			}
+
+			if err = s.SeasonRepository.WithTx(tx).CreatePrizes(ctx, prizes); err != nil {
+				return err
+			}
+
+			return s.SeasonRepository.WithTx(tx).UpdateStatus(ctx, season.ID, model.SeasonStatusSettling, nil)
+		})
+	if err != nil {
+		return apperrors.Internal("failed to compute season prizes", err)
+	}
+
+	season.Status = model.SeasonStatusSettling
+
+	return nil
+}
+
+// payPrize reports whether the prize ended up paid. A prize with a stored transfer is never
+// sent again before that transfer is known to have failed
+func (s *SeasonService) payPrize(ctx context.Context, season *model.Season, prize *model.SeasonPrize) (bool, error) {
+	if prize.Status == model.SeasonPrizeStatusSending && prize.TxSignature != nil {
+		outcome, err := s.WalletService.TransferOutcome(ctx, *prize.TxSignature, prize.LastValidBlockHeight)
+		if err != nil {
+			return false, err
+		}
+
+		return s.completePrize(ctx, season, prize, outcome)
+	}
+
+	// held by another payout
+	if !prize.Claimable(time.Now().UTC(), seasonPrizeClaimLease) {
+		return false, nil
+	}
+
+	prize.ClaimedBy = uuid.NewString()
+	claimed, err := s.SeasonRepository.ClaimPrize(ctx, prize, prize.ClaimedBy, seasonPrizeClaimLease)
+	if err != nil {
+		return false, apperrors.Internal("failed to claim season prize", err)
+	}
+	if !claimed {
+		return false, nil
+	}
+
+	amount := uint64(math.Round(prize.Amount * USDCPriceMultiplier))
+
+	transfer, err := s.WalletService.PrepareSeasonPrize(ctx, prize.Wallet, amount)
+	if err != nil {
+		// nothing was sent, the next run sends the prize again
+		if markErr := s.SeasonRepository.MarkPrizeFailed(ctx, prize, err.Error()); markErr != nil {
+			return false, apperrors.Internal("failed to mark season prize failed", markErr)
+		}
+		return false, err
+	}
+
+	stored, err := s.SeasonRepository.SetPrizeTransfer(ctx, prize, transfer.Signature, transfer.LastValidBlockHeight)
+	if err != nil {
+		return false, apperrors.Internal("failed to store season prize transfer", err)
+	}
+	if !stored {
+		return false, nil
+	}
+
+	prize.TxSignature = &transfer.Signature
+
+	outcome, err := s.WalletService.SendPreparedTransfer(ctx, transfer)
+	if err != nil {
+		// the transfer may have been sent, it is reconciled by the next run
+		return false, err
+	}
+
+	return s.completePrize(ctx, season, prize, outcome)
+}
+
+// completePrize applies the outcome of the stored transfer to a sending prize
+func (s *SeasonService) completePrize(
+	ctx context.Context,
+	season *model.Season,
+	prize *model.SeasonPrize,
+	outcome model.TransferOutcome,
+) (bool, error) {
+	signature := *prize.TxSignature
+
+	switch prize.StatusAfter(outcome) {
+	case model.SeasonPrizeStatusPaid:
+	case model.SeasonPrizeStatusFailed:
+		if err := s.SeasonRepository.MarkPrizeFailed(ctx, prize, "transfer failed: "+signature); err != nil {
+			return false, apperrors.Internal("failed to mark season prize failed", err)
+		}
+		return false, nil
+	default:
+		return false, nil
+	}
+
+	var paid bool
+	err := s.TransactionManager.WithinTransaction(ctx,
+		func(ctx context.Context, tx bun.Tx) error {
+			var err error
+			paid, err = s.SeasonRepository.WithTx(tx).MarkPrizePaid(ctx, prize)
+			if err != nil || !paid {
+				return err
+			}
+
+			transaction := model.NewTransaction(model.TransactionTypeSeasonPrize, signature)
+			return s.TxRepository.WithTx(tx).Create(ctx, &transaction)
+		})
+	if err != nil {
+		// the prize stays sending with its signature and is completed by the next run
+		return false, apperrors.Internal("failed to record season prize transfer "+signature, err)
+	}
+
+	if !paid {
+		return false, nil
+	}
+
+	if err = s.notifyPrizePaid(ctx, season, prize, signature); err != nil {
+		zap.L().Error("failed to send season prize notification", zap.Error(err))
+	}
+
+	return true, nil
+}
+
+func (s *SeasonService) notifyPrizePaid(
+	ctx context.Context,
+	season *model.Season,
+	prize *model.SeasonPrize,
+	signature string,
+) error {
+	notification, err := model.NewNotification(
+		prize.UserID,
+		model.NotificationSeasonPrizePaid,
+		&model.SeasonPrizePaidNotification{
+			SeasonID:    season.ID,
+			SeasonName:  season.Name,
+			Rank:        prize.Rank,
+			Amount:      prize.Amount,
+			TxSignature: signature,
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.NotificationService.Publish(ctx, notification)
+}
+
+func (s *SeasonService) getSeason(ctx context.Context, seasonID uuid.UUID) (*model.Season, error) {
+	season, err := s.SeasonRepository.GetByID(ctx, seasonID)
+	if err != nil {
+		if repo.IsErrNoRows(err) {
+			return nil, apperrors.NotFound("season not found")
+		}
+		return nil, apperrors.Internal("failed to get season", err)
+	}
+
+	return season, nil
+}
+
+func seasonPage(req *model.SeasonPageReq) (limit, offset int) {
+	if req.PageSize <= 0 {
+		req.PageSize = defaultSeasonPageSize
+	}
+	req.PageSize = min(req.PageSize, maxSeasonPageSize)
+	req.PageNum = max(req.PageNum, 1)
+
+	return req.PageSize, (req.PageNum - 1) * req.PageSize
+}
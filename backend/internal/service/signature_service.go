@@ -0,0 +1,343 @@
+package service
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/internal/storage/cache"
+	"duels-api/internal/storage/repository"
+	"duels-api/pkg/apperrors"
+	repo "duels-api/pkg/repository"
+	"duels-api/pkg/sigtracker"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gagliardetto/solana-go"
+	"github.com/google/uuid"
+	"go.uber.org/zap"
+)
+
+// SignatureTrackingTimeout covers the lifetime of a blockhash,
+// a transaction that is not found after it will never land
+const SignatureTrackingTimeout = 90 * time.Second
+
+// SignatureClaimLease is how long an instance owns a signature it watches, it outlasts
+// tracking and handling, so another instance resumes it only if the owner went away
+const SignatureClaimLease = 5 * time.Minute
+
+// SignatureHandler reacts to the on-chain outcome of a tracked signature. A server error
+// returned from OnConfirmed leaves the signature pending to be retried, any other error
+// marks it as failed.
+type SignatureHandler struct {
+	OnConfirmed func(ctx context.Context, tracked *model.TrackedSignature) error
+	OnFailed    func(ctx context.Context, tracked *model.TrackedSignature)
+}
+
+type SignatureService struct {
+	Repository *repository.TrackedSignatureRepository
+	Tracker    *sigtracker.TxTracker
+	Events     *cache.EventPubSub
+
+	handlers map[uint8]SignatureHandler
+	// instanceID claims the signatures watched by this instance
+	instanceID string
+
+	// signatures that are being watched by this instance
+	inFlight      map[string]struct{}
+	inFlightMutex sync.Mutex
+}
+
+func NewSignatureService(
+	trackedSignatureRepository *repository.TrackedSignatureRepository,
+	tracker *sigtracker.TxTracker,
+	events *cache.EventPubSub,
+) *SignatureService {
+	return &SignatureService{
+		Repository: trackedSignatureRepository,
+		Tracker:    tracker,
+		Events:     events,
+		handlers:   make(map[uint8]SignatureHandler),
+		instanceID: uuid.NewString(),
+		inFlight:   make(map[string]struct{}),
+	}
+}
+
+// Handle registers the handler for signatures with the given purpose.
+// Handlers must be registered before the service is started.
+func (s *SignatureService) Handle(purpose uint8, handler SignatureHandler) {
+	s.handlers[purpose] = handler
+}
+
+// Resume continues tracking of pending signatures that no live instance claims
+func (s *SignatureService) Resume(ctx context.Context) error {
+	pending, err := s.Repository.GetPending(ctx)
+	if err != nil {
+		return apperrors.Internal("failed to get pending signatures", err)
+	}
+
+	for _, tracked := range pending {
+		s.watch(ctx, tracked)
+	}
+
+	zap.L().Info("pending signatures resumed", zap.Int("count", len(pending)))
+	return nil
+}
+
+// Track persists the signature and starts watching it in background.
+// Tracking the same signature again returns the already stored operation.
+func (s *SignatureService) Track(
+	ctx context.Context,
+	tracked *model.TrackedSignature,
+) (*model.TrackedSignature, error) {
+	if _, err := solana.SignatureFromBase58(tracked.Signature); err != nil {
+		return nil, apperrors.BadRequest("failed to parse tx hash")
+	}
+
+	stored, err := s.Repository.CreateOrGet(ctx, tracked)
+	if err != nil {
+		return nil, apperrors.Internal("failed to store tracked signature", err)
+	}
+
+	if stored.Purpose != tracked.Purpose || stored.UserID != tracked.UserID {
+		return nil, apperrors.BadRequest("transaction is already used by another operation")
+	}
+
+	if stored.ID == tracked.ID {
+		s.publishStage(ctx, stored)
+	}
+
+	s.watch(ctx, stored)
+
+	return stored, nil
+}
+
+// GetUserSignature returns the tracked signature by id if it belongs to the user
+func (s *SignatureService) GetUserSignature(
+	ctx context.Context,
+	userID uuid.UUID,
+	id uuid.UUID,
+) (*model.TrackedSignature, error) {
+	tracked, err := s.Repository.GetUserSignature(ctx, userID, id)
+	if repo.IsErrNoRows(err) {
+		return nil, apperrors.NotFound("operation not found")
+	}
+	if err != nil {
+		return nil, apperrors.Internal("failed to get tracked signature", err)
+	}
+
+	return tracked, nil
+}
+
+// Advance moves the tracked signature to the given stage and reports it to the user
+func (s *SignatureService) Advance(ctx context.Context, tracked *model.TrackedSignature, stage uint8) {
+	tracked.Stage = stage
+
+	if err := s.Repository.UpdateStage(ctx, tracked); err != nil {
+		zap.L().Error("failed to update tracked signature stage",
+			zap.String("signature", tracked.Signature),
+			zap.Error(err),
+		)
+	}
+
+	s.publishStage(ctx, tracked)
+}
+
+// TrackSent starts tracking of transactions sent by the admin wallet
+func (s *SignatureService) TrackSent(
+	ctx context.Context,
+	purpose uint8,
+	duelID uuid.UUID,
+	signatures ...string,
+) {
+	for _, signature := range signatures {
+		if signature == "" {
+			continue
+		}
+
+		tracked, err := model.NewTrackedSignature(signature, purpose, duelID, uuid.Nil, nil)
+		if err == nil {
+			_, err = s.Track(ctx, tracked)
+		}
+		if err != nil {
+			zap.L().Error("failed to track sent transaction",
+				zap.String("signature", signature),
+				zap.String("duel_id", duelID.String()),
+				zap.Error(err),
+			)
+		}
+	}
+}
+
+// watch tracks the signature if this instance claims it, signatures
+// claimed by another instance are completed by it
+func (s *SignatureService) watch(ctx context.Context, tracked *model.TrackedSignature) {
+	if tracked.Status != model.SignatureStatusPending {
+		return
+	}
+
+	s.inFlightMutex.Lock()
+	_, inFlight := s.inFlight[tracked.Signature]
+	s.inFlight[tracked.Signature] = struct{}{}
+	s.inFlightMutex.Unlock()
+
+	if inFlight {
+		return
+	}
+
+	if !s.claim(ctx, tracked) {
+		s.release(tracked.Signature)
+		return
+	}
+
+	signature, err := solana.SignatureFromBase58(tracked.Signature)
+	if err != nil {
+		go s.complete(tracked, sigtracker.Status{State: sigtracker.StateFailed, Err: err.Error()})
+		return
+	}
+
+	s.Tracker.WatchFunc(signature, SignatureTrackingTimeout, func(status sigtracker.Status) {
+		s.complete(tracked, status)
+	})
+}
+
+func (s *SignatureService) complete(tracked *model.TrackedSignature, status sigtracker.Status) {
+	defer s.release(tracked.Signature)
+
+	// the tracker is shutting down, the signature stays pending until the next start
+	if status.State == sigtracker.StateAborted {
+		return
+	}
+
+	ctx := context.Background()
+	handler := s.handlers[tracked.Purpose]
+
+	// the lease is renewed before the handlers run, it fails if another instance took the signature over
+	if !s.claim(ctx, tracked) {
+		return
+	}
+
+	tracked.Slot = status.Slot
+	tracked.Status = model.SignatureStatusConfirmed
+
+	if !status.Confirmed() {
+		tracked.Status = model.SignatureStatusFailed
+		tracked.Error = signatureFailureReason(status)
+	} else {
+		s.Advance(ctx, tracked, model.SignatureStageConfirmed)
+
+		if handler.OnConfirmed != nil {
+			if err := handler.OnConfirmed(ctx, tracked); err != nil {
+				if retryable(err) {
+					s.retry(ctx, tracked, err)
+					return
+				}
+
+				tracked.Status = model.SignatureStatusFailed
+				tracked.Error = errorReason(err)
+			}
+		}
+	}
+
+	tracked.Stage = model.SignatureStageRecorded
+	if tracked.Status == model.SignatureStatusFailed {
+		tracked.Stage = model.SignatureStageFailed
+
+		if handler.OnFailed != nil {
+			handler.OnFailed(ctx, tracked)
+		}
+	}
+
+	updated, err := s.Repository.UpdateStatus(ctx, tracked)
+	if err != nil {
+		zap.L().Error("failed to update tracked signature status",
+			zap.String("signature", tracked.Signature),
+			zap.Error(err),
+		)
+	}
+	if !updated {
+		return
+	}
+
+	s.publishStage(ctx, tracked)
+}
+
+func (s *SignatureService) claim(ctx context.Context, tracked *model.TrackedSignature) bool {
+	claimed, err := s.Repository.Claim(ctx, tracked.ID, s.instanceID, SignatureClaimLease)
+	if err != nil {
+		zap.L().Error("failed to claim tracked signature",
+			zap.String("signature", tracked.Signature),
+			zap.Error(err),
+		)
+		return false
+	}
+
+	return claimed
+}
+
+// retry drops the lease of a signature whose handler failed transiently,
+// the signature cron resumes it
+func (s *SignatureService) retry(ctx context.Context, tracked *model.TrackedSignature, err error) {
+	zap.L().Error("failed to handle confirmed signature, it will be retried",
+		zap.String("signature", tracked.Signature),
+		zap.Error(err),
+	)
+
+	if err = s.Repository.Release(ctx, tracked.ID, s.instanceID); err != nil {
+		zap.L().Error("failed to release tracked signature",
+			zap.String("signature", tracked.Signature),
+			zap.Error(err),
+		)
+	}
+}
+
+func (s *SignatureService) release(signature string) {
+	s.inFlightMutex.Lock()
+	delete(s.inFlight, signature)
+	s.inFlightMutex.Unlock()
+}
+
+// publishStage sends the current stage to the user's event stream,
+// signatures sent by the admin wallet have no user to report to
+func (s *SignatureService) publishStage(ctx context.Context, tracked *model.TrackedSignature) {
+	if tracked.UserID == uuid.Nil {
+		return
+	}
+
+	event := model.NewEventWithPayload(model.EventOperationStage, tracked)
+	if err := s.Events.Publish(ctx, tracked.UserID, event); err != nil {
+		zap.L().Error("failed to publish operation stage",
+			zap.String("signature", tracked.Signature),
+			zap.Error(err),
+		)
+	}
+}
+
+func signatureFailureReason(status sigtracker.Status) string {
+	switch status.State {
+	case sigtracker.StateExpired:
+		return "transaction was not confirmed in time"
+	case sigtracker.StateFailed:
+		if status.Err != nil {
+			return fmt.Sprintf("transaction failed: %v", status.Err)
+		}
+		return "transaction failed"
+	default:
+		return "transaction " + status.State.String()
+	}
+}
+
+// retryable reports whether the error is a server failure that may pass, e.g. a lost
+// connection. Errors that are not app errors are not retried
+func retryable(err error) bool {
+	appErr, ok := apperrors.IsAppError(err)
+	return ok && appErr.Status >= http.StatusInternalServerError
+}
+
+func errorReason(err error) string {
+	if appErr, ok := apperrors.IsAppError(err); ok {
+		return appErr.Message
+	}
+
+	return err.Error()
+}
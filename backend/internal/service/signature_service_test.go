@@ -0,0 +1,30 @@
+package service
+
+import (
+	"duels-api/pkg/apperrors"
+	"errors"
+	"testing"
+)
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"internal", apperrors.Internal("failed to get user", errors.New("connection reset")), true},
+		{"service unavailable", apperrors.ServiceUnavailable("failed to get transaction"), true},
+		{"bad request", apperrors.BadRequest("transaction payer is not the user"), false},
+		{"not found", apperrors.NotFound("duel not found"), false},
+		{"wrapped validation error", apperrors.BadRequest("invalid", apperrors.Forbidden("denied")), false},
+		{"plain error", errors.New("unexpected"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := retryable(tt.err); got != tt.want {
+				t.Errorf("retryable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
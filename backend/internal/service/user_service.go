@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"duels-api/config"
 	"duels-api/internal/model"
 	"duels-api/internal/storage/cache"
 	"duels-api/internal/storage/repository"
@@ -14,93 +15,134 @@ import (
 	"github.com/google/uuid"
 	"github.com/uptrace/bun"
 	"mime/multipart"
+	"time"
 )
 
 type UserService struct {
-	FileService        *FileService
-	UserRepository     *repository.UserRepository
-	DuelRepository     *repository.DuelRepository
-	JWTStorage         *cache.JWTStorage
-	JWTAuth            auth.JWTAuthenticator
-	TransactionManager *repo.TransactionManager
+	FileService           *FileService
+	UserRepository        *repository.UserRepository
+	WalletRepository      *repository.UserWalletRepository
+	DuelRepository        *repository.DuelRepository
+	JWTStorage            *cache.JWTStorage
+	JWTAuth               auth.JWTAuthenticator
+	TransactionManager    *repo.TransactionManager
+	ChallengeStorage      *cache.SignInChallengeStorage
+	FollowRepository      *repository.FollowRepository
+	AchievementRepository *repository.AchievementRepository
+
+	signInDomain       string
+	signInChallengeTTL time.Duration
 }
 
 func NewUserService(
+	c *config.Config,
 	fileService *FileService,
 	userRepository *repository.UserRepository,
+	walletRepository *repository.UserWalletRepository,
 	duelRepository *repository.DuelRepository,
 	jwtStorage *cache.JWTStorage,
 	jwtAuth auth.JWTAuthenticator,
 	transactionManager *repo.TransactionManager,
+	challengeStorage *cache.SignInChallengeStorage,
+	followRepository *repository.FollowRepository,
+	achievementRepository *repository.AchievementRepository,
 ) *UserService {
 	return &UserService{
-		UserRepository:     userRepository,
-		DuelRepository:     duelRepository,
-		JWTStorage:         jwtStorage,
-		JWTAuth:            jwtAuth,
-		TransactionManager: transactionManager,
-		FileService:        fileService,
+		UserRepository:        userRepository,
+		WalletRepository:      walletRepository,
+		DuelRepository:        duelRepository,
+		JWTStorage:            jwtStorage,
+		JWTAuth:               jwtAuth,
+		TransactionManager:    transactionManager,
+		FileService:           fileService,
+		ChallengeStorage:      challengeStorage,
+		FollowRepository:      followRepository,
+		AchievementRepository: achievementRepository,
+		signInDomain:          c.Auth.SignInDomain,
+		signInChallengeTTL:    c.Auth.SignInChallengeTTL,
 	}
 }
 
-func (s *UserService) SignInWithWallet(
+// IssueSignInChallenge creates a single-use message the wallet has to sign to sign in
+func (s *UserService) IssueSignInChallenge(
 	ctx context.Context,
-	authWallet model.AuthWithWallet,
-) (*model.User, error) {
-	user, err := s.GetByPublicAddress(ctx, authWallet)
+	address string,
+) (*model.SignInChallenge, error) {
+	if _, err := solana.PublicKeyFromBase58(address); err != nil {
+		return nil, apperrors.BadRequest("invalid solana address", err)
+	}
+
+	challenge, err := model.NewSignInChallenge(s.signInDomain, address, s.signInChallengeTTL)
 	if err != nil {
 		return nil, err
 	}
 
-	if user == nil {
-		user, err = s.CreateWithWallet(ctx, authWallet)
-		if err != nil {
-			return nil, err
-		}
+	if err = s.ChallengeStorage.Save(ctx, challenge); err != nil {
+		return nil, err
 	}
 
-	return user, nil
+	return challenge, nil
 }
 
-func (s *UserService) GetByPublicAddress(
+func (s *UserService) SignInWithWallet(
 	ctx context.Context,
 	authWallet model.AuthWithWallet,
 ) (*model.User, error) {
-	ok, err := s.VerifySecret(authWallet)
-	if err != nil || !ok {
+	if err := s.VerifySignInChallenge(ctx, authWallet); err != nil {
 		return nil, err
 	}
 
 	user, err := s.UserRepository.GetByPublicAddress(ctx, authWallet.Address)
 	if err != nil {
-		return nil, apperrors.NotFound("user with public address not found", err)
+		return nil, apperrors.Internal("failed to get user by public address", err)
+	}
+
+	if user == nil {
+		user, err = s.CreateWithWallet(ctx, authWallet)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	return user, nil
 }
 
-func (s *UserService) VerifySecret(
+// VerifySignInChallenge consumes the issued challenge and checks
+// that the wallet signed exactly its message
+func (s *UserService) VerifySignInChallenge(
+	ctx context.Context,
 	authWallet model.AuthWithWallet,
-) (bool, error) {
+) error {
 	publicKey, err := solana.PublicKeyFromBase58(authWallet.Address)
 	if err != nil {
-		return false, apperrors.BadRequest("invalid solana address", err)
+		return apperrors.BadRequest("invalid solana address", err)
 	}
 
 	signatureBytes, err := base64.StdEncoding.DecodeString(authWallet.Secret)
+	if err != nil || len(signatureBytes) != solana.SignatureLength {
+		return apperrors.BadRequest("invalid signature format", err)
+	}
+
+	challenge, err := s.ChallengeStorage.Consume(ctx, authWallet.Address, authWallet.Nonce)
 	if err != nil {
-		return false, apperrors.BadRequest("invalid signature format", err)
+		return err
+	}
+
+	if challenge.Expired() {
+		return apperrors.Unauthorized("sign in challenge expired")
+	}
+
+	if challenge.Domain != s.signInDomain {
+		return apperrors.Unauthorized("sign in challenge issued for another domain")
 	}
 
-	var signature solana.Signature
-	copy(signature[:], signatureBytes)
+	signature := solana.SignatureFromBytes(signatureBytes)
 
-	verified := signature.Verify(publicKey, []byte(authWallet.Address))
-	if !verified {
-		return false, apperrors.BadRequest("invalid signature")
+	if !signature.Verify(publicKey, []byte(challenge.Message())) {
+		return apperrors.Unauthorized("invalid signature")
 	}
 
-	return true, nil
+	return nil
 }
 
 func (s *UserService) CreateWithWallet(
@@ -120,6 +162,11 @@ func (s *UserService) CreateWithWallet(
 			if err := s.UserRepository.WithTx(tx).Create(ctx, user); err != nil {
 				return apperrors.Internal("failed to create user", err)
 			}
+
+			wallet := model.NewUserWallet(user.ID, user.PublicAddress, true)
+			if err := s.WalletRepository.WithTx(tx).Create(ctx, wallet); err != nil {
+				return apperrors.Internal("failed to create user wallet", err)
+			}
 			return nil
 		})
 	if err != nil {
@@ -161,12 +208,12 @@ func (s *UserService) UpdateProfilePicture(
 	}
 
 	if user.ImageUrl != "" && !s.FileService.IsDefaultUserIcon(user.ImageUrl) {
-		if err = s.FileService.RemoveUserFile(user.ImageUrl); err != nil {
+		if err = s.FileService.RemoveUserFile(ctx, user.ImageUrl); err != nil {
 			return "", apperrors.Internal("failed to remove previous profile image", err)
 		}
 	}
 
-	newURL, err := s.FileService.SaveUserFile(file)
+	newURL, err := s.FileService.SaveUserFile(ctx, userID, file)
 	if err != nil {
 		return "", err
 	}
@@ -176,6 +223,10 @@ func (s *UserService) UpdateProfilePicture(
 		return "", apperrors.Internal("failed to update user profile picture", err)
 	}
 
+	if err = s.FileService.BindUploads(ctx, newURL); err != nil {
+		return "", err
+	}
+
 	return newURL, nil
 }
 
@@ -187,6 +238,10 @@ func (s *UserService) ChangeUsername(
 	if !username.Valid() {
 		return apperrors.BadRequest("invalid username")
 	}
+	// public profiles are looked up by id or username, they must not be confused
+	if _, err := uuid.Parse(username.String()); err == nil {
+		return apperrors.BadRequest("invalid username")
+	}
 	user := &model.User{ID: userID, Username: username}
 
 	err := s.UserRepository.Update(ctx, user)
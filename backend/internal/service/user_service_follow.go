@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/apperrors"
+	repo "duels-api/pkg/repository"
+
+	"github.com/google/uuid"
+)
+
+func (s *UserService) Follow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	if followerID == followeeID {
+		return apperrors.BadRequest("users cannot follow themselves")
+	}
+
+	if _, err := s.UserRepository.GetByID(ctx, followeeID); err != nil {
+		if repo.IsErrNoRows(err) {
+			return apperrors.NotFound("user not found")
+		}
+		return apperrors.Internal("failed to get user by id", err)
+	}
+
+	if err := s.FollowRepository.Follow(ctx, model.NewFollow(followerID, followeeID)); err != nil {
+		return apperrors.Internal("failed to follow user", err)
+	}
+
+	return nil
+}
+
+func (s *UserService) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) error {
+	unfollowed, err := s.FollowRepository.Unfollow(ctx, followerID, followeeID)
+	if err != nil {
+		return apperrors.Internal("failed to unfollow user", err)
+	}
+
+	if !unfollowed {
+		return apperrors.NotFound("user is not followed")
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package service
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/apperrors"
+	repo "duels-api/pkg/repository"
+
+	"github.com/google/uuid"
+)
+
+// publicProfileDuels is how many created duels and results the public profile shows
+const publicProfileDuels = 10
+
+func (s *UserService) UpdateProfile(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *model.UpdateProfileReq,
+) error {
+	if !req.Valid() {
+		return apperrors.BadRequest("bio must be up to 280 characters and social links must be http(s) urls")
+	}
+
+	if err := s.UserRepository.UpdateProfile(ctx, userID, req); err != nil {
+		return apperrors.Internal("failed to update user profile", err)
+	}
+
+	return nil
+}
+
+func (s *UserService) UpdatePrivacy(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *model.UpdatePrivacyReq,
+) error {
+	if err := s.UserRepository.UpdatePrivacy(ctx, userID, req); err != nil {
+		return apperrors.Internal("failed to update user privacy settings", err)
+	}
+
+	return nil
+}
+
+func (s *UserService) GetPublicProfileByID(ctx context.Context, userID uuid.UUID) (*model.PublicProfile, error) {
+	user, err := s.UserRepository.GetByID(ctx, userID)
+	if err != nil {
+		if repo.IsErrNoRows(err) {
+			return nil, apperrors.NotFound("user not found")
+		}
+		return nil, apperrors.Internal("failed to get user by id", err)
+	}
+
+	return s.getPublicProfile(ctx, user)
+}
+
+func (s *UserService) GetPublicProfileByUsername(ctx context.Context, username string) (*model.PublicProfile, error) {
+	user, err := s.UserRepository.GetByUsername(ctx, username)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get user by username", err)
+	}
+
+	if user == nil {
+		return nil, apperrors.NotFound("user not found")
+	}
+
+	return s.getPublicProfile(ctx, user)
+}
+
+// getPublicProfile collects the profile, duels are shown from the perspective of the profile owner
+func (s *UserService) getPublicProfile(ctx context.Context, user *model.User) (*model.PublicProfile, error) {
+	profile := &model.PublicProfile{
+		User:          model.NewPublicUser(user),
+		Progress:      model.NewUserProgress(user.XP),
+		StatsHidden:   user.HideStats,
+		HistoryHidden: user.HideHistory,
+	}
+
+	followCounts, err := s.FollowRepository.GetCounts(ctx, user.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get follow counts", err)
+	}
+	profile.FollowCounts = *followCounts
+
+	profile.Achievements, err = s.AchievementRepository.GetUserAchievements(ctx, user.ID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get user achievements", err)
+	}
+
+	// the owner's answers are not public, the duels are read as by an anonymous viewer
+	createdDuels, err := s.DuelRepository.GetUserDuels(ctx, user.ID, uuid.Nil, latestDuelsOptions())
+	if err != nil {
+		return nil, apperrors.Internal("failed to get created duels", err)
+	}
+	profile.CreatedDuels = createdDuels
+
+	if !user.HideStats {
+		profile.Stats, err = s.DuelRepository.GetUserStats(ctx, user.ID)
+		if err != nil {
+			return nil, apperrors.Internal("failed to get user stats", err)
+		}
+	}
+
+	if !user.HideHistory {
+		profile.RecentResults, err = s.DuelRepository.GetHistoryByUserID(ctx, user.ID, latestDuelsOptions())
+		if err != nil {
+			return nil, apperrors.Internal("failed to get recent results", err)
+		}
+	}
+
+	return profile, nil
+}
+
+func latestDuelsOptions() *repo.Options {
+	return &repo.Options{
+		Pagination: repo.Pagination{PageSize: publicProfileDuels, PageNum: 1},
+		Order:      repo.Order{OrderBy: "duels.updated_at", OrderType: repo.OrderDesc},
+	}
+}
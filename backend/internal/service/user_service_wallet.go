@@ -0,0 +1,112 @@
+package service
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/apperrors"
+	repo "duels-api/pkg/repository"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+func (s *UserService) GetWallets(ctx context.Context, userID uuid.UUID) ([]model.UserWallet, error) {
+	wallets, err := s.WalletRepository.GetUserWallets(ctx, userID)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get user wallets", err)
+	}
+
+	return wallets, nil
+}
+
+// LinkWallet links another wallet to the user,
+// the ownership is proven by signing a challenge issued for the wallet
+func (s *UserService) LinkWallet(
+	ctx context.Context,
+	userID uuid.UUID,
+	authWallet model.AuthWithWallet,
+) (*model.UserWallet, error) {
+	if err := s.VerifySignInChallenge(ctx, authWallet); err != nil {
+		return nil, err
+	}
+
+	owner, err := s.UserRepository.GetByPublicAddress(ctx, authWallet.Address)
+	if err != nil {
+		return nil, apperrors.Internal("failed to get user by public address", err)
+	}
+
+	if owner != nil {
+		if owner.ID == userID {
+			return nil, apperrors.AlreadyExist("wallet is already linked")
+		}
+
+		return nil, apperrors.AlreadyExist("wallet is linked to another account")
+	}
+
+	wallet := model.NewUserWallet(userID, authWallet.Address, false)
+	if err = s.WalletRepository.Create(ctx, wallet); err != nil {
+		if repo.DuplicateKeyViolation(err) {
+			return nil, apperrors.AlreadyExist("wallet is linked to another account")
+		}
+
+		return nil, apperrors.Internal("failed to link wallet", err)
+	}
+
+	return wallet, nil
+}
+
+func (s *UserService) UnlinkWallet(ctx context.Context, userID uuid.UUID, address string) error {
+	wallet, err := s.getUserWallet(ctx, userID, address)
+	if err != nil {
+		return err
+	}
+
+	if wallet.IsPrimary {
+		return apperrors.BadRequest("primary wallet can not be unlinked, switch the primary wallet first")
+	}
+
+	if err = s.WalletRepository.Delete(ctx, wallet.ID); err != nil {
+		return apperrors.Internal("failed to unlink wallet", err)
+	}
+
+	return nil
+}
+
+// SetPrimaryWallet switches the primary wallet, it is used to sign in
+// and as the default payer and payout address
+func (s *UserService) SetPrimaryWallet(ctx context.Context, userID uuid.UUID, address string) error {
+	wallet, err := s.getUserWallet(ctx, userID, address)
+	if err != nil {
+		return err
+	}
+
+	if wallet.IsPrimary {
+		return nil
+	}
+
+	return s.TransactionManager.WithinTransaction(ctx,
+		func(ctx context.Context, tx bun.Tx) error {
+			if err := s.WalletRepository.WithTx(tx).SetPrimary(ctx, userID, address); err != nil {
+				return apperrors.Internal("failed to set primary wallet", err)
+			}
+
+			if err := s.UserRepository.WithTx(tx).UpdatePublicAddress(ctx, userID, address); err != nil {
+				return apperrors.Internal("failed to update user public address", err)
+			}
+
+			return nil
+		})
+}
+
+func (s *UserService) getUserWallet(ctx context.Context, userID uuid.UUID, address string) (*model.UserWallet, error) {
+	wallet, err := s.WalletRepository.GetUserWallet(ctx, userID, address)
+	if err != nil {
+		if repo.IsErrNoRows(err) {
+			return nil, apperrors.NotFound("wallet is not linked to the user")
+		}
+
+		return nil, apperrors.Internal("failed to get user wallet", err)
+	}
+
+	return wallet, nil
+}
@@ -90,41 +90,37 @@ func NewWalletService(
 func (s *WalletService) validateCreateCryptoDuelSCTransaction(
 	ctx context.Context,
 	duel *model.CreateDuelReq,
-) (uint64, error) {
+) (uint64, string, error) {
 	sig, err := solana.SignatureFromBase58(duel.Hash)
 	if err != nil {
-		return 0, apperrors.BadRequest("failed to parse tx hash")
-	}
-
-	sent, err := s.SigTracker.SubscribeForSignatureStatus(sig, TxConfirmationTimeout)
-	if err != nil && !errors.Is(err, rpc.ErrNotConfirmed) {
-		return 0, apperrors.Internal("subscribe to signature status", err)
-	}
-
-	if !sent {
-		return 0, apperrors.Internal("tx was not confirmed: "+sig.String(), nil)
+		return 0, "", apperrors.BadRequest("failed to parse tx hash")
 	}
 
 	txInfo, err := s.SolanaRPC.GetTransaction(ctx, sig, &rpc.GetTransactionOpts{
 		Commitment: rpc.CommitmentConfirmed,
 	})
 	if err != nil {
-		return 0, apperrors.Internal("get transaction info", err)
+		return 0, "", apperrors.Internal("get transaction info", err)
+	}
+
+	payer, err := transactionPayer(txInfo)
+	if err != nil {
+		return 0, "", err
 	}
 
 	logs := strings.Join(txInfo.Meta.LogMessages, ", ")
 
 	if !strings.Contains(logs, "Instruction: Init") {
-		return 0, apperrors.BadRequest("invalid instruction")
+		return 0, "", apperrors.BadRequest("invalid instruction")
 	}
 	if !strings.Contains(logs, fmt.Sprintf("Description: %s", duel.Question)) {
-		return 0, apperrors.BadRequest("invalid description")
+		return 0, "", apperrors.BadRequest("invalid description")
 	}
 	if !strings.Contains(logs, fmt.Sprintf("Bet: %d USDC", int64(duel.DuelPrice))) {
-		return 0, apperrors.BadRequest("invalid bet")
+		return 0, "", apperrors.BadRequest("invalid bet")
 	}
 	if !strings.Contains(logs, fmt.Sprintf("Current executing program address: %s", s.contractAddress)) {
-		return 0, apperrors.BadRequest("invalid program address")
+		return 0, "", apperrors.BadRequest("invalid program address")
 	}
 
 	var roomNumber uint64
@@ -137,16 +133,16 @@ func (s *WalletService) validateCreateCryptoDuelSCTransaction(
 		err = apperrors.BadRequest("room number not found")
 	}
 	if err != nil {
-		return 0, err
+		return 0, "", err
 	}
 
-	return roomNumber, nil
+	return roomNumber, payer, nil
 }
 
 func (s *WalletService) InitAndJoinSolanaRoomWithExternalWallet(
 	ctx context.Context,
 	duel *model.Duel,
-	user *model.User,
+	payer string,
 	answer uint8,
 ) (string, error) {
 	duelPrice := duel.DuelPrice * USDCPriceMultiplier
@@ -165,7 +161,7 @@ func (s *WalletService) InitAndJoinSolanaRoomWithExternalWallet(
 		return "", err
 	}
 
-	publicKey, err := solana.PublicKeyFromBase58(user.PublicAddress)
+	publicKey, err := solana.PublicKeyFromBase58(payer)
 	if err != nil {
 		return "", apperrors.Internal("failed to parse user's public key", err)
 	}
@@ -234,8 +230,8 @@ func (s *WalletService) InitAndJoinSolanaRoomWithExternalWallet(
 	return encodedTx, nil
 }
 
-func (s *WalletService) JoinSolanaRoomWithExternalWallet(ctx context.Context, duel *model.Duel, user *model.User, answer uint8) (string, error) {
-	publicKey, err := solana.PublicKeyFromBase58(user.PublicAddress)
+func (s *WalletService) JoinSolanaRoomWithExternalWallet(ctx context.Context, duel *model.Duel, payer string, answer uint8) (string, error) {
+	publicKey, err := solana.PublicKeyFromBase58(payer)
 	if err != nil {
 		return "", apperrors.Internal("failed to parse user's public key", err)
 	}
@@ -309,38 +305,52 @@ func (s *WalletService) JoinSolanaRoomWithExternalWallet(ctx context.Context, du
 func (s *WalletService) validateJoinCryptoDuelSCTransaction(
 	ctx context.Context,
 	txHash string,
-) error {
+) (string, error) {
 	sig, err := solana.SignatureFromBase58(txHash)
 	if err != nil {
-		return apperrors.BadRequest("failed to parse tx hash")
-	}
-
-	sent, err := s.SigTracker.SubscribeForSignatureStatus(sig, TxConfirmationTimeout)
-	if err != nil && !errors.Is(err, rpc.ErrNotConfirmed) {
-		return apperrors.Internal("subscribe to signature status", err)
-	}
-
-	if !sent {
-		return apperrors.Internal("tx was not confirmed: " + sig.String())
+		return "", apperrors.BadRequest("failed to parse tx hash")
 	}
 
 	tx, err := s.SolanaRPC.GetTransaction(ctx, sig, &rpc.GetTransactionOpts{
 		Commitment: rpc.CommitmentConfirmed,
 	})
 	if err != nil {
-		return apperrors.Internal("failed to get transaction by tx hash", err)
+		return "", apperrors.Internal("failed to get transaction by tx hash", err)
+	}
+
+	payer, err := transactionPayer(tx)
+	if err != nil {
+		return "", err
 	}
 
 	logs := strings.Join(tx.Meta.LogMessages, ", ")
 
 	if !strings.Contains(logs, "Instruction: Join") {
-		return apperrors.BadRequest("invalid instruction")
+		return "", apperrors.BadRequest("invalid instruction")
 	}
 	if !strings.Contains(logs, fmt.Sprintf("Current executing program address: %s", s.contractAddress)) {
-		return apperrors.BadRequest("invalid program address")
+		return "", apperrors.BadRequest("invalid program address")
 	}
 
-	return nil
+	return payer, nil
+}
+
+// transactionPayer returns the fee payer, the wallet that signed and paid for the transaction
+func transactionPayer(txInfo *rpc.GetTransactionResult) (string, error) {
+	if txInfo == nil || txInfo.Transaction == nil {
+		return "", apperrors.Internal("transaction not found")
+	}
+
+	tx, err := txInfo.Transaction.GetTransaction()
+	if err != nil {
+		return "", apperrors.Internal("failed to decode transaction", err)
+	}
+
+	if len(tx.Message.AccountKeys) == 0 {
+		return "", apperrors.BadRequest("transaction has no fee payer")
+	}
+
+	return tx.Message.AccountKeys[0].String(), nil
 }
 
 func (s *WalletService) TransferBulkSolanaChain(
@@ -546,73 +556,227 @@ func (s *WalletService) RewardDuelOwnerWithCommission(
 	commissionReward uint64,
 	mint solana.PublicKey,
 ) (string, error) {
-	if commissionReward == 0 {
+	return s.TransferToken(ctx, publicAddress, commissionReward, mint)
+}
+
+// PreparedTransfer is a transfer signed by the admin wallet and not sent yet,
+// so its signature can be stored before it may land
+type PreparedTransfer struct {
+	Tx                   *solana.Transaction
+	Signature            string
+	LastValidBlockHeight uint64
+}
+
+// PrepareSeasonPrize signs the USDC transfer of the season prize without sending it
+func (s *WalletService) PrepareSeasonPrize(
+	ctx context.Context,
+	publicAddress string,
+	prize uint64,
+) (*PreparedTransfer, error) {
+	instructions, err := s.tokenTransferInstructions(ctx, publicAddress, prize, s.usdcMintAddress)
+	if err != nil {
+		return nil, err
+	}
+
+	tx, err := s.newBudgetedTransaction(ctx, instructions)
+	if err != nil {
+		return nil, err
+	}
+
+	recentBlockHashResp, err := s.SolanaRPC.GetLatestBlockhash(ctx, Finalized)
+	if err != nil {
+		return nil, apperrors.ServiceUnavailable("failed to get latest block hash", err)
+	}
+
+	tx.Message.RecentBlockhash = recentBlockHashResp.Value.Blockhash
+	if _, err = tx.Sign(txSignerPrivateKeyGetter(s.solanaAdminPrivateKey)); err != nil {
+		return nil, apperrors.Internal("failed to sign transaction", err)
+	}
+
+	return &PreparedTransfer{
+		Tx:                   tx,
+		Signature:            tx.Signatures[0].String(),
+		LastValidBlockHeight: recentBlockHashResp.Value.LastValidBlockHeight,
+	}, nil
+}
+
+// SendPreparedTransfer sends the transfer and waits for its outcome. A send error
+// leaves the transfer pending, it may have reached the cluster anyway
+func (s *WalletService) SendPreparedTransfer(
+	ctx context.Context,
+	transfer *PreparedTransfer,
+) (model.TransferOutcome, error) {
+	sig, err := s.submitTransaction(ctx, transfer.Tx)
+	if err != nil {
+		return model.TransferPending, err
+	}
+
+	select {
+	case status := <-s.SigTracker.Watch(sig, TxConfirmationTimeout):
+		switch status.State {
+		case sigtracker.StateConfirmed:
+			return model.TransferConfirmed, nil
+		case sigtracker.StateFailed:
+			return model.TransferFailed, nil
+		}
+	case <-ctx.Done():
+		return model.TransferPending, apperrors.Internal("tx confirmation was interrupted: "+sig.String(), ctx.Err())
+	}
+
+	return s.TransferOutcome(ctx, transfer.Signature, transfer.LastValidBlockHeight)
+}
+
+// TransferOutcome looks up a sent transfer. A transfer that is not found once the
+// chain passed its last valid block height can never land
+func (s *WalletService) TransferOutcome(
+	ctx context.Context,
+	signature string,
+	lastValidBlockHeight uint64,
+) (model.TransferOutcome, error) {
+	sig, err := solana.SignatureFromBase58(signature)
+	if err != nil {
+		return model.TransferPending, apperrors.Internal("invalid transfer signature "+signature, err)
+	}
+
+	// the height is read before the status, so a transfer landing in between is still found
+	height, err := s.SolanaRPC.GetBlockHeight(ctx, Finalized)
+	if err != nil {
+		return model.TransferPending, apperrors.ServiceUnavailable("failed to get block height", err)
+	}
+
+	statuses, err := s.SolanaRPC.GetSignatureStatuses(ctx, true, sig)
+	if err != nil {
+		return model.TransferPending, apperrors.ServiceUnavailable("failed to get signature status", err)
+	}
+
+	if statuses != nil && len(statuses.Value) > 0 && statuses.Value[0] != nil {
+		status := statuses.Value[0]
+		switch {
+		case status.Err != nil:
+			return model.TransferFailed, nil
+		case status.ConfirmationStatus == rpc.ConfirmationStatusConfirmed,
+			status.ConfirmationStatus == rpc.ConfirmationStatusFinalized:
+			return model.TransferConfirmed, nil
+		default:
+			return model.TransferPending, nil
+		}
+	}
+
+	if height > lastValidBlockHeight {
+		return model.TransferFailed, nil
+	}
+
+	return model.TransferPending, nil
+}
+
+// TransferToken transfers SPL tokens from the admin wallet, the associated
+// token account of the recipient is created if it does not exist yet
+func (s *WalletService) TransferToken(
+	ctx context.Context,
+	publicAddress string,
+	amount uint64,
+	mint solana.PublicKey,
+) (string, error) {
+	if amount == 0 {
 		return "", nil
 	}
 
+	inst, err := s.tokenTransferInstructions(ctx, publicAddress, amount, mint)
+	if err != nil {
+		return "", err
+	}
+
+	txHash, err := s.SendTransaction(ctx, inst)
+	if err != nil {
+		return "", err
+	}
+
+	return txHash, nil
+}
+
+func (s *WalletService) tokenTransferInstructions(
+	ctx context.Context,
+	publicAddress string,
+	amount uint64,
+	mint solana.PublicKey,
+) ([]solana.Instruction, error) {
 	adminTokenAccount, _, err := solana.FindAssociatedTokenAddress(s.solanaAdminPrivateKey.PublicKey(), mint)
 	if err != nil || adminTokenAccount == ZeroValuePublicKey {
-		return "", apperrors.Internal("failed to find associated token account for user rewarding", err)
+		return nil, apperrors.Internal("failed to find associated token account for token transfer", err)
 	}
 
-	duelOwnerAddress, err := solana.PublicKeyFromBase58(publicAddress)
-	if err != nil || duelOwnerAddress == ZeroValuePublicKey {
-		return "", apperrors.BadRequest("recipient is not valid solana address", err)
+	recipientAddress, err := solana.PublicKeyFromBase58(publicAddress)
+	if err != nil || recipientAddress == ZeroValuePublicKey {
+		return nil, apperrors.BadRequest("recipient is not valid solana address", err)
 	}
 
-	duelOwnerATA, _, err := solana.FindAssociatedTokenAddress(duelOwnerAddress, mint)
-	if err != nil || duelOwnerATA == ZeroValuePublicKey {
-		return "", apperrors.BadRequest("failed to find recipient associated token account", err)
+	recipientATA, _, err := solana.FindAssociatedTokenAddress(recipientAddress, mint)
+	if err != nil || recipientATA == ZeroValuePublicKey {
+		return nil, apperrors.BadRequest("failed to find recipient associated token account", err)
 	}
 
-	info, err := s.SolanaRPC.GetAccountInfo(ctx, duelOwnerATA)
+	info, err := s.SolanaRPC.GetAccountInfo(ctx, recipientATA)
 	if err != nil && !errors.Is(err, rpc.ErrNotFound) {
-		return "", apperrors.ServiceUnavailable("failed to get recipient's account info", err)
+		return nil, apperrors.ServiceUnavailable("failed to get recipient's account info", err)
 	}
 
 	inst := make([]solana.Instruction, 0, 2)
 	if info == nil || info.Value == nil || info.Value.Owner == ZeroValuePublicKey {
 		initTokenAccountInstruction, err := associatedtokenaccount.NewCreateInstruction(
 			s.solanaAdminPrivateKey.PublicKey(),
-			duelOwnerAddress,
+			recipientAddress,
 			mint).ValidateAndBuild()
 		if err != nil {
-			return "", apperrors.Internal("failed to build token account initialization instruction", err)
+			return nil, apperrors.Internal("failed to build token account initialization instruction", err)
 		}
 
 		inst = append(inst, initTokenAccountInstruction)
 	}
 
 	transferInstruction, err := token.NewTransferInstruction(
-		commissionReward,
+		amount,
 		adminTokenAccount,
-		duelOwnerATA,
+		recipientATA,
 		s.solanaAdminPrivateKey.PublicKey(),
 		[]solana.PublicKey{s.solanaAdminPrivateKey.PublicKey()}).ValidateAndBuild()
 	if err != nil {
-		return "", apperrors.Internal("failed to build transfer transaction", err)
+		return nil, apperrors.Internal("failed to build transfer transaction", err)
 	}
 
-	inst = append(inst, transferInstruction)
+	return append(inst, transferInstruction), nil
+}
 
-	txHash, err := s.SendTransaction(ctx, inst)
+func (s *WalletService) SendTransaction(
+	ctx context.Context,
+	instructions []solana.Instruction,
+) (string, error) {
+	tx, err := s.newBudgetedTransaction(ctx, instructions)
 	if err != nil {
 		return "", err
 	}
 
-	return txHash, nil
+	txHash, err := s.sendTransaction(
+		ctx,
+		tx,
+		txSignerPrivateKeyGetter(s.solanaAdminPrivateKey))
+	if err != nil {
+		return "", err
+	}
+
+	return txHash.String(), nil
 }
 
-func (s *WalletService) SendTransaction(
+// newBudgetedTransaction prepends compute budget instructions sized by a simulation
+func (s *WalletService) newBudgetedTransaction(
 	ctx context.Context,
 	instructions []solana.Instruction,
-) (string, error) {
+) (*solana.Transaction, error) {
 	tx, err := s.NewTransactionForSimulation(
 		instructions,
 		txSignerPrivateKeyGetter(s.solanaAdminPrivateKey),
 		solana.TransactionPayer(s.solanaAdminPrivateKey.PublicKey()))
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	computeUnits, err := s.GetSimulationComputeUnits(ctx, tx)
@@ -627,14 +791,14 @@ func (s *WalletService) SendTransaction(
 		SetMicroLamports(s.PriorityTracker.GetHighPriorityMicroLamports()).
 		ValidateAndBuild()
 	if err != nil {
-		return "", apperrors.Internal("failed to set transaction compute unit price", err)
+		return nil, apperrors.Internal("failed to set transaction compute unit price", err)
 	}
 
 	cuLimitInstruction, err := computebudget.NewSetComputeUnitLimitInstructionBuilder().
 		SetUnits(computeUnits).
 		ValidateAndBuild()
 	if err != nil {
-		return "", apperrors.Internal("failed to set transaction compute unit limit", err)
+		return nil, apperrors.Internal("failed to set transaction compute unit limit", err)
 	}
 
 	// Compute Unit Price and Compute Unit Limit instructions must be first
@@ -645,18 +809,10 @@ func (s *WalletService) SendTransaction(
 		solana.Hash{},
 		solana.TransactionPayer(s.solanaAdminPrivateKey.PublicKey()))
 	if err != nil {
-		return "", apperrors.Internal("failed to create transaction", err)
+		return nil, apperrors.Internal("failed to create transaction", err)
 	}
 
-	txHash, err := s.sendTransaction(
-		ctx,
-		tx,
-		txSignerPrivateKeyGetter(s.solanaAdminPrivateKey))
-	if err != nil {
-		return "", err
-	}
-
-	return txHash.String(), nil
+	return tx, nil
 }
 
 func (s *WalletService) NewTransactionForSimulation(
@@ -747,12 +903,6 @@ func (s *WalletService) sendTransaction(
 	tx *solana.Transaction,
 	privateKeyGetter func(key solana.PublicKey) *solana.PrivateKey,
 ) (solana.Signature, error) {
-	opts := rpc.TransactionOpts{
-		SkipPreflight:       false,
-		PreflightCommitment: Finalized,
-		MaxRetries:          &TransactionMaxRetryCount,
-	}
-
 	if err := s.RefreshBlockHash(ctx, &tx.Message); err != nil {
 		return solana.Signature{}, err
 	}
@@ -762,6 +912,17 @@ func (s *WalletService) sendTransaction(
 		return solana.Signature{}, apperrors.Internal("failed to sign transaction", err)
 	}
 
+	return s.submitTransaction(ctx, tx)
+}
+
+// submitTransaction sends a signed transaction
+func (s *WalletService) submitTransaction(ctx context.Context, tx *solana.Transaction) (solana.Signature, error) {
+	opts := rpc.TransactionOpts{
+		SkipPreflight:       false,
+		PreflightCommitment: Finalized,
+		MaxRetries:          &TransactionMaxRetryCount,
+	}
+
 	sig, err := s.SolanaRPC.SendTransactionWithOpts(ctx, tx, opts)
 	if err != nil {
 		return solana.Signature{}, apperrors.Internal("failed to send transaction", err)
@@ -780,6 +941,7 @@ func (s *WalletService) RefreshBlockHash(ctx context.Context, txMessage *solana.
 	return nil
 }
 
+// sendTxWithTracker sends the transaction and waits until it is confirmed
 func (s *WalletService) sendTxWithTracker(
 	ctx context.Context,
 	tx *solana.Transaction,
@@ -790,13 +952,13 @@ func (s *WalletService) sendTxWithTracker(
 		return solana.Signature{}, err
 	}
 
-	sent, err := s.SigTracker.SubscribeForSignatureStatus(sig, TxConfirmationTimeout)
-	if err != nil && !errors.Is(err, rpc.ErrNotConfirmed) {
-		return solana.Signature{}, apperrors.Internal("subscribe to signature status", err)
-	}
-
-	if !sent {
-		return solana.Signature{}, apperrors.Internal("tx was not confirmed: " + sig.String())
+	select {
+	case status := <-s.SigTracker.Watch(sig, TxConfirmationTimeout):
+		if !status.Confirmed() {
+			return solana.Signature{}, apperrors.Internal("tx was not confirmed: " + sig.String())
+		}
+	case <-ctx.Done():
+		return solana.Signature{}, apperrors.Internal("tx confirmation was interrupted: "+sig.String(), ctx.Err())
 	}
 
 	return sig, nil
@@ -0,0 +1,61 @@
+package cache
+
+import (
+	"context"
+	"duels-api/pkg/apperrors"
+	"duels-api/pkg/middleware/limiter"
+	redislimiter "duels-api/pkg/middleware/limiter/redis"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// lastUsedInterval throttles last used updates of a key in the database
+	lastUsedInterval = time.Minute
+	// rateWindow is the window of the per minute limit of a key in seconds
+	rateWindow = uint64(60)
+)
+
+type APIKeyUsage struct {
+	client  *redis.Client
+	limiter limiter.Storage
+}
+
+func NewAPIKeyUsage(client *redis.Client) *APIKeyUsage {
+	return &APIKeyUsage{
+		client: client,
+		// shares the counters of the route limiter, keys of api keys do not clash with policy names
+		limiter: redislimiter.New(client, "limiter:"),
+	}
+}
+
+// Hit counts the request in the sliding minute window of the key and reports whether it is within the limit
+func (s *APIKeyUsage) Hit(ctx context.Context, keyID uuid.UUID, limit uint32) (bool, error) {
+	rate, _, err := s.limiter.Hit(ctx, getAPIKeyRateKey(keyID), rateWindow)
+	if err != nil {
+		return false, apperrors.Internal("failed to count api key usage", err)
+	}
+
+	return rate <= int(limit), nil
+}
+
+// ShouldTouch reports whether the last used time of the key is stale and claims the update
+func (s *APIKeyUsage) ShouldTouch(ctx context.Context, keyID uuid.UUID) (bool, error) {
+	ok, err := s.client.SetNX(ctx, getAPIKeyTouchedKey(keyID), 1, lastUsedInterval).Result()
+	if err != nil {
+		return false, apperrors.Internal("failed to check api key last usage", err)
+	}
+
+	return ok, nil
+}
+
+func getAPIKeyRateKey(keyID uuid.UUID) string {
+	return fmt.Sprintf("apikey:%s", keyID)
+}
+
+func getAPIKeyTouchedKey(keyID uuid.UUID) string {
+	return fmt.Sprintf("apikey:%s:touched", keyID)
+}
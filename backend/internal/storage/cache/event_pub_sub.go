@@ -2,28 +2,76 @@ package cache
 
 import (
 	"context"
+	"duels-api/config"
 	"duels-api/internal/model"
 	"duels-api/pkg/apperrors"
+	"encoding"
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"github.com/goccy/go-json"
 	"github.com/google/uuid"
 	"github.com/redis/go-redis/v9"
 )
 
+// replayPageSize is how many stored events are read from the stream at once
+const replayPageSize = 100
+
+// publishUserEventScript appends the event to the stream of the user, trims events older
+// than the retention and broadcasts the event with its stream id prepended as event_id.
+// Doing it in one script keeps the broadcast order the same as the stream order, the trim
+// point is taken from the clock of redis that assigns the stream ids
+var publishUserEventScript = redis.NewScript(`
+local now = redis.call('TIME')
+local minID = string.format('%d', tonumber(now[1]) * 1000 + math.floor(tonumber(now[2]) / 1000) - tonumber(ARGV[2]))
+
+local id = redis.call('XADD', KEYS[1], 'MINID', '~', minID, '*', 'data', ARGV[1])
+redis.call('PEXPIRE', KEYS[1], ARGV[2])
+
+local data = ARGV[1]
+if string.len(data) > 2 then
+    data = '{"event_id":"' .. id .. '",' .. string.sub(data, 2)
+else
+    data = '{"event_id":"' .. id .. '"}'
+end
+
+redis.call('PUBLISH', KEYS[2], data)
+return id
+`)
+
 type EventPubSub struct {
-	c *redis.Client
+	c         *redis.Client
+	retention time.Duration
 }
 
-func NewEventPubSub(c *redis.Client) *EventPubSub {
-	return &EventPubSub{c: c}
+// StoredEvent is an event read back from the stream of the user, Data includes the event_id
+type StoredEvent struct {
+	ID   string
+	Data []byte
+}
+
+func NewEventPubSub(c *redis.Client, conf *config.Config) *EventPubSub {
+	return &EventPubSub{c: c, retention: conf.App.EventRetention}
 }
 
 func (s *EventPubSub) Subscribe(ctx context.Context, userID uuid.UUID) *redis.PubSub {
 	return s.c.Subscribe(ctx, s.UserEventKey(userID))
 }
 
-func (s *EventPubSub) Publish(ctx context.Context, userID uuid.UUID, event *model.Event) error {
-	err := s.c.Publish(ctx, s.UserEventKey(userID), event).Err()
+// Publish stores the event for replay and delivers it to live subscribers of the user.
+// Events must marshal to JSON objects, they are delivered with the event_id field added
+func (s *EventPubSub) Publish(ctx context.Context, userID uuid.UUID, event encoding.BinaryMarshaler) error {
+	data, err := event.MarshalBinary()
+	if err != nil {
+		return apperrors.Internal("failed to marshal event", err)
+	}
+
+	err = publishUserEventScript.Run(ctx, s.c,
+		[]string{s.UserStreamKey(userID), s.UserEventKey(userID)},
+		data, s.retention.Milliseconds(),
+	).Err()
 	if err != nil {
 		return apperrors.Internal("failed to publish event", err)
 	}
@@ -31,6 +79,139 @@ func (s *EventPubSub) Publish(ctx context.Context, userID uuid.UUID, event *mode
 	return nil
 }
 
+// ReadSince returns stored events of the user published after the event id, the oldest first
+func (s *EventPubSub) ReadSince(ctx context.Context, userID uuid.UUID, lastEventID string) ([]StoredEvent, error) {
+	messages, err := s.c.XRangeN(ctx, s.UserStreamKey(userID), "("+lastEventID, "+", replayPageSize).Result()
+	if err != nil {
+		return nil, apperrors.Internal("failed to read events", err)
+	}
+
+	events := make([]StoredEvent, 0, len(messages))
+	for _, message := range messages {
+		data, _ := message.Values["data"].(string)
+		events = append(events, StoredEvent{
+			ID:   message.ID,
+			Data: withEventID(data, message.ID),
+		})
+	}
+
+	return events, nil
+}
+
+// Trimmed reports whether events published after the event id may have been trimmed,
+// so a replay from it would miss events
+func (s *EventPubSub) Trimmed(ctx context.Context, userID uuid.UUID, lastEventID string) (bool, error) {
+	oldest, err := s.c.XRangeN(ctx, s.UserStreamKey(userID), "-", "+", 1).Result()
+	if err != nil {
+		return false, apperrors.Internal("failed to read events", err)
+	}
+
+	var oldestID string
+	if len(oldest) > 0 {
+		oldestID = oldest[0].ID
+	}
+
+	now, err := s.c.Time(ctx).Result()
+	if err != nil {
+		return false, apperrors.Internal("failed to get redis time", err)
+	}
+
+	return cursorTrimmed(lastEventID, oldestID, now.Add(-s.retention)), nil
+}
+
 func (s *EventPubSub) UserEventKey(userID uuid.UUID) string {
 	return fmt.Sprintf("user:%s:events", userID.String())
 }
+
+func (s *EventPubSub) UserStreamKey(userID uuid.UUID) string {
+	return fmt.Sprintf("user:%s:stream", userID.String())
+}
+
+// SubscribeTopic subscribes to the events of the topic published by any instance
+func (s *EventPubSub) SubscribeTopic(ctx context.Context, topic string) *redis.PubSub {
+	return s.c.Subscribe(ctx, s.TopicEventKey(topic))
+}
+
+func (s *EventPubSub) PublishTopic(ctx context.Context, event *model.Event) error {
+	err := s.c.Publish(ctx, s.TopicEventKey(event.Topic), event).Err()
+	if err != nil {
+		return apperrors.Internal("failed to publish topic event", err)
+	}
+
+	return nil
+}
+
+func (s *EventPubSub) TopicEventKey(topic string) string {
+	return fmt.Sprintf("topic:%s:events", topic)
+}
+
+// withEventID adds the event_id field the same way publishUserEventScript does
+func withEventID(data, id string) []byte {
+	if len(data) > 2 {
+		return []byte(`{"event_id":"` + id + `",` + data[1:])
+	}
+	return []byte(`{"event_id":"` + id + `"}`)
+}
+
+// cursorTrimmed reports whether the stream may have lost events after the cursor. The event of
+// the cursor is retained until newer events are trimmed, so an oldest retained event after the
+// cursor means the cursor was trimmed. An empty stream lost the cursor if it is older than minTime
+func cursorTrimmed(cursor, oldestID string, minTime time.Time) bool {
+	if oldestID != "" {
+		return EventIDAfter(oldestID, cursor)
+	}
+
+	ms, _, _ := strings.Cut(cursor, "-")
+	cursorMs, err := strconv.ParseInt(ms, 10, 64)
+	if err != nil {
+		return true
+	}
+
+	return cursorMs < minTime.UnixMilli()
+}
+
+// ParseEventID returns the event_id of a delivered user event, empty if it has none
+func ParseEventID(data []byte) string {
+	var event struct {
+		EventID string `json:"event_id"`
+	}
+	if err := json.Unmarshal(data, &event); err != nil {
+		return ""
+	}
+	return event.EventID
+}
+
+// ValidEventID reports whether the id is a stream id, e.g. 1700000000000-0
+func ValidEventID(id string) bool {
+	ms, seq, ok := strings.Cut(id, "-")
+	if !ok {
+		return false
+	}
+
+	_, errMs := strconv.ParseUint(ms, 10, 64)
+	_, errSeq := strconv.ParseUint(seq, 10, 64)
+
+	return errMs == nil && errSeq == nil
+}
+
+// EventIDAfter reports whether the stream id a was assigned after b, ids are compared
+// by time and then by sequence. Events without an id are never skipped
+func EventIDAfter(a, b string) bool {
+	if !ValidEventID(a) || !ValidEventID(b) {
+		return true
+	}
+
+	aMs, aSeq, _ := strings.Cut(a, "-")
+	bMs, bSeq, _ := strings.Cut(b, "-")
+
+	aMsN, _ := strconv.ParseUint(aMs, 10, 64)
+	bMsN, _ := strconv.ParseUint(bMs, 10, 64)
+	if aMsN != bMsN {
+		return aMsN > bMsN
+	}
+
+	aSeqN, _ := strconv.ParseUint(aSeq, 10, 64)
+	bSeqN, _ := strconv.ParseUint(bSeq, 10, 64)
+
+	return aSeqN > bSeqN
+}
@@ -0,0 +1,80 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventIDAfter(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"later time", "1700000000001-0", "1700000000000-5", true},
+		{"earlier time", "1700000000000-5", "1700000000001-0", false},
+		{"later sequence", "1700000000000-2", "1700000000000-1", true},
+		{"earlier sequence", "1700000000000-1", "1700000000000-2", false},
+		{"same id", "1700000000000-1", "1700000000000-1", false},
+		{"sequence compared as number", "1700000000000-10", "1700000000000-9", true},
+		{"time compared as number", "10000000000000-0", "9999999999999-0", true},
+		{"missing id", "", "1700000000000-0", true},
+		{"invalid cursor", "1700000000000-0", "abc", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EventIDAfter(tt.a, tt.b); got != tt.want {
+				t.Errorf("EventIDAfter(%q, %q) = %v, want %v", tt.a, tt.b, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParseEventID(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want string
+	}{
+		{"stored event", `{"event_id":"1700000000000-0","type":2}`, "1700000000000-0"},
+		{"event without payload", string(withEventID("{}", "1700000000000-1")), "1700000000000-1"},
+		{"event with payload", string(withEventID(`{"type":4,"payload":{}}`, "1700000000000-2")), "1700000000000-2"},
+		{"topic event", `{"type":3,"topic":"duel:1"}`, ""},
+		{"not json", "ping", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseEventID([]byte(tt.data)); got != tt.want {
+				t.Errorf("ParseEventID() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCursorTrimmed(t *testing.T) {
+	minTime := time.UnixMilli(1700000000000)
+
+	tests := []struct {
+		name     string
+		cursor   string
+		oldestID string
+		want     bool
+	}{
+		{"cursor retained", "1700000000500-0", "1700000000500-0", false},
+		{"cursor after oldest", "1700000000900-0", "1700000000500-0", false},
+		{"cursor trimmed", "1700000000100-0", "1700000000500-0", true},
+		{"empty stream, recent cursor", "1700000000500-0", "", false},
+		{"empty stream, expired cursor", "1699999999999-0", "", true},
+		{"empty stream, invalid cursor", "abc", "", true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cursorTrimmed(tt.cursor, tt.oldestID, minTime); got != tt.want {
+				t.Errorf("cursorTrimmed(%q, %q) = %v, want %v", tt.cursor, tt.oldestID, got, tt.want)
+			}
+		})
+	}
+}
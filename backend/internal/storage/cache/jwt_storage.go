@@ -3,6 +3,7 @@ package cache
 import (
 	"context"
 	"duels-api/config"
+	"duels-api/internal/model"
 	"duels-api/pkg/apperrors"
 	auth "duels-api/pkg/jwt"
 	"errors"
@@ -34,12 +35,20 @@ func NewJWTCacheStorage(
 
 const userSessionsLimit = 5
 
+// sessionsRevokedChannel announces users whose sessions were revoked,
+// so open connections of them are checked against the storage
+const sessionsRevokedChannel = "sessions:revoked"
+
 func (s *JWTStorage) Save(
 	ctx context.Context,
 	token *auth.TokenExpiration,
 	claims auth.TokenClaims,
+	session *model.Session,
 ) error {
-	key := getUserSessionsHashKey(claims.UserID)
+	var (
+		key     = getUserSessionsHashKey(claims.UserID)
+		metaKey = getUserSessionsMetaHashKey(claims.UserID)
+	)
 
 	sessionCount, err := s.client.HLen(ctx, key).Result()
 	if err != nil {
@@ -57,8 +66,12 @@ func (s *JWTStorage) Save(
 			slices.Sort(keys)
 
 			fieldsToDelete := keys[:len(keys)-userSessionsLimit+1]
-			err = s.client.HDel(ctx, key, fieldsToDelete...).Err()
-			if err != nil {
+
+			pipe := s.client.Pipeline()
+			pipe.HDel(ctx, key, fieldsToDelete...)
+			pipe.HDel(ctx, metaKey, fieldsToDelete...)
+			pipe.Publish(ctx, sessionsRevokedChannel, claims.UserID.String())
+			if _, err = pipe.Exec(ctx); err != nil {
 				return apperrors.Internal("failed to delete old user session", err)
 			}
 		}
@@ -66,9 +79,15 @@ func (s *JWTStorage) Save(
 
 	field := getSessionHashField(claims.SessionID)
 
+	session.ID = claims.SessionID
+	session.FamilyID = claims.FamilyID
+	session.ExpiresAt = token.ExpiresAt
+
 	pipe := s.client.Pipeline()
 	pipe.HSet(ctx, key, field, token.Token)
 	pipe.HExpireAt(ctx, key, token.ExpiresAt, field)
+	pipe.HSet(ctx, metaKey, field, session)
+	pipe.HExpireAt(ctx, metaKey, token.ExpiresAt, field)
 	_, err = pipe.Exec(ctx)
 	if err != nil {
 		return apperrors.Internal("failed to save new user session", err)
@@ -81,6 +100,10 @@ func getUserSessionsHashKey(userID uuid.UUID) string {
 	return fmt.Sprintf("user:%s:session", userID.String())
 }
 
+func getUserSessionsMetaHashKey(userID uuid.UUID) string {
+	return fmt.Sprintf("user:%s:session:meta", userID.String())
+}
+
 func getSessionHashField(sessionID uuid.UUID) string {
 	if t := sessionID.Time(); t != 0 {
 		return strconv.FormatInt(int64(t), 10)
@@ -98,7 +121,7 @@ func (s *JWTStorage) GetUserSession(ctx context.Context, userID, sessionID uuid.
 	token, err := s.client.HGet(ctx, key, field).Result()
 	if err != nil {
 		if errors.Is(err, redis.Nil) {
-			return "", apperrors.NotFound("token not found in storage")
+			return "", apperrors.Unauthorized("session is revoked or unknown")
 		}
 
 		return "", apperrors.Unauthorized("failed to find token by user_id and session_id", err)
@@ -107,15 +130,138 @@ func (s *JWTStorage) GetUserSession(ctx context.Context, userID, sessionID uuid.
 	return token, nil
 }
 
+// DeleteUserSession revokes the session, open connections of the user are checked at once
 func (s *JWTStorage) DeleteUserSession(ctx context.Context, userID, sessionID uuid.UUID) error {
-	key := getUserSessionsHashKey(userID)
+	return s.deleteUserSession(ctx, userID, sessionID, true)
+}
 
+// DeleteRotatedSession deletes a session replaced by a refresh without announcing a revocation,
+// connections of the session move to the new one with their next refresh or expire
+func (s *JWTStorage) DeleteRotatedSession(ctx context.Context, userID, sessionID uuid.UUID) error {
+	return s.deleteUserSession(ctx, userID, sessionID, false)
+}
+
+func (s *JWTStorage) deleteUserSession(ctx context.Context, userID, sessionID uuid.UUID, revoked bool) error {
 	field := getSessionHashField(sessionID)
 
-	err := s.client.HDel(ctx, key, field).Err()
-	if err != nil {
+	pipe := s.client.Pipeline()
+	pipe.HDel(ctx, getUserSessionsHashKey(userID), field)
+	pipe.HDel(ctx, getUserSessionsMetaHashKey(userID), field)
+	if revoked {
+		pipe.Publish(ctx, sessionsRevokedChannel, userID.String())
+	}
+	if _, err := pipe.Exec(ctx); err != nil {
 		return apperrors.Internal("failed to delete user session from a storage", err)
 	}
 
 	return nil
 }
+
+func (s *JWTStorage) DeleteAllUserSessions(ctx context.Context, userID uuid.UUID) error {
+	pipe := s.client.Pipeline()
+	pipe.Del(ctx, getUserSessionsHashKey(userID), getUserSessionsMetaHashKey(userID))
+	pipe.Publish(ctx, sessionsRevokedChannel, userID.String())
+	if _, err := pipe.Exec(ctx); err != nil {
+		return apperrors.Internal("failed to delete user sessions from a storage", err)
+	}
+
+	return nil
+}
+
+// SubscribeRevocations receives the ids of users whose sessions were revoked
+func (s *JWTStorage) SubscribeRevocations(ctx context.Context) *redis.PubSub {
+	return s.client.Subscribe(ctx, sessionsRevokedChannel)
+}
+
+// DeleteSessionFamily deletes every session refreshed from the same sign in,
+// it reports whether any session was deleted
+func (s *JWTStorage) DeleteSessionFamily(ctx context.Context, userID, familyID uuid.UUID) (bool, error) {
+	sessions, err := s.GetUserSessions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	var deleted bool
+	for _, session := range sessions {
+		if session.FamilyID != familyID {
+			continue
+		}
+
+		if err = s.DeleteUserSession(ctx, userID, session.ID); err != nil {
+			return false, err
+		}
+		deleted = true
+	}
+
+	return deleted, nil
+}
+
+func (s *JWTStorage) SessionExists(ctx context.Context, userID, sessionID uuid.UUID) (bool, error) {
+	exists, err := s.client.HExists(ctx, getUserSessionsHashKey(userID), getSessionHashField(sessionID)).Result()
+	if err != nil {
+		return false, apperrors.Internal("failed to check user session", err)
+	}
+
+	return exists, nil
+}
+
+// SessionAlive reports whether the session or a session rotated from it exists,
+// rotated sessions keep the family id of their sign in
+func (s *JWTStorage) SessionAlive(ctx context.Context, userID, sessionID, familyID uuid.UUID) (bool, error) {
+	exists, err := s.SessionExists(ctx, userID, sessionID)
+	if err != nil || exists || familyID == uuid.Nil {
+		return exists, err
+	}
+
+	sessions, err := s.GetUserSessions(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	for _, session := range sessions {
+		if session.FamilyID == familyID {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// GetSessionMeta returns the stored session, nil for sessions started before metadata was stored
+func (s *JWTStorage) GetSessionMeta(ctx context.Context, userID, sessionID uuid.UUID) (*model.Session, error) {
+	session := new(model.Session)
+
+	err := s.client.HGet(ctx, getUserSessionsMetaHashKey(userID), getSessionHashField(sessionID)).Scan(session)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, nil
+		}
+
+		return nil, apperrors.Internal("failed to get user session", err)
+	}
+
+	return session, nil
+}
+
+func (s *JWTStorage) GetUserSessions(ctx context.Context, userID uuid.UUID) ([]model.Session, error) {
+	values, err := s.client.HGetAll(ctx, getUserSessionsMetaHashKey(userID)).Result()
+	if err != nil {
+		return nil, apperrors.Internal("failed to get user sessions", err)
+	}
+
+	sessions := make([]model.Session, 0, len(values))
+	for _, value := range values {
+		var session model.Session
+		if err = session.UnmarshalBinary([]byte(value)); err != nil {
+			return nil, apperrors.Internal("failed to unmarshal user session", err)
+		}
+
+		sessions = append(sessions, session)
+	}
+
+	slices.SortFunc(sessions, func(a, b model.Session) int {
+		return b.LastUsedAt.Compare(a.LastUsedAt)
+	})
+
+	return sessions, nil
+}
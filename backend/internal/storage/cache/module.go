@@ -12,6 +12,10 @@ func Module() fx.Option {
 		fx.Provide(
 			NewJWTCacheStorage,
 			NewEventPubSub,
+			NewSignInChallengeStorage,
+			NewTokenDenylist,
+			NewAPIKeyUsage,
+			NewPresence,
 		),
 	)
 }
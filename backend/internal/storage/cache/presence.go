@@ -0,0 +1,115 @@
+package cache
+
+import (
+	"context"
+	"duels-api/pkg/apperrors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+)
+
+// PresenceTTL is how long a viewer is counted without being refreshed,
+// viewers of an instance that went away expire after it
+const PresenceTTL = 45 * time.Second
+
+// Presence counts the users viewing duels, a viewer is kept per instance so
+// that a user viewing a duel through several instances is counted once
+type Presence struct {
+	client *redis.Client
+}
+
+func NewPresence(client *redis.Client) *Presence {
+	return &Presence{client: client}
+}
+
+// Join counts the users as viewers of the duel through the instance, it also refreshes
+// viewers that are still counted and returns the number of viewers after the update
+func (p *Presence) Join(ctx context.Context, duelID uuid.UUID, instanceID string, userIDs ...uuid.UUID) (uint64, error) {
+	expiresAt := float64(time.Now().Add(PresenceTTL).UnixMilli())
+
+	members := make([]redis.Z, 0, len(userIDs))
+	for _, userID := range userIDs {
+		members = append(members, redis.Z{Score: expiresAt, Member: presenceMember(userID, instanceID)})
+	}
+
+	return p.update(ctx, duelID, func(pipe redis.Pipeliner, key string) {
+		pipe.ZAdd(ctx, key, members...)
+	})
+}
+
+// Leave stops counting the user as a viewer of the duel through the instance
+func (p *Presence) Leave(ctx context.Context, duelID uuid.UUID, instanceID string, userID uuid.UUID) (uint64, error) {
+	return p.update(ctx, duelID, func(pipe redis.Pipeliner, key string) {
+		pipe.ZRem(ctx, key, presenceMember(userID, instanceID))
+	})
+}
+
+func (p *Presence) update(
+	ctx context.Context,
+	duelID uuid.UUID,
+	change func(pipe redis.Pipeliner, key string),
+) (uint64, error) {
+	key := getDuelViewersKey(duelID)
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	pipe := p.client.TxPipeline()
+	change(pipe, key)
+	pipe.ZRemRangeByScore(ctx, key, "-inf", now)
+	pipe.PExpire(ctx, key, PresenceTTL)
+	members := pipe.ZRange(ctx, key, 0, -1)
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return 0, apperrors.Internal("failed to update duel viewers", err)
+	}
+
+	return countViewers(members.Val()), nil
+}
+
+// Count returns the number of viewers of each duel
+func (p *Presence) Count(ctx context.Context, duelIDs []uuid.UUID) (map[uuid.UUID]uint64, error) {
+	if len(duelIDs) == 0 {
+		return map[uuid.UUID]uint64{}, nil
+	}
+
+	now := strconv.FormatInt(time.Now().UnixMilli(), 10)
+
+	pipe := p.client.Pipeline()
+	members := make([]*redis.StringSliceCmd, len(duelIDs))
+	for i, duelID := range duelIDs {
+		members[i] = pipe.ZRangeByScore(ctx, getDuelViewersKey(duelID), &redis.ZRangeBy{Min: "(" + now, Max: "+inf"})
+	}
+
+	if _, err := pipe.Exec(ctx); err != nil {
+		return nil, apperrors.Internal("failed to count duel viewers", err)
+	}
+
+	counts := make(map[uuid.UUID]uint64, len(duelIDs))
+	for i, duelID := range duelIDs {
+		counts[duelID] = countViewers(members[i].Val())
+	}
+
+	return counts, nil
+}
+
+// countViewers counts distinct users among the members of the instances
+func countViewers(members []string) uint64 {
+	users := make(map[string]struct{}, len(members))
+	for _, member := range members {
+		userID, _, _ := strings.Cut(member, ":")
+		users[userID] = struct{}{}
+	}
+
+	return uint64(len(users))
+}
+
+func presenceMember(userID uuid.UUID, instanceID string) string {
+	return userID.String() + ":" + instanceID
+}
+
+func getDuelViewersKey(duelID uuid.UUID) string {
+	return fmt.Sprintf("duel:%s:viewers", duelID)
+}
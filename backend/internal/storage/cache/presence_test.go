@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+func TestCountViewers(t *testing.T) {
+	alice := uuid.MustParse("0190d1f2-0000-7000-8000-000000000001")
+	bob := uuid.MustParse("0190d1f2-0000-7000-8000-000000000002")
+
+	tests := []struct {
+		name    string
+		members []string
+		want    uint64
+	}{
+		{"no viewers", nil, 0},
+		{"one viewer", []string{presenceMember(alice, "a")}, 1},
+		{"one viewer through two instances", []string{presenceMember(alice, "a"), presenceMember(alice, "b")}, 1},
+		{"two viewers on one instance", []string{presenceMember(alice, "a"), presenceMember(bob, "a")}, 2},
+		{
+			"two viewers through two instances",
+			[]string{presenceMember(alice, "a"), presenceMember(bob, "a"), presenceMember(bob, "b")},
+			2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := countViewers(tt.members); got != tt.want {
+				t.Errorf("countViewers() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+package cache
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/apperrors"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+type SignInChallengeStorage struct {
+	client *redis.Client
+}
+
+func NewSignInChallengeStorage(client *redis.Client) *SignInChallengeStorage {
+	return &SignInChallengeStorage{client: client}
+}
+
+func (s *SignInChallengeStorage) Save(ctx context.Context, challenge *model.SignInChallenge) error {
+	key := getSignInChallengeKey(challenge.Address, challenge.Nonce)
+
+	err := s.client.Set(ctx, key, challenge, time.Until(challenge.ExpirationTime)).Err()
+	if err != nil {
+		return apperrors.Internal("failed to save sign in challenge", err)
+	}
+
+	return nil
+}
+
+// Consume atomically reads and deletes the challenge, so it can be used only once
+func (s *SignInChallengeStorage) Consume(
+	ctx context.Context,
+	address string,
+	nonce string,
+) (*model.SignInChallenge, error) {
+	challenge := new(model.SignInChallenge)
+
+	err := s.client.GetDel(ctx, getSignInChallengeKey(address, nonce)).Scan(challenge)
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return nil, apperrors.Unauthorized("sign in challenge not found or already used")
+		}
+
+		return nil, apperrors.Internal("failed to get sign in challenge", err)
+	}
+
+	return challenge, nil
+}
+
+func getSignInChallengeKey(address, nonce string) string {
+	return fmt.Sprintf("siws:%s:%s", address, nonce)
+}
@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"context"
+	"duels-api/pkg/apperrors"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// Reasons a token id is denied
+const (
+	DenyReasonRevoked = "revoked"
+	// DenyReasonRotated marks a refresh token that was exchanged for a new pair,
+	// presenting it again means it was stolen
+	DenyReasonRotated = "rotated"
+)
+
+type TokenDenylist struct {
+	client *redis.Client
+}
+
+func NewTokenDenylist(client *redis.Client) *TokenDenylist {
+	return &TokenDenylist{client: client}
+}
+
+// Deny rejects the token id until the token expires by itself
+func (d *TokenDenylist) Deny(ctx context.Context, tokenID, reason string, expiresAt time.Time) error {
+	if !expiresAt.After(time.Now()) {
+		return nil
+	}
+
+	err := d.client.SetArgs(ctx, getDeniedTokenKey(tokenID), reason, redis.SetArgs{ExpireAt: expiresAt}).Err()
+	if err != nil {
+		return apperrors.Internal("failed to deny token", err)
+	}
+
+	return nil
+}
+
+// Reason returns why the token id is denied, empty string if it is not
+func (d *TokenDenylist) Reason(ctx context.Context, tokenID string) (string, error) {
+	reason, err := d.client.Get(ctx, getDeniedTokenKey(tokenID)).Result()
+	if err != nil {
+		if errors.Is(err, redis.Nil) {
+			return "", nil
+		}
+
+		return "", apperrors.Internal("failed to check token denylist", err)
+	}
+
+	return reason, nil
+}
+
+func getDeniedTokenKey(tokenID string) string {
+	return fmt.Sprintf("jwt:denied:%s", tokenID)
+}
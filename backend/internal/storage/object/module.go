@@ -0,0 +1,34 @@
+package object
+
+import (
+	"duels-api/config"
+	"fmt"
+
+	"go.uber.org/fx"
+)
+
+func Module() fx.Option {
+	return fx.Module("object",
+		fx.Provide(
+			func(lc fx.Lifecycle, c *config.Config) (Storage, error) {
+				switch c.Storage.Backend {
+				case BackendLocal:
+					return NewLocalStorage(c.Storage.LocalDir, MediaURLPrefix), nil
+				case BackendS3:
+					storage, err := NewS3Storage(c.Storage)
+					if err != nil {
+						return nil, err
+					}
+
+					lc.Append(fx.Hook{
+						OnStart: storage.EnsureBucket,
+					})
+
+					return storage, nil
+				default:
+					return nil, fmt.Errorf("unknown storage backend %q", c.Storage.Backend)
+				}
+			},
+		),
+	)
+}
@@ -0,0 +1,102 @@
+package object
+
+import (
+	"bytes"
+	"context"
+	"duels-api/config"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+)
+
+// S3Storage keeps files in a bucket of any S3 compatible storage, e.g. AWS S3 or MinIO
+type S3Storage struct {
+	client *minio.Client
+	bucket string
+
+	// publicURL is the base url of a public bucket or a CDN in front of it,
+	// signed urls are generated when it is empty
+	publicURL string
+	urlTTL    time.Duration
+}
+
+func NewS3Storage(c config.StorageConfig) (*S3Storage, error) {
+	client, err := minio.New(c.S3Endpoint, &minio.Options{
+		Creds:        credentials.NewStaticV4(c.S3AccessKey, c.S3SecretKey, ""),
+		Secure:       c.S3UseSSL,
+		Region:       c.S3Region,
+		BucketLookup: minio.BucketLookupPath,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &S3Storage{
+		client:    client,
+		bucket:    c.S3Bucket,
+		publicURL: strings.TrimSuffix(c.S3PublicURL, "/"),
+		urlTTL:    c.S3URLTTL,
+	}, nil
+}
+
+// EnsureBucket creates the bucket if it does not exist yet
+func (s *S3Storage) EnsureBucket(ctx context.Context) error {
+	exists, err := s.client.BucketExists(ctx, s.bucket)
+	if err != nil || exists {
+		return err
+	}
+
+	return s.client.MakeBucket(ctx, s.bucket, minio.MakeBucketOptions{})
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, data []byte, contentType string) error {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.client.PutObject(ctx, s.bucket, cleaned, bytes.NewReader(data), int64(len(data)),
+		minio.PutObjectOptions{ContentType: contentType})
+
+	return err
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return err
+	}
+
+	// S3 does not report missing objects on delete
+	_, err = s.client.StatObject(ctx, s.bucket, cleaned, minio.StatObjectOptions{})
+	if err != nil {
+		if minio.ToErrorResponse(err).Code == "NoSuchKey" {
+			return ErrNotFound
+		}
+
+		return err
+	}
+
+	return s.client.RemoveObject(ctx, s.bucket, cleaned, minio.RemoveObjectOptions{})
+}
+
+func (s *S3Storage) URL(ctx context.Context, key string) (string, error) {
+	cleaned, err := cleanKey(key)
+	if err != nil {
+		return "", err
+	}
+
+	if s.publicURL != "" {
+		return s.publicURL + "/" + cleaned, nil
+	}
+
+	signed, err := s.client.PresignedGetObject(ctx, s.bucket, cleaned, s.urlTTL, url.Values{})
+	if err != nil {
+		return "", err
+	}
+
+	return signed.String(), nil
+}
@@ -0,0 +1,42 @@
+package object
+
+import (
+	"context"
+	"errors"
+	"path"
+	"strings"
+)
+
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+// MediaURLPrefix is the route the stored keys are resolved by
+const MediaURLPrefix = "/media"
+
+var ErrNotFound = errors.New("object not found")
+
+// Storage keeps uploaded files. Keys are slash separated paths like
+// "/user_uploads/<name>.png", the same values are stored in image_url columns
+// and resolved by /media/<key>.
+type Storage interface {
+	Put(ctx context.Context, key string, data []byte, contentType string) error
+	Delete(ctx context.Context, key string) error
+	// URL returns a public or a signed url the object can be downloaded from
+	URL(ctx context.Context, key string) (string, error)
+}
+
+// cleanKey drops the leading slash and rejects keys escaping the storage root
+func cleanKey(key string) (string, error) {
+	if strings.Contains(key, "..") {
+		return "", errors.New("invalid object key")
+	}
+
+	cleaned := strings.TrimPrefix(path.Clean("/"+key), "/")
+	if cleaned == "" {
+		return "", errors.New("empty object key")
+	}
+
+	return cleaned, nil
+}
@@ -0,0 +1,62 @@
+package repository
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/repository"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type AchievementRepository struct {
+	repository.Generic[model.UserAchievement, uuid.UUID]
+}
+
+func NewAchievementRepository(
+	genericRepository repository.Generic[model.UserAchievement, uuid.UUID],
+) *AchievementRepository {
+	return &AchievementRepository{
+		Generic: genericRepository,
+	}
+}
+
+func (r *AchievementRepository) WithTx(tx bun.Tx) *AchievementRepository {
+	return &AchievementRepository{Generic: r.Generic.WithTx(tx)}
+}
+
+// Unlock reports whether the achievement is unlocked now rather than before
+func (r *AchievementRepository) Unlock(ctx context.Context, achievement *model.UserAchievement) (bool, error) {
+	res, err := r.DB.NewInsert().
+		Model(achievement).
+		On("CONFLICT (user_id, achievement) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *AchievementRepository) GetUserAchievements(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]model.UserAchievement, error) {
+	achievements := make([]model.UserAchievement, 0)
+
+	err := r.DB.NewSelect().
+		Model(&achievements).
+		Where("user_id = ?", userID).
+		Order("unlocked_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return achievements, nil
+}
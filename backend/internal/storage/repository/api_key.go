@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type APIKeyRepository struct {
+	repository.Generic[model.APIKey, uuid.UUID]
+}
+
+func NewAPIKeyRepository(
+	genericRepository repository.Generic[model.APIKey, uuid.UUID],
+) *APIKeyRepository {
+	return &APIKeyRepository{
+		Generic: genericRepository,
+	}
+}
+
+func (r *APIKeyRepository) WithTx(tx bun.Tx) *APIKeyRepository {
+	return &APIKeyRepository{Generic: r.Generic.WithTx(tx)}
+}
+
+func (r *APIKeyRepository) GetByHash(ctx context.Context, hash string) (*model.APIKey, error) {
+	key := new(model.APIKey)
+
+	err := r.DB.NewSelect().
+		Model(key).
+		Where("key_hash = ?", hash).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return key, nil
+}
+
+func (r *APIKeyRepository) GetUserKeys(ctx context.Context, userID uuid.UUID) ([]model.APIKey, error) {
+	keys := make([]model.APIKey, 0)
+
+	err := r.DB.NewSelect().
+		Model(&keys).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return keys, nil
+}
+
+func (r *APIKeyRepository) CountActive(ctx context.Context, userID uuid.UUID) (int, error) {
+	return r.DB.NewSelect().
+		Model((*model.APIKey)(nil)).
+		Where("user_id = ?", userID).
+		Where("revoked_at IS NULL").
+		Where("expires_at IS NULL OR expires_at > current_timestamp").
+		Count(ctx)
+}
+
+// Revoke marks the key revoked, userID limits it to the keys of the user unless it is nil
+func (r *APIKeyRepository) Revoke(ctx context.Context, id, userID uuid.UUID) (bool, error) {
+	query := r.DB.NewUpdate().
+		Model((*model.APIKey)(nil)).
+		Set("revoked_at = ?", time.Now().UTC()).
+		Where("id = ?", id).
+		Where("revoked_at IS NULL")
+
+	if userID != uuid.Nil {
+		query = query.Where("user_id = ?", userID)
+	}
+
+	res, err := query.Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *APIKeyRepository) UpdateLastUsed(ctx context.Context, id uuid.UUID, at time.Time) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.APIKey)(nil)).
+		Set("last_used_at = ?", at).
+		Where("id = ?", id).
+		Exec(ctx)
+
+	return err
+}
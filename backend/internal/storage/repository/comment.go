@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type CommentRepository struct {
+	repository.Generic[model.Comment, uuid.UUID]
+}
+
+func NewCommentRepository(
+	genericRepository repository.Generic[model.Comment, uuid.UUID],
+) *CommentRepository {
+	return &CommentRepository{
+		Generic: genericRepository,
+	}
+}
+
+func (r *CommentRepository) WithTx(tx bun.Tx) *CommentRepository {
+	return &CommentRepository{Generic: r.Generic.WithTx(tx)}
+}
+
+// GetWithAuthor returns the comment with the username and image of the author
+func (r *CommentRepository) GetWithAuthor(ctx context.Context, commentID uuid.UUID) (*model.Comment, error) {
+	comment := new(model.Comment)
+
+	err := r.withAuthorQuery(comment).
+		Where("c.id = ?", commentID).
+		Scan(ctx)
+	if err != nil {
+		if repository.IsErrNoRows(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// GetThreads returns top-level comments of the duel except the pinned one, the latest first
+func (r *CommentRepository) GetThreads(
+	ctx context.Context,
+	duelID uuid.UUID,
+	cursor *model.CommentCursor,
+	limit int,
+) ([]model.Comment, error) {
+	comments := make([]model.Comment, 0, limit)
+
+	q := r.withAuthorQuery(&comments).
+		Where("c.duel_id = ?", duelID).
+		Where("c.root_id IS NULL").
+		Where("c.pinned_at IS NULL").
+		Order("c.created_at DESC", "c.id DESC").
+		Limit(limit)
+
+	if cursor != nil {
+		q = q.Where("(c.created_at, c.id) < (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+// GetPinned returns nil if no comment of the duel is pinned
+func (r *CommentRepository) GetPinned(ctx context.Context, duelID uuid.UUID) (*model.Comment, error) {
+	comment := new(model.Comment)
+
+	err := r.withAuthorQuery(comment).
+		Where("c.duel_id = ?", duelID).
+		Where("c.pinned_at IS NOT NULL").
+		Scan(ctx)
+	if err != nil {
+		if repository.IsErrNoRows(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return comment, nil
+}
+
+// GetReplies returns replies of the thread in the order they were written
+func (r *CommentRepository) GetReplies(
+	ctx context.Context,
+	rootID uuid.UUID,
+	cursor *model.CommentCursor,
+	limit int,
+) ([]model.Comment, error) {
+	comments := make([]model.Comment, 0, limit)
+
+	q := r.withAuthorQuery(&comments).
+		Where("c.root_id = ?", rootID).
+		Order("c.created_at ASC", "c.id ASC").
+		Limit(limit)
+
+	if cursor != nil {
+		q = q.Where("(c.created_at, c.id) > (?, ?)", cursor.CreatedAt, cursor.ID)
+	}
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return comments, nil
+}
+
+func (r *CommentRepository) IncrementReplies(ctx context.Context, commentID uuid.UUID) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.Comment)(nil)).
+		Set("replies_count = replies_count + 1").
+		Where("id = ?", commentID).
+		Exec(ctx)
+
+	return err
+}
+
+func (r *CommentRepository) UpdateText(ctx context.Context, commentID uuid.UUID, text string) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.Comment)(nil)).
+		Set("text = ?", text).
+		Set("edited_at = ?", time.Now().UTC()).
+		Where("id = ?", commentID).
+		Exec(ctx)
+
+	return err
+}
+
+// UpdateStatus unpins the comment as well, only active comments stay pinned
+func (r *CommentRepository) UpdateStatus(ctx context.Context, commentID uuid.UUID, status uint8) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.Comment)(nil)).
+		Set("status = ?", status).
+		Set("pinned_at = NULL").
+		Where("id = ?", commentID).
+		Exec(ctx)
+
+	return err
+}
+
+// Pin replaces the pinned comment of the duel, call it within a transaction.
+// The duel row is locked, so concurrent pins of the duel replace each other in turn
+func (r *CommentRepository) Pin(ctx context.Context, comment *model.Comment) error {
+	var duelID uuid.UUID
+
+	err := r.DB.NewSelect().
+		Table("duels").
+		Column("id").
+		Where("id = ?", comment.DuelID).
+		For("UPDATE").
+		Scan(ctx, &duelID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.DB.NewUpdate().
+		Model((*model.Comment)(nil)).
+		Set("pinned_at = NULL").
+		Where("duel_id = ?", comment.DuelID).
+		Where("pinned_at IS NOT NULL").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.DB.NewUpdate().
+		Model((*model.Comment)(nil)).
+		Set("pinned_at = ?", time.Now().UTC()).
+		Where("id = ?", comment.ID).
+		Exec(ctx)
+
+	return err
+}
+
+func (r *CommentRepository) Unpin(ctx context.Context, commentID uuid.UUID) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.Comment)(nil)).
+		Set("pinned_at = NULL").
+		Where("id = ?", commentID).
+		Exec(ctx)
+
+	return err
+}
+
+func (r *CommentRepository) withAuthorQuery(model any) *bun.SelectQuery {
+	return r.DB.NewSelect().
+		Model(model).
+		ColumnExpr("c.*").
+		ColumnExpr("u.username, u.image_url").
+		Join("JOIN users AS u ON u.id = c.user_id")
+}
@@ -91,6 +91,41 @@ func (r *DuelRepository) GetAllDuelsWhereParticipate(
 	return duels, nil
 }
 
+// GetFeed returns active duels created by the users the follower follows
+func (r *DuelRepository) GetFeed(
+	ctx context.Context,
+	followerID uuid.UUID,
+	options *repository.Options,
+) ([]model.DuelShow, error) {
+	duels := make([]model.DuelShow, 0)
+
+	q := r.DB.NewSelect().
+		Model(&duels).
+		ColumnExpr("duels.*").
+		ColumnExpr("(p.user_id IS NOT NULL) AS joined").
+		ColumnExpr("p.final_status as player_status").
+		ColumnExpr("p.answer AS your_answer").
+		ColumnExpr("COALESCE(yes_counts.yes_count, 0) AS yes_count").
+		ColumnExpr("duels.players_count - COALESCE(yes_counts.yes_count, 0) as no_count").
+		ColumnExpr("u.image_url AS owner_image_url").
+		Join("left join users u ON u.id = duels.owner_id").
+		Join("left join players p on p.duel_id = duels.id AND p.user_id = ?", followerID).
+		Join("left join (select duel_id, COUNT(*) AS yes_count FROM players WHERE answer = 1 GROUP BY duel_id) AS yes_counts ON yes_counts.duel_id = duels.id").
+		Where("duels.owner_id IN (SELECT f.followee_id FROM follows AS f WHERE f.follower_id = ?)", followerID).
+		Where("duels.status = ?", model.DuelStatusInProcess)
+	// the newest duels go first unless the client orders them
+	if options == nil || !options.Order.IsValid() {
+		q = q.Order("duels.created_at DESC")
+	}
+	q = options.Apply(q)
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, err
+	}
+
+	return duels, nil
+}
+
 func (r *DuelRepository) GetDuelShowByID(
 	ctx context.Context,
 	userID uuid.UUID,
@@ -154,9 +189,12 @@ func (r *DuelRepository) GetHistoryByUserID(
 	return duels, nil
 }
 
+// GetUserDuels returns duels created by the owner, the player fields are those
+// of the viewer, uuid.Nil leaves them empty
 func (r *DuelRepository) GetUserDuels(
 	ctx context.Context,
-	userID uuid.UUID,
+	ownerID uuid.UUID,
+	viewerID uuid.UUID,
 	options *repository.Options,
 ) ([]model.DuelShow, error) {
 	duels := make([]model.DuelShow, 0)
@@ -171,9 +209,9 @@ func (r *DuelRepository) GetUserDuels(
 		ColumnExpr("duels.players_count - COALESCE(yes_counts.yes_count, 0) as no_count").
 		ColumnExpr("u.image_url AS owner_image_url").
 		Join("left join users u ON u.id = duels.owner_id").
-		Join("left join players p on p.duel_id = duels.id AND p.user_id = ?", userID).
+		Join("left join players p on p.duel_id = duels.id AND p.user_id = ?", viewerID).
 		Join("left join (select duel_id, COUNT(*) AS yes_count FROM players WHERE answer = 1 GROUP BY duel_id) AS yes_counts ON yes_counts.duel_id = duels.id").
-		Where("duels.owner_id = ?", userID)
+		Where("duels.owner_id = ?", ownerID)
 	q = options.Apply(q)
 
 	if err := q.Scan(ctx); err != nil {
@@ -224,11 +262,12 @@ func (r *DuelRepository) JoinDuel(
 	duel *model.Duel,
 ) (*model.Player, error) {
 	player := &model.Player{
-		ID:        uuid.New(),
-		UserID:    userID,
-		DuelID:    req.DuelID,
-		Answer:    req.Answer,
-		CreatedAt: time.Now(),
+		ID:            uuid.New(),
+		UserID:        userID,
+		DuelID:        req.DuelID,
+		WalletAddress: req.Wallet,
+		Answer:        req.Answer,
+		CreatedAt:     time.Now(),
 	}
 	_, err := r.DB.NewInsert().Model(player).Exec(ctx)
 	if err != nil {
@@ -317,6 +356,43 @@ func (r *DuelRepository) FindOwnerIDByDuelID(
 	return ownerID, nil
 }
 
+// GetStatus returns the status of the duel
+func (r *DuelRepository) GetStatus(ctx context.Context, duelID uuid.UUID) (uint8, error) {
+	var status uint8
+
+	err := r.DB.NewSelect().
+		Table("duels").
+		Column("status").
+		Where("id = ?", duelID).
+		Scan(ctx, &status)
+
+	return status, err
+}
+
+// GetOdds returns the public counters of the duel
+func (r *DuelRepository) GetOdds(
+	ctx context.Context,
+	duelID uuid.UUID,
+) (*model.DuelOdds, error) {
+	odds := new(model.DuelOdds)
+
+	err := r.DB.NewSelect().
+		Model((*model.Duel)(nil)).
+		ColumnExpr("duels.id AS duel_id, duels.players_count").
+		ColumnExpr("COUNT(p.user_id) FILTER (WHERE p.answer = 1) AS yes_count").
+		ColumnExpr("duels.players_count - COUNT(p.user_id) FILTER (WHERE p.answer = 1) AS no_count").
+		ColumnExpr("duels.players_count * duels.duel_price AS pool").
+		Join("LEFT JOIN players AS p ON p.duel_id = duels.id").
+		Where("duels.id = ?", duelID).
+		GroupExpr("duels.id").
+		Scan(ctx, odds)
+	if err != nil {
+		return nil, err
+	}
+
+	return odds, nil
+}
+
 func (r *DuelRepository) GetUserStats(
 	ctx context.Context,
 	userID uuid.UUID,
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/repository"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type DuelEventCreditRepository struct {
+	repository.Generic[model.DuelEventCredit, uuid.UUID]
+}
+
+func NewDuelEventCreditRepository(
+	genericRepository repository.Generic[model.DuelEventCredit, uuid.UUID],
+) *DuelEventCreditRepository {
+	return &DuelEventCreditRepository{
+		Generic: genericRepository,
+	}
+}
+
+func (r *DuelEventCreditRepository) WithTx(tx bun.Tx) *DuelEventCreditRepository {
+	return &DuelEventCreditRepository{Generic: r.Generic.WithTx(tx)}
+}
+
+// Credit reports whether the reward of the event is credited now rather than before,
+// the reward itself must be given in the same transaction
+func (r *DuelEventCreditRepository) Credit(ctx context.Context, credit *model.DuelEventCredit) (bool, error) {
+	res, err := r.DB.NewInsert().
+		Model(credit).
+		On("CONFLICT (user_id, duel_id, event_type, reward) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
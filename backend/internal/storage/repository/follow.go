@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/repository"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type FollowRepository struct {
+	repository.Generic[model.Follow, uuid.UUID]
+}
+
+func NewFollowRepository(
+	genericRepository repository.Generic[model.Follow, uuid.UUID],
+) *FollowRepository {
+	return &FollowRepository{
+		Generic: genericRepository,
+	}
+}
+
+func (r *FollowRepository) WithTx(tx bun.Tx) *FollowRepository {
+	return &FollowRepository{Generic: r.Generic.WithTx(tx)}
+}
+
+// Follow is idempotent, following a user twice is not an error
+func (r *FollowRepository) Follow(ctx context.Context, follow *model.Follow) error {
+	_, err := r.DB.NewInsert().
+		Model(follow).
+		On("CONFLICT (follower_id, followee_id) DO NOTHING").
+		Exec(ctx)
+
+	return err
+}
+
+// Unfollow reports whether the follower followed the followee
+func (r *FollowRepository) Unfollow(ctx context.Context, followerID, followeeID uuid.UUID) (bool, error) {
+	res, err := r.DB.NewDelete().
+		Model((*model.Follow)(nil)).
+		Where("follower_id = ?", followerID).
+		Where("followee_id = ?", followeeID).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+func (r *FollowRepository) GetCounts(ctx context.Context, userID uuid.UUID) (*model.FollowCounts, error) {
+	counts := new(model.FollowCounts)
+
+	err := r.DB.NewSelect().
+		ColumnExpr("(SELECT COUNT(*) FROM follows WHERE followee_id = ?)::bigint AS followers", userID).
+		ColumnExpr("(SELECT COUNT(*) FROM follows WHERE follower_id = ?)::bigint AS following", userID).
+		Scan(ctx, counts)
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
+
+func (r *FollowRepository) GetFollowerIDs(ctx context.Context, followeeID uuid.UUID) ([]uuid.UUID, error) {
+	followerIDs := make([]uuid.UUID, 0)
+
+	err := r.DB.NewSelect().
+		Model((*model.Follow)(nil)).
+		Column("follower_id").
+		Where("followee_id = ?", followeeID).
+		Scan(ctx, &followerIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return followerIDs, nil
+}
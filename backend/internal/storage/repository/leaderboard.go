@@ -0,0 +1,206 @@
+package repository
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type LeaderboardRepository struct {
+	repository.Generic[model.LeaderboardStats, string]
+}
+
+func NewLeaderboardRepository(
+	genericRepository repository.Generic[model.LeaderboardStats, string],
+) *LeaderboardRepository {
+	return &LeaderboardRepository{
+		Generic: genericRepository,
+	}
+}
+
+func (r *LeaderboardRepository) WithTx(tx bun.Tx) *LeaderboardRepository {
+	return &LeaderboardRepository{Generic: r.Generic.WithTx(tx)}
+}
+
+// refreshLeaderboardQuery aggregates duels resolved since the given time. Net profit,
+// wins and volume rank players, win rate ranks players with enough duels and
+// commission ranks creators, users who do not qualify for a metric get no rank.
+const refreshLeaderboardQuery = `
+WITH player_stats AS (
+    SELECT p.user_id,
+           COUNT(*)                                                      AS participated,
+           COUNT(*) FILTER (WHERE p.is_winner)                           AS wins,
+           SUM(CASE WHEN p.is_winner THEN p.win_amount ELSE 0 END - d.duel_price) AS net_profit,
+           SUM(d.duel_price)                                             AS volume
+    FROM players AS p
+             JOIN duels AS d ON d.id = p.duel_id
+    WHERE p.final_status = ?
+      AND d.status = ?
+      AND d.resolved_at >= ?
+    GROUP BY p.user_id
+),
+     creator_stats AS (
+         SELECT d.owner_id AS user_id, SUM(d.creator_commission) AS commission
+         FROM duels AS d
+         WHERE d.status = ?
+           AND d.resolved_at >= ?
+         GROUP BY d.owner_id
+     ),
+     stats AS (
+         SELECT COALESCE(ps.user_id, cs.user_id) AS user_id,
+                COALESCE(ps.participated, 0)     AS participated,
+                COALESCE(ps.wins, 0)             AS wins,
+                COALESCE(ps.net_profit, 0)       AS net_profit,
+                CASE
+                    WHEN ps.participated >= ? THEN ps.wins::double precision / ps.participated
+                    END                          AS win_rate,
+                COALESCE(ps.volume, 0)           AS volume,
+                COALESCE(cs.commission, 0)       AS commission
+         FROM player_stats AS ps
+                  FULL OUTER JOIN creator_stats AS cs ON cs.user_id = ps.user_id
+     )
+INSERT
+INTO leaderboard_stats (period, user_id, participated, wins, net_profit, win_rate, volume, commission,
+                        net_profit_rank, wins_rank, win_rate_rank, volume_rank, commission_rank, refreshed_at)
+SELECT ?,
+       user_id,
+       participated,
+       wins,
+       net_profit,
+       win_rate,
+       volume,
+       commission,
+       CASE WHEN participated > 0 THEN RANK() OVER (PARTITION BY participated > 0 ORDER BY net_profit DESC) END,
+       CASE WHEN participated > 0 THEN RANK() OVER (PARTITION BY participated > 0 ORDER BY wins DESC) END,
+       CASE WHEN win_rate IS NOT NULL THEN RANK() OVER (PARTITION BY win_rate IS NOT NULL ORDER BY win_rate DESC) END,
+       CASE WHEN participated > 0 THEN RANK() OVER (PARTITION BY participated > 0 ORDER BY volume DESC) END,
+       CASE WHEN commission > 0 THEN RANK() OVER (PARTITION BY commission > 0 ORDER BY commission DESC) END,
+       ?
+FROM stats`
+
+// leaderboardRefreshLock is the advisory lock key held by a refresh, so replicas never refresh at once
+const leaderboardRefreshLock = 7_311_410_013
+
+// TryLockRefresh takes the refresh lock until the transaction ends, it reports false if another refresh holds it
+func (r *LeaderboardRepository) TryLockRefresh(ctx context.Context) (bool, error) {
+	var locked bool
+
+	err := r.DB.NewRaw("SELECT pg_try_advisory_xact_lock(?)", leaderboardRefreshLock).Scan(ctx, &locked)
+	if err != nil {
+		return false, err
+	}
+
+	return locked, nil
+}
+
+// Refresh replaces the leaderboard of the period, call it within a transaction
+// so readers never see an empty leaderboard
+func (r *LeaderboardRepository) Refresh(
+	ctx context.Context,
+	period model.LeaderboardPeriod,
+	since time.Time,
+	minDuels int,
+	refreshedAt time.Time,
+) error {
+	_, err := r.DB.NewDelete().
+		Model((*model.LeaderboardStats)(nil)).
+		Where("period = ?", period).
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.DB.NewRaw(refreshLeaderboardQuery,
+		model.PlayerStatusResolved,
+		model.DuelStatusResolved,
+		since,
+		model.DuelStatusResolved,
+		since,
+		minDuels,
+		period,
+		refreshedAt,
+	).Exec(ctx)
+
+	return err
+}
+
+func (r *LeaderboardRepository) GetEntries(
+	ctx context.Context,
+	period model.LeaderboardPeriod,
+	metric model.LeaderboardMetric,
+	limit, offset int,
+) ([]model.LeaderboardEntry, error) {
+	entries := make([]model.LeaderboardEntry, 0, limit)
+
+	err := r.entriesQuery(period, metric).
+		Where("? IS NOT NULL", bun.Ident("ls."+metric.RankColumn())).
+		OrderExpr("? ASC, ls.user_id ASC", bun.Ident("ls."+metric.RankColumn())).
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx, &entries)
+	if err != nil {
+		return nil, err
+	}
+
+	return entries, nil
+}
+
+// GetUserEntry returns nil if the user is not ranked by the metric
+func (r *LeaderboardRepository) GetUserEntry(
+	ctx context.Context,
+	period model.LeaderboardPeriod,
+	metric model.LeaderboardMetric,
+	userID uuid.UUID,
+) (*model.LeaderboardEntry, error) {
+	entry := new(model.LeaderboardEntry)
+
+	err := r.entriesQuery(period, metric).
+		Where("ls.user_id = ?", userID).
+		Where("? IS NOT NULL", bun.Ident("ls."+metric.RankColumn())).
+		Scan(ctx, entry)
+	if err != nil {
+		if repository.IsErrNoRows(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// GetRefreshedAt returns nil if the leaderboard of the period was never computed
+func (r *LeaderboardRepository) GetRefreshedAt(
+	ctx context.Context,
+	period model.LeaderboardPeriod,
+) (*time.Time, error) {
+	var refreshedAt *time.Time
+
+	err := r.DB.NewSelect().
+		Model((*model.LeaderboardStats)(nil)).
+		ColumnExpr("MAX(refreshed_at)").
+		Where("period = ?", period).
+		Scan(ctx, &refreshedAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return refreshedAt, nil
+}
+
+func (r *LeaderboardRepository) entriesQuery(
+	period model.LeaderboardPeriod,
+	metric model.LeaderboardMetric,
+) *bun.SelectQuery {
+	return r.DB.NewSelect().
+		Model((*model.LeaderboardStats)(nil)).
+		ColumnExpr("? AS rank", bun.Ident("ls."+metric.RankColumn())).
+		ColumnExpr("COALESCE(?, 0)::double precision AS value", bun.Ident("ls."+string(metric))).
+		ColumnExpr("ls.user_id, ls.participated, ls.wins, ls.net_profit, ls.win_rate, ls.volume, ls.commission").
+		ColumnExpr("u.username, u.image_url").
+		Join("JOIN users AS u ON u.id = ls.user_id").
+		Where("ls.period = ?", period)
+}
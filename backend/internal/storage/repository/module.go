@@ -44,7 +44,44 @@ func Module() fx.Option {
 			NewNotificationRepository,
 		),
 		fx.Provide(
-			NewFileRepository,
+			repository.NewGenericRepository[model.TrackedSignature, uuid.UUID],
+			NewTrackedSignatureRepository,
+		),
+		fx.Provide(
+			repository.NewGenericRepository[model.UserWallet, uuid.UUID],
+			NewUserWalletRepository,
+		),
+		fx.Provide(
+			repository.NewGenericRepository[model.APIKey, uuid.UUID],
+			NewAPIKeyRepository,
+		),
+		fx.Provide(
+			repository.NewGenericRepository[model.Upload, uuid.UUID],
+			NewUploadRepository,
+		),
+		fx.Provide(
+			repository.NewGenericRepository[model.Follow, uuid.UUID],
+			NewFollowRepository,
+		),
+		fx.Provide(
+			repository.NewGenericRepository[model.LeaderboardStats, string],
+			NewLeaderboardRepository,
+		),
+		fx.Provide(
+			repository.NewGenericRepository[model.UserAchievement, uuid.UUID],
+			NewAchievementRepository,
+		),
+		fx.Provide(
+			repository.NewGenericRepository[model.Season, uuid.UUID],
+			NewSeasonRepository,
+		),
+		fx.Provide(
+			repository.NewGenericRepository[model.Comment, uuid.UUID],
+			NewCommentRepository,
+		),
+		fx.Provide(
+			repository.NewGenericRepository[model.DuelEventCredit, uuid.UUID],
+			NewDuelEventCreditRepository,
 		),
 	)
 }
@@ -212,7 +212,7 @@ func (r *PlayerRepository) GetByUserID(
 	return player, nil
 }
 
-// --- crypto helpers (адреса берём из users) ---
+// --- crypto helpers (адрес кошелька, которым платил игрок, иначе основной из users) ---
 
 func (r *PlayerRepository) GetCryptoDuelWinners(
 	ctx context.Context,
@@ -226,7 +226,7 @@ func (r *PlayerRepository) GetCryptoDuelWinners(
 		ColumnExpr("players.id").
 		ColumnExpr("players.user_id").
 		ColumnExpr("players.answer").
-		ColumnExpr("u.public_address").
+		ColumnExpr("COALESCE(players.wallet_address, u.public_address) AS public_address").
 		Join("left join users AS u on players.user_id = u.id").
 		Where("players.duel_id = ?", duelID).
 		Where("players.answer = ?", answer).
@@ -250,7 +250,7 @@ func (r *PlayerRepository) GetCryptoDuelPlayers(
 		ColumnExpr("players.id").
 		ColumnExpr("players.user_id").
 		ColumnExpr("players.answer").
-		ColumnExpr("u.public_address").
+		ColumnExpr("COALESCE(players.wallet_address, u.public_address) AS public_address").
 		Join("left join users AS u on players.user_id = u.id").
 		Where("players.duel_id = ?", duelID).
 		Scan(ctx)
@@ -273,7 +273,7 @@ func (r *PlayerRepository) GetDuelPlayersToRefund(
 		ColumnExpr("players.id").
 		ColumnExpr("players.user_id").
 		ColumnExpr("players.answer").
-		ColumnExpr("u.public_address").
+		ColumnExpr("COALESCE(players.wallet_address, u.public_address) AS public_address").
 		Join("left join users AS u on players.user_id = u.id").
 		Where("players.duel_id = ?", duelID).
 		Where("players.final_status = ?", model.PlayerStatusActive).
@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type SeasonRepository struct {
+	repository.Generic[model.Season, uuid.UUID]
+}
+
+func NewSeasonRepository(
+	genericRepository repository.Generic[model.Season, uuid.UUID],
+) *SeasonRepository {
+	return &SeasonRepository{
+		Generic: genericRepository,
+	}
+}
+
+func (r *SeasonRepository) WithTx(tx bun.Tx) *SeasonRepository {
+	return &SeasonRepository{Generic: r.Generic.WithTx(tx)}
+}
+
+// GetRunning returns nil if no season is running at the time
+func (r *SeasonRepository) GetRunning(ctx context.Context, at time.Time) (*model.Season, error) {
+	season := new(model.Season)
+
+	err := r.DB.NewSelect().
+		Model(season).
+		Where("s.starts_at <= ?", at).
+		Where("s.ends_at > ?", at).
+		Order("s.starts_at DESC").
+		Limit(1).
+		Scan(ctx)
+	if err != nil {
+		if repository.IsErrNoRows(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return season, nil
+}
+
+// Overlaps reports whether any season intersects the period
+func (r *SeasonRepository) Overlaps(ctx context.Context, startsAt, endsAt time.Time) (bool, error) {
+	return r.DB.NewSelect().
+		Model((*model.Season)(nil)).
+		Where("s.starts_at < ?", endsAt).
+		Where("s.ends_at > ?", startsAt).
+		Exists(ctx)
+}
+
+// GetPast returns seasons ended before the time, the latest first
+func (r *SeasonRepository) GetPast(ctx context.Context, before time.Time, limit, offset int) ([]model.Season, error) {
+	seasons := make([]model.Season, 0, limit)
+
+	err := r.DB.NewSelect().
+		Model(&seasons).
+		Where("s.ends_at <= ?", before).
+		Order("s.ends_at DESC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return seasons, nil
+}
+
+// GetUnsettled returns ended seasons whose prizes are not paid out yet, the oldest first
+func (r *SeasonRepository) GetUnsettled(ctx context.Context, before time.Time) ([]model.Season, error) {
+	seasons := make([]model.Season, 0)
+
+	err := r.DB.NewSelect().
+		Model(&seasons).
+		Where("s.ends_at <= ?", before).
+		Where("s.status != ?", model.SeasonStatusSettled).
+		Order("s.ends_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return seasons, nil
+}
+
+func (r *SeasonRepository) UpdateStatus(
+	ctx context.Context,
+	seasonID uuid.UUID,
+	status uint8,
+	settledAt *time.Time,
+) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.Season)(nil)).
+		Set("status = ?", status).
+		Set("settled_at = ?", settledAt).
+		Where("id = ?", seasonID).
+		Exec(ctx)
+
+	return err
+}
+
+// AddPoints creates the standing of the user in the season on the first event
+func (r *SeasonRepository) AddPoints(
+	ctx context.Context,
+	seasonID, userID uuid.UUID,
+	points, participated, wins uint64,
+) error {
+	standing := &model.SeasonPoints{
+		SeasonID:     seasonID,
+		UserID:       userID,
+		Points:       points,
+		Participated: participated,
+		Wins:         wins,
+		UpdatedAt:    time.Now().UTC(),
+	}
+
+	_, err := r.DB.NewInsert().
+		Model(standing).
+		On("CONFLICT (season_id, user_id) DO UPDATE").
+		Set("points = sp.points + EXCLUDED.points").
+		Set("participated = sp.participated + EXCLUDED.participated").
+		Set("wins = sp.wins + EXCLUDED.wins").
+		Set("updated_at = EXCLUDED.updated_at").
+		Exec(ctx)
+
+	return err
+}
+
+func (r *SeasonRepository) GetStandings(
+	ctx context.Context,
+	seasonID uuid.UUID,
+	limit, offset int,
+) ([]model.SeasonStanding, error) {
+	standings := make([]model.SeasonStanding, 0, limit)
+
+	err := r.DB.NewSelect().
+		Model((*model.SeasonPoints)(nil)).
+		ColumnExpr("ROW_NUMBER() OVER (ORDER BY sp.points DESC, sp.wins DESC, sp.updated_at ASC, sp.user_id ASC) AS rank").
+		ColumnExpr("sp.user_id, sp.points, sp.participated, sp.wins").
+		ColumnExpr("u.username, u.image_url, u.public_address").
+		Join("JOIN users AS u ON u.id = sp.user_id").
+		Where("sp.season_id = ?", seasonID).
+		Where("sp.points > 0").
+		OrderExpr("rank ASC").
+		Limit(limit).
+		Offset(offset).
+		Scan(ctx, &standings)
+	if err != nil {
+		return nil, err
+	}
+
+	return standings, nil
+}
+
+// CreatePrizes keeps prizes computed by a previous run, so they are computed once per season
+func (r *SeasonRepository) CreatePrizes(ctx context.Context, prizes []model.SeasonPrize) error {
+	if len(prizes) == 0 {
+		return nil
+	}
+
+	_, err := r.DB.NewInsert().
+		Model(&prizes).
+		On("CONFLICT (season_id, user_id) DO NOTHING").
+		Exec(ctx)
+
+	return err
+}
+
+func (r *SeasonRepository) GetPrizes(ctx context.Context, seasonID uuid.UUID) ([]model.SeasonPrize, error) {
+	prizes := make([]model.SeasonPrize, 0)
+
+	err := r.DB.NewSelect().
+		Model(&prizes).
+		ColumnExpr("spz.*").
+		ColumnExpr("u.username").
+		Join("JOIN users AS u ON u.id = spz.user_id").
+		Where("spz.season_id = ?", seasonID).
+		Order("spz.rank ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return prizes, nil
+}
+
+// GetUnpaidPrizes returns pending, failed and sending prizes of the season
+func (r *SeasonRepository) GetUnpaidPrizes(ctx context.Context, seasonID uuid.UUID) ([]model.SeasonPrize, error) {
+	prizes := make([]model.SeasonPrize, 0)
+
+	err := r.DB.NewSelect().
+		Model(&prizes).
+		Where("spz.season_id = ?", seasonID).
+		Where("spz.status != ?", model.SeasonPrizeStatusPaid).
+		Order("spz.rank ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return prizes, nil
+}
+
+// ClaimPrize moves a claimable prize to sending under the given claim,
+// it returns false when another payout holds the prize. The condition mirrors SeasonPrize.Claimable
+func (r *SeasonRepository) ClaimPrize(
+	ctx context.Context,
+	prize *model.SeasonPrize,
+	claim string,
+	lease time.Duration,
+) (bool, error) {
+	res, err := r.DB.NewUpdate().
+		Model((*model.SeasonPrize)(nil)).
+		Set("status = ?", model.SeasonPrizeStatusSending).
+		Set("claimed_by = ?", claim).
+		Set("claimed_at = ?", time.Now().UTC()).
+		Set("tx_signature = NULL").
+		Set("last_valid_block_height = NULL").
+		Where("season_id = ?", prize.SeasonID).
+		Where("user_id = ?", prize.UserID).
+		Where("(status IN (?) OR (status = ? AND tx_signature IS NULL AND claimed_at < ?))",
+			bun.In([]uint8{model.SeasonPrizeStatusPending, model.SeasonPrizeStatusFailed}),
+			model.SeasonPrizeStatusSending,
+			time.Now().UTC().Add(-lease),
+		).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// SetPrizeTransfer stores the signed transfer of a claimed prize before it is sent,
+// it returns false when the claim is lost
+func (r *SeasonRepository) SetPrizeTransfer(
+	ctx context.Context,
+	prize *model.SeasonPrize,
+	signature string,
+	lastValidBlockHeight uint64,
+) (bool, error) {
+	res, err := r.DB.NewUpdate().
+		Model((*model.SeasonPrize)(nil)).
+		Set("tx_signature = ?", signature).
+		Set("last_valid_block_height = ?", lastValidBlockHeight).
+		Where("season_id = ?", prize.SeasonID).
+		Where("user_id = ?", prize.UserID).
+		Where("status = ?", model.SeasonPrizeStatusSending).
+		Where("claimed_by = ?", prize.ClaimedBy).
+		Where("tx_signature IS NULL").
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// MarkPrizePaid completes a sending prize whose transfer is confirmed,
+// it returns false when the prize was completed already
+func (r *SeasonRepository) MarkPrizePaid(ctx context.Context, prize *model.SeasonPrize) (bool, error) {
+	res, err := r.DB.NewUpdate().
+		Model((*model.SeasonPrize)(nil)).
+		Set("status = ?", model.SeasonPrizeStatusPaid).
+		Set("error = NULL").
+		Set("paid_at = ?", time.Now().UTC()).
+		Where("season_id = ?", prize.SeasonID).
+		Where("user_id = ?", prize.UserID).
+		Where("status = ?", model.SeasonPrizeStatusSending).
+		Where("claimed_by = ?", prize.ClaimedBy).
+		Where("tx_signature = ?", prize.TxSignature).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// MarkPrizeFailed releases a sending prize whose transfer can never land, so it is sent again
+func (r *SeasonRepository) MarkPrizeFailed(
+	ctx context.Context,
+	prize *model.SeasonPrize,
+	reason string,
+) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.SeasonPrize)(nil)).
+		Set("status = ?", model.SeasonPrizeStatusFailed).
+		Set("error = ?", reason).
+		Where("season_id = ?", prize.SeasonID).
+		Where("user_id = ?", prize.UserID).
+		Where("status = ?", model.SeasonPrizeStatusSending).
+		Where("claimed_by = ?", prize.ClaimedBy).
+		Exec(ctx)
+
+	return err
+}
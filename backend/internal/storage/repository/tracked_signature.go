@@ -0,0 +1,181 @@
+package repository
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type TrackedSignatureRepository struct {
+	repository.Generic[model.TrackedSignature, uuid.UUID]
+}
+
+func NewTrackedSignatureRepository(
+	genericRepository repository.Generic[model.TrackedSignature, uuid.UUID],
+) *TrackedSignatureRepository {
+	return &TrackedSignatureRepository{
+		Generic: genericRepository,
+	}
+}
+
+func (r *TrackedSignatureRepository) WithTx(tx bun.Tx) *TrackedSignatureRepository {
+	return &TrackedSignatureRepository{Generic: r.Generic.WithTx(tx)}
+}
+
+// CreateOrGet stores the tracked signature, or returns the already stored one with the same signature
+func (r *TrackedSignatureRepository) CreateOrGet(
+	ctx context.Context,
+	tracked *model.TrackedSignature,
+) (*model.TrackedSignature, error) {
+	_, err := r.DB.NewInsert().
+		Model(tracked).
+		On("CONFLICT (signature) DO NOTHING").
+		Exec(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.GetBySignature(ctx, tracked.Signature)
+}
+
+func (r *TrackedSignatureRepository) GetBySignature(
+	ctx context.Context,
+	signature string,
+) (*model.TrackedSignature, error) {
+	tracked := new(model.TrackedSignature)
+
+	err := r.DB.NewSelect().
+		Model(tracked).
+		Where("signature = ?", signature).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tracked, nil
+}
+
+func (r *TrackedSignatureRepository) GetUserSignature(
+	ctx context.Context,
+	userID uuid.UUID,
+	id uuid.UUID,
+) (*model.TrackedSignature, error) {
+	tracked := new(model.TrackedSignature)
+
+	err := r.DB.NewSelect().
+		Model(tracked).
+		Where("id = ?", id).
+		Where("user_id = ?", userID).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tracked, nil
+}
+
+// GetPending returns pending signatures that are not claimed by a live instance
+func (r *TrackedSignatureRepository) GetPending(
+	ctx context.Context,
+) ([]*model.TrackedSignature, error) {
+	tracked := make([]*model.TrackedSignature, 0)
+
+	err := r.DB.NewSelect().
+		Model(&tracked).
+		Where("status = ?", model.SignatureStatusPending).
+		Where("(claimed_until IS NULL OR claimed_until < now())").
+		Order("created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return tracked, nil
+}
+
+// Claim leases the pending signature to the instance, it reports false if the signature
+// is no longer pending or another instance holds an unexpired lease. Claiming again extends the lease
+func (r *TrackedSignatureRepository) Claim(
+	ctx context.Context,
+	id uuid.UUID,
+	owner string,
+	lease time.Duration,
+) (bool, error) {
+	res, err := r.DB.NewUpdate().
+		Model((*model.TrackedSignature)(nil)).
+		Set("claimed_by = ?", owner).
+		Set("claimed_until = now() + ? * interval '1 millisecond'", lease.Milliseconds()).
+		Where("id = ?", id).
+		Where("status = ?", model.SignatureStatusPending).
+		Where("(claimed_until IS NULL OR claimed_until < now() OR claimed_by = ?)", owner).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// Release drops the lease of the instance on a pending signature, so the next resume retries it
+func (r *TrackedSignatureRepository) Release(ctx context.Context, id uuid.UUID, owner string) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.TrackedSignature)(nil)).
+		Set("claimed_until = NULL").
+		Where("id = ?", id).
+		Where("status = ?", model.SignatureStatusPending).
+		Where("claimed_by = ?", owner).
+		Exec(ctx)
+
+	return err
+}
+
+// UpdateStatus completes the pending signature, it reports false if it was completed already
+func (r *TrackedSignatureRepository) UpdateStatus(
+	ctx context.Context,
+	tracked *model.TrackedSignature,
+) (bool, error) {
+	tracked.UpdatedAt = time.Now()
+
+	res, err := r.DB.NewUpdate().
+		Model(tracked).
+		Column("status", "stage", "slot", "error", "updated_at").
+		WherePK().
+		Where("status = ?", model.SignatureStatusPending).
+		Exec(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	affected, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return affected > 0, nil
+}
+
+// UpdateStage moves the stage of a signature that is still pending
+func (r *TrackedSignatureRepository) UpdateStage(
+	ctx context.Context,
+	tracked *model.TrackedSignature,
+) error {
+	tracked.UpdatedAt = time.Now()
+
+	_, err := r.DB.NewUpdate().
+		Model(tracked).
+		Column("stage", "updated_at").
+		WherePK().
+		Where("status = ?", model.SignatureStatusPending).
+		Exec(ctx)
+
+	return err
+}
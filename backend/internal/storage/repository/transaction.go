@@ -72,3 +72,14 @@ func (r *TransactionRepository) GetTransactionsBySignatures(
 	}
 	return items, nil
 }
+
+func (r *TransactionRepository) DeleteBySignature(
+	ctx context.Context,
+	signature string,
+) error {
+	_, err := r.DB.NewDelete().
+		Model((*model.TransactionType)(nil)).
+		Where("signature = ?", signature).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,99 @@
+package repository
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/repository"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type UploadRepository struct {
+	repository.Generic[model.Upload, uuid.UUID]
+}
+
+func NewUploadRepository(
+	genericRepository repository.Generic[model.Upload, uuid.UUID],
+) *UploadRepository {
+	return &UploadRepository{
+		Generic: genericRepository,
+	}
+}
+
+func (r *UploadRepository) WithTx(tx bun.Tx) *UploadRepository {
+	return &UploadRepository{Generic: r.Generic.WithTx(tx)}
+}
+
+func (r *UploadRepository) GetByKeys(ctx context.Context, keys []string) ([]model.Upload, error) {
+	uploads := make([]model.Upload, 0, len(keys))
+
+	err := r.DB.NewSelect().
+		Model(&uploads).
+		Where("object_key IN (?)", bun.In(keys)).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
+
+// Bind marks the uploads as referenced, so they are never collected
+func (r *UploadRepository) Bind(ctx context.Context, keys []string) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.Upload)(nil)).
+		Set("state = ?", model.UploadStateBound).
+		Set("bound_at = ?", time.Now().UTC()).
+		Where("object_key IN (?)", bun.In(keys)).
+		Where("state = ?", model.UploadStatePending).
+		Exec(ctx)
+
+	return err
+}
+
+// Release marks the uploads as unreferenced again, so they are collected after the orphan ttl
+// that starts over. Keys still used by a profile or by a duel that is not cancelled stay bound
+func (r *UploadRepository) Release(ctx context.Context, keys []string) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.Upload)(nil)).
+		Set("state = ?", model.UploadStatePending).
+		Set("bound_at = NULL").
+		Set("created_at = ?", time.Now().UTC()).
+		Where("object_key IN (?)", bun.In(keys)).
+		Where("state = ?", model.UploadStateBound).
+		Where("NOT EXISTS (SELECT 1 FROM users u WHERE u.image_url = up.object_key)").
+		Where("NOT EXISTS (SELECT 1 FROM duels d WHERE (d.image_url = up.object_key OR d.bg_url = up.object_key) AND d.status NOT IN (?))",
+			bun.In([]uint8{model.DuelStatusAutoCancelled, model.DuelStatusAdminCancelled})).
+		Exec(ctx)
+
+	return err
+}
+
+// GetOrphaned returns pending uploads created before the cutoff, the oldest first
+func (r *UploadRepository) GetOrphaned(ctx context.Context, cutoff time.Time, limit int) ([]model.Upload, error) {
+	uploads := make([]model.Upload, 0, limit)
+
+	err := r.DB.NewSelect().
+		Model(&uploads).
+		Where("state = ?", model.UploadStatePending).
+		Where("created_at < ?", cutoff).
+		Order("created_at ASC").
+		Limit(limit).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return uploads, nil
+}
+
+func (r *UploadRepository) DeleteByKey(ctx context.Context, key string) error {
+	_, err := r.DB.NewDelete().
+		Model((*model.Upload)(nil)).
+		Where("object_key = ?", key).
+		Exec(ctx)
+
+	return err
+}
@@ -24,6 +24,7 @@ func NewUserRepository(
 	}
 }
 
+// GetByPublicAddress returns the user any of whose linked wallets has the address
 func (r *UserRepository) GetByPublicAddress(
 	ctx context.Context,
 	address string,
@@ -32,7 +33,7 @@ func (r *UserRepository) GetByPublicAddress(
 
 	err := r.DB.NewSelect().
 		Model(user).
-		Where("public_address = ?", address).
+		Where("u.id = (SELECT uw.user_id FROM user_wallets AS uw WHERE uw.address = ?)", address).
 		Scan(ctx)
 	if err != nil {
 		if repository.IsErrNoRows(err) {
@@ -43,3 +44,114 @@ func (r *UserRepository) GetByPublicAddress(
 
 	return user, nil
 }
+
+func (r *UserRepository) UpdatePublicAddress(
+	ctx context.Context,
+	userID uuid.UUID,
+	address string,
+) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.User)(nil)).
+		Set("public_address = ?", address).
+		Set("updated_at = current_timestamp").
+		Where("id = ?", userID).
+		Exec(ctx)
+
+	return err
+}
+
+func (r *UserRepository) GetByUsername(
+	ctx context.Context,
+	username string,
+) (*model.User, error) {
+	var user = new(model.User)
+
+	err := r.DB.NewSelect().
+		Model(user).
+		Where("u.username = ?", username).
+		Scan(ctx)
+	if err != nil {
+		if repository.IsErrNoRows(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UpdateProfile sets the public profile fields, empty values clear them
+func (r *UserRepository) UpdateProfile(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *model.UpdateProfileReq,
+) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.User)(nil)).
+		Set("bio = ?", req.Bio).
+		Set("social_links = ?", req.SocialLinks).
+		Set("updated_at = current_timestamp").
+		Where("id = ?", userID).
+		Exec(ctx)
+
+	return err
+}
+
+func (r *UserRepository) UpdatePrivacy(
+	ctx context.Context,
+	userID uuid.UUID,
+	req *model.UpdatePrivacyReq,
+) error {
+	_, err := r.DB.NewUpdate().
+		Model((*model.User)(nil)).
+		Set("hide_stats = ?", req.HideStats).
+		Set("hide_history = ?", req.HideHistory).
+		Set("updated_at = current_timestamp").
+		Where("id = ?", userID).
+		Exec(ctx)
+
+	return err
+}
+
+// AddXP returns the XP of the user after the increment
+func (r *UserRepository) AddXP(
+	ctx context.Context,
+	userID uuid.UUID,
+	xp uint64,
+) (uint64, error) {
+	var total uint64
+
+	err := r.DB.NewUpdate().
+		Model((*model.User)(nil)).
+		Set("xp = xp + ?", xp).
+		Where("id = ?", userID).
+		Returning("xp").
+		Scan(ctx, &total)
+	if err != nil {
+		return 0, err
+	}
+
+	return total, nil
+}
+
+// UpdateWinStreak extends the streak of the user on a win and resets it on a loss,
+// it returns the streak after the update
+func (r *UserRepository) UpdateWinStreak(
+	ctx context.Context,
+	userID uuid.UUID,
+	won bool,
+) (uint64, error) {
+	var streak uint64
+
+	err := r.DB.NewUpdate().
+		Model((*model.User)(nil)).
+		Set("win_streak = CASE WHEN ? THEN win_streak + 1 ELSE 0 END", won).
+		Where("id = ?", userID).
+		Returning("win_streak").
+		Scan(ctx, &streak)
+	if err != nil {
+		return 0, err
+	}
+
+	return streak, nil
+}
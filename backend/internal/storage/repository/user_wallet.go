@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"duels-api/internal/model"
+	"duels-api/pkg/repository"
+
+	"github.com/google/uuid"
+	"github.com/uptrace/bun"
+)
+
+type UserWalletRepository struct {
+	repository.Generic[model.UserWallet, uuid.UUID]
+}
+
+func NewUserWalletRepository(
+	genericRepository repository.Generic[model.UserWallet, uuid.UUID],
+) *UserWalletRepository {
+	return &UserWalletRepository{
+		Generic: genericRepository,
+	}
+}
+
+func (r *UserWalletRepository) WithTx(tx bun.Tx) *UserWalletRepository {
+	return &UserWalletRepository{Generic: r.Generic.WithTx(tx)}
+}
+
+func (r *UserWalletRepository) GetUserWallets(
+	ctx context.Context,
+	userID uuid.UUID,
+) ([]model.UserWallet, error) {
+	wallets := make([]model.UserWallet, 0)
+
+	err := r.DB.NewSelect().
+		Model(&wallets).
+		Where("user_id = ?", userID).
+		Order("is_primary DESC", "created_at ASC").
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return wallets, nil
+}
+
+func (r *UserWalletRepository) GetUserWallet(
+	ctx context.Context,
+	userID uuid.UUID,
+	address string,
+) (*model.UserWallet, error) {
+	wallet := new(model.UserWallet)
+
+	err := r.DB.NewSelect().
+		Model(wallet).
+		Where("user_id = ?", userID).
+		Where("address = ?", address).
+		Scan(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return wallet, nil
+}
+
+func (r *UserWalletRepository) SetPrimary(
+	ctx context.Context,
+	userID uuid.UUID,
+	address string,
+) error {
+	// reset first, the partial unique index allows one primary wallet per user
+	_, err := r.DB.NewUpdate().
+		Model((*model.UserWallet)(nil)).
+		Set("is_primary = FALSE").
+		Where("user_id = ?", userID).
+		Where("is_primary").
+		Exec(ctx)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.DB.NewUpdate().
+		Model((*model.UserWallet)(nil)).
+		Set("is_primary = TRUE").
+		Where("user_id = ?", userID).
+		Where("address = ?", address).
+		Exec(ctx)
+
+	return err
+}
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS uploads_pending_idx;
+DROP INDEX IF EXISTS uploads_object_key_uq;
+
+DROP TABLE IF EXISTS uploads;
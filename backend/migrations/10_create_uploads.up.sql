@@ -0,0 +1,31 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS uploads
+(
+    id         UUID PRIMARY KEY,
+    user_id    UUID         NOT NULL,
+    object_key VARCHAR(255) NOT NULL,
+    state      SMALLINT     NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    bound_at   TIMESTAMPTZ  NULL,
+
+    CONSTRAINT uploads_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS uploads_object_key_uq ON uploads (object_key);
+CREATE INDEX IF NOT EXISTS uploads_pending_idx ON uploads (created_at) WHERE state = 0;
+
+-- files referenced before uploads were registered are bound to their owners
+INSERT INTO uploads (id, user_id, object_key, state, created_at, bound_at)
+SELECT uuid_generate_v4(), id, image_url, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM users
+WHERE image_url LIKE '/user_uploads/%'
+UNION ALL
+SELECT uuid_generate_v4(), owner_id, image_url, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM duels
+WHERE image_url LIKE '/user_uploads/%'
+UNION ALL
+SELECT uuid_generate_v4(), owner_id, bg_url, 1, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP
+FROM duels
+WHERE bg_url LIKE '/user_uploads/%'
+ON CONFLICT DO NOTHING;
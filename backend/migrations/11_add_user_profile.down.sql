@@ -0,0 +1,5 @@
+ALTER TABLE users
+    DROP COLUMN IF EXISTS hide_history,
+    DROP COLUMN IF EXISTS hide_stats,
+    DROP COLUMN IF EXISTS social_links,
+    DROP COLUMN IF EXISTS bio;
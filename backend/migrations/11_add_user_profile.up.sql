@@ -0,0 +1,5 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS bio          VARCHAR(280) NOT NULL DEFAULT '',
+    ADD COLUMN IF NOT EXISTS social_links JSONB        NOT NULL DEFAULT '{}'::jsonb,
+    ADD COLUMN IF NOT EXISTS hide_stats   BOOLEAN      NOT NULL DEFAULT FALSE,
+    ADD COLUMN IF NOT EXISTS hide_history BOOLEAN      NOT NULL DEFAULT FALSE;
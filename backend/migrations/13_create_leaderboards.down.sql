@@ -0,0 +1,13 @@
+DROP INDEX IF EXISTS leaderboard_stats_commission_rank_idx;
+DROP INDEX IF EXISTS leaderboard_stats_volume_rank_idx;
+DROP INDEX IF EXISTS leaderboard_stats_win_rate_rank_idx;
+DROP INDEX IF EXISTS leaderboard_stats_wins_rank_idx;
+DROP INDEX IF EXISTS leaderboard_stats_net_profit_rank_idx;
+
+DROP TABLE IF EXISTS leaderboard_stats;
+
+DROP INDEX IF EXISTS duels_resolved_at_idx;
+
+ALTER TABLE duels
+    DROP COLUMN IF EXISTS resolved_at,
+    DROP COLUMN IF EXISTS creator_commission;
@@ -0,0 +1,46 @@
+ALTER TABLE duels
+    ADD COLUMN IF NOT EXISTS creator_commission NUMERIC(15, 9) NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS resolved_at        TIMESTAMPTZ    NULL;
+
+UPDATE duels
+SET resolved_at = updated_at
+WHERE status = 5
+  AND resolved_at IS NULL;
+
+-- owners of duels resolved before the column existed were paid as DuelParams.CalculateCryptoCommissionReward computes
+UPDATE duels
+SET creator_commission = FLOOR((players_count - refunded_players_count) * duel_price * commission * 1000000 / 100.0 / 2) / 1000000
+WHERE status = 5
+  AND creator_commission = 0;
+
+CREATE INDEX IF NOT EXISTS duels_resolved_at_idx ON duels (resolved_at) WHERE resolved_at IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS leaderboard_stats
+(
+    period          VARCHAR(16)     NOT NULL,
+    user_id         UUID            NOT NULL,
+
+    participated    INTEGER         NOT NULL DEFAULT 0,
+    wins            INTEGER         NOT NULL DEFAULT 0,
+    net_profit      NUMERIC(15, 9)  NOT NULL DEFAULT 0,
+    win_rate        DOUBLE PRECISION NULL,
+    volume          NUMERIC(15, 9)  NOT NULL DEFAULT 0,
+    commission      NUMERIC(15, 9)  NOT NULL DEFAULT 0,
+
+    net_profit_rank INTEGER         NULL,
+    wins_rank       INTEGER         NULL,
+    win_rate_rank   INTEGER         NULL,
+    volume_rank     INTEGER         NULL,
+    commission_rank INTEGER         NULL,
+
+    refreshed_at    TIMESTAMPTZ     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (period, user_id),
+    CONSTRAINT leaderboard_stats_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS leaderboard_stats_net_profit_rank_idx ON leaderboard_stats (period, net_profit_rank);
+CREATE INDEX IF NOT EXISTS leaderboard_stats_wins_rank_idx ON leaderboard_stats (period, wins_rank);
+CREATE INDEX IF NOT EXISTS leaderboard_stats_win_rate_rank_idx ON leaderboard_stats (period, win_rate_rank);
+CREATE INDEX IF NOT EXISTS leaderboard_stats_volume_rank_idx ON leaderboard_stats (period, volume_rank);
+CREATE INDEX IF NOT EXISTS leaderboard_stats_commission_rank_idx ON leaderboard_stats (period, commission_rank);
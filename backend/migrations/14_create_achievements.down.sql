@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS user_achievements;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS win_streak,
+    DROP COLUMN IF EXISTS xp;
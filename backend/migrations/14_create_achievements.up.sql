@@ -0,0 +1,13 @@
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS xp         INTEGER NOT NULL DEFAULT 0,
+    ADD COLUMN IF NOT EXISTS win_streak INTEGER NOT NULL DEFAULT 0;
+
+CREATE TABLE IF NOT EXISTS user_achievements
+(
+    user_id     UUID        NOT NULL,
+    achievement VARCHAR(32) NOT NULL,
+    unlocked_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, achievement),
+    CONSTRAINT user_achievements_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
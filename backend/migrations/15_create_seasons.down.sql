@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS season_prizes;
+DROP TABLE IF EXISTS season_points;
+DROP TABLE IF EXISTS seasons;
@@ -0,0 +1,53 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS seasons
+(
+    id          UUID PRIMARY KEY DEFAULT uuid_generate_v4(),
+    name        VARCHAR(64)    NOT NULL,
+    starts_at   TIMESTAMPTZ    NOT NULL,
+    ends_at     TIMESTAMPTZ    NOT NULL,
+    prize_pool  NUMERIC(15, 9) NOT NULL DEFAULT 0,
+    prize_split JSONB          NOT NULL DEFAULT '[]',
+    status      SMALLINT       NOT NULL DEFAULT 0,
+    settled_at  TIMESTAMPTZ    NULL,
+    created_at  TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT seasons_period_check CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS seasons_ends_at_idx ON seasons (ends_at);
+
+CREATE TABLE IF NOT EXISTS season_points
+(
+    season_id    UUID        NOT NULL,
+    user_id      UUID        NOT NULL,
+    points       INTEGER     NOT NULL DEFAULT 0,
+    participated INTEGER     NOT NULL DEFAULT 0,
+    wins         INTEGER     NOT NULL DEFAULT 0,
+    updated_at   TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (season_id, user_id),
+    CONSTRAINT season_points_season_fk FOREIGN KEY (season_id) REFERENCES seasons (id) ON DELETE CASCADE,
+    CONSTRAINT season_points_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS season_points_standings_idx ON season_points (season_id, points DESC, wins DESC, updated_at);
+
+CREATE TABLE IF NOT EXISTS season_prizes
+(
+    season_id    UUID           NOT NULL,
+    user_id      UUID           NOT NULL,
+    rank         INTEGER        NOT NULL,
+    points       INTEGER        NOT NULL,
+    amount       NUMERIC(15, 9) NOT NULL,
+    wallet       VARCHAR(44)    NOT NULL,
+    status       SMALLINT       NOT NULL DEFAULT 0,
+    tx_signature VARCHAR(88)    NULL,
+    error        TEXT           NULL,
+    created_at   TIMESTAMPTZ    NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    paid_at      TIMESTAMPTZ    NULL,
+
+    PRIMARY KEY (season_id, user_id),
+    CONSTRAINT season_prizes_season_fk FOREIGN KEY (season_id) REFERENCES seasons (id) ON DELETE CASCADE,
+    CONSTRAINT season_prizes_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
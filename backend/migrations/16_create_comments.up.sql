@@ -0,0 +1,25 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS comments
+(
+    id            UUID PRIMARY KEY       DEFAULT uuid_generate_v4(),
+    duel_id       UUID          NOT NULL,
+    user_id       UUID          NOT NULL,
+    parent_id     UUID          NULL,
+    root_id       UUID          NULL,
+    text          VARCHAR(1000) NOT NULL,
+    status        SMALLINT      NOT NULL DEFAULT 0,
+    replies_count INTEGER       NOT NULL DEFAULT 0,
+    pinned_at     TIMESTAMPTZ   NULL,
+    edited_at     TIMESTAMPTZ   NULL,
+    created_at    TIMESTAMPTZ   NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT comments_duel_fk FOREIGN KEY (duel_id) REFERENCES duels (id) ON DELETE CASCADE,
+    CONSTRAINT comments_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE,
+    CONSTRAINT comments_parent_fk FOREIGN KEY (parent_id) REFERENCES comments (id) ON DELETE CASCADE,
+    CONSTRAINT comments_root_fk FOREIGN KEY (root_id) REFERENCES comments (id) ON DELETE CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS comments_threads_idx ON comments (duel_id, created_at DESC, id DESC) WHERE root_id IS NULL;
+CREATE INDEX IF NOT EXISTS comments_replies_idx ON comments (root_id, created_at, id) WHERE root_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS comments_pinned_idx ON comments (duel_id) WHERE pinned_at IS NOT NULL;
@@ -0,0 +1,3 @@
+ALTER TABLE tracked_signatures
+    DROP COLUMN IF EXISTS claimed_by,
+    DROP COLUMN IF EXISTS claimed_until;
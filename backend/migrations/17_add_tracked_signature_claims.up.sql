@@ -0,0 +1,3 @@
+ALTER TABLE tracked_signatures
+    ADD COLUMN IF NOT EXISTS claimed_by    VARCHAR(36) NULL,
+    ADD COLUMN IF NOT EXISTS claimed_until TIMESTAMPTZ NULL;
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS duel_event_credits;
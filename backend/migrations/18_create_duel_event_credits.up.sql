@@ -0,0 +1,12 @@
+-- every duel event is credited once per kind of reward, so handlers may run again safely
+CREATE TABLE IF NOT EXISTS duel_event_credits
+(
+    user_id     UUID        NOT NULL,
+    duel_id     UUID        NOT NULL,
+    event_type  SMALLINT    NOT NULL,
+    reward      VARCHAR(16) NOT NULL,
+    credited_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    PRIMARY KEY (user_id, duel_id, event_type, reward),
+    CONSTRAINT duel_event_credits_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
@@ -0,0 +1,4 @@
+ALTER TABLE season_prizes
+    DROP COLUMN IF EXISTS last_valid_block_height,
+    DROP COLUMN IF EXISTS claimed_at,
+    DROP COLUMN IF EXISTS claimed_by;
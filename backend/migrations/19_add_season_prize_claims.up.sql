@@ -0,0 +1,4 @@
+ALTER TABLE season_prizes
+    ADD COLUMN IF NOT EXISTS claimed_by              VARCHAR(36) NULL,
+    ADD COLUMN IF NOT EXISTS claimed_at              TIMESTAMPTZ NULL,
+    ADD COLUMN IF NOT EXISTS last_valid_block_height BIGINT      NULL;
@@ -0,0 +1,5 @@
+DROP INDEX IF EXISTS tracked_signatures_duel_id_idx;
+DROP INDEX IF EXISTS tracked_signatures_pending_idx;
+DROP INDEX IF EXISTS tracked_signatures_signature_uq;
+
+DROP TABLE IF EXISTS tracked_signatures;
@@ -0,0 +1,22 @@
+CREATE TABLE IF NOT EXISTS tracked_signatures
+(
+    id         UUID PRIMARY KEY,
+    signature  VARCHAR(88) NOT NULL,
+    purpose    SMALLINT    NOT NULL,
+    status     SMALLINT    NOT NULL DEFAULT 0,
+    duel_id    UUID        NULL,
+    user_id    UUID        NULL,
+    data       JSONB       NULL,
+    slot       BIGINT      NOT NULL DEFAULT 0,
+    error      TEXT        NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS tracked_signatures_signature_uq
+    ON tracked_signatures (signature);
+
+CREATE INDEX IF NOT EXISTS tracked_signatures_pending_idx
+    ON tracked_signatures (created_at) WHERE status = 0;
+
+CREATE INDEX IF NOT EXISTS tracked_signatures_duel_id_idx ON tracked_signatures (duel_id);
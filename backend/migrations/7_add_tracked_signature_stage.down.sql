@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS tracked_signatures_user_id_idx;
+
+ALTER TABLE tracked_signatures
+    DROP COLUMN IF EXISTS stage;
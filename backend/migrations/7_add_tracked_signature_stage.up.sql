@@ -0,0 +1,4 @@
+ALTER TABLE tracked_signatures
+    ADD COLUMN IF NOT EXISTS stage SMALLINT NOT NULL DEFAULT 0;
+
+CREATE INDEX IF NOT EXISTS tracked_signatures_user_id_idx ON tracked_signatures (user_id);
@@ -0,0 +1,8 @@
+ALTER TABLE players
+    DROP COLUMN IF EXISTS wallet_address;
+
+DROP INDEX IF EXISTS user_wallets_user_id_idx;
+DROP INDEX IF EXISTS user_wallets_primary_uq;
+DROP INDEX IF EXISTS user_wallets_address_uq;
+
+DROP TABLE IF EXISTS user_wallets;
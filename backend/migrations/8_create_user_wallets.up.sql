@@ -0,0 +1,31 @@
+CREATE EXTENSION IF NOT EXISTS "uuid-ossp";
+
+CREATE TABLE IF NOT EXISTS user_wallets
+(
+    id         UUID PRIMARY KEY,
+    user_id    UUID         NOT NULL,
+    address    VARCHAR(100) NOT NULL,
+    is_primary BOOLEAN      NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT user_wallets_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS user_wallets_address_uq ON user_wallets (address);
+CREATE UNIQUE INDEX IF NOT EXISTS user_wallets_primary_uq ON user_wallets (user_id) WHERE is_primary;
+CREATE INDEX IF NOT EXISTS user_wallets_user_id_idx ON user_wallets (user_id);
+
+INSERT INTO user_wallets (id, user_id, address, is_primary, created_at)
+SELECT uuid_generate_v4(), id, public_address, TRUE, created_at
+FROM users
+WHERE public_address IS NOT NULL
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE players
+    ADD COLUMN IF NOT EXISTS wallet_address VARCHAR(100) NULL;
+
+UPDATE players
+SET wallet_address = users.public_address
+FROM users
+WHERE players.user_id = users.id
+  AND players.wallet_address IS NULL;
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS api_keys_user_id_idx;
+DROP INDEX IF EXISTS api_keys_key_hash_uq;
+
+DROP TABLE IF EXISTS api_keys;
@@ -0,0 +1,20 @@
+CREATE TABLE IF NOT EXISTS api_keys
+(
+    id           UUID PRIMARY KEY,
+    user_id      UUID         NOT NULL,
+    issued_by    UUID         NOT NULL,
+    name         VARCHAR(64)  NOT NULL,
+    prefix       VARCHAR(16)  NOT NULL,
+    key_hash     VARCHAR(64)  NOT NULL,
+    scopes       TEXT[]       NOT NULL DEFAULT '{}',
+    rate_limit   INTEGER      NOT NULL,
+    last_used_at TIMESTAMPTZ  NULL,
+    expires_at   TIMESTAMPTZ  NULL,
+    revoked_at   TIMESTAMPTZ  NULL,
+    created_at   TIMESTAMPTZ  NOT NULL DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT api_keys_user_fk FOREIGN KEY (user_id) REFERENCES users (id) ON DELETE CASCADE
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS api_keys_key_hash_uq ON api_keys (key_hash);
+CREATE INDEX IF NOT EXISTS api_keys_user_id_idx ON api_keys (user_id);
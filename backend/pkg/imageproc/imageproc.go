@@ -0,0 +1,233 @@
+// Package imageproc normalizes uploaded images. Every image is decoded and
+// encoded again, so metadata like EXIF location is dropped and only pixels
+// reach the storage. The EXIF orientation is applied to the pixels before that. SVG images are rasterized, scripts in them never run.
+package imageproc
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"net/http"
+
+	"golang.org/x/image/draw"
+)
+
+const (
+	// MaxSourceSide and MaxSourcePixels are checked before decoding, so decompression bombs are rejected cheaply
+	MaxSourceSide   = 8000
+	MaxSourcePixels = 40_000_000
+
+	jpegQuality = 85
+)
+
+var (
+	ErrUnsupportedFormat = errors.New("unsupported image format")
+	ErrTooLarge          = errors.New("image dimensions are too large")
+	ErrTooSmall          = errors.New("image dimensions are too small")
+)
+
+const (
+	ContentTypeJPEG = "image/jpeg"
+	ContentTypePNG  = "image/png"
+	ContentTypeSVG  = "image/svg+xml"
+)
+
+// Profile describes the normalized sizes of an image kind
+type Profile struct {
+	// Width and Height bound the main image, it is cropped to the exact size if Crop is set
+	Width  int
+	Height int
+	Crop   bool
+
+	ThumbWidth  int
+	ThumbHeight int
+
+	// MinSide rejects images that would be upscaled too much
+	MinSide int
+}
+
+var (
+	ProfileAvatar = Profile{
+		Width: 512, Height: 512, Crop: true,
+		ThumbWidth: 128, ThumbHeight: 128,
+		MinSide: 64,
+	}
+	ProfileDuelCard = Profile{
+		Width: 1600, Height: 1600,
+		ThumbWidth: 480, ThumbHeight: 480,
+		MinSide: 200,
+	}
+)
+
+type Result struct {
+	Image       []byte
+	Thumbnail   []byte
+	ContentType string
+	// Extension matches the content type of the encoded images
+	Extension string
+}
+
+// Process validates the image by its content, resizes it by the profile and encodes it again
+func Process(data []byte, profile Profile) (*Result, error) {
+	contentType := DetectContentType(data)
+
+	var (
+		src         image.Image
+		orientation = orientationNormal
+		err         error
+	)
+
+	switch contentType {
+	case ContentTypeJPEG, ContentTypePNG:
+		if err = checkDimensions(data); err != nil {
+			return nil, err
+		}
+
+		src, _, err = image.Decode(bytes.NewReader(data))
+		if err != nil {
+			return nil, ErrUnsupportedFormat
+		}
+
+		if contentType == ContentTypeJPEG {
+			orientation = jpegOrientation(data)
+		}
+	case ContentTypeSVG:
+		src, err = rasterizeSVG(data, profile)
+		if err != nil {
+			return nil, err
+		}
+	default:
+		return nil, ErrUnsupportedFormat
+	}
+
+	bounds := src.Bounds()
+	if min(bounds.Dx(), bounds.Dy()) < profile.MinSide {
+		return nil, ErrTooSmall
+	}
+
+	// transparency is kept only for formats that may have it
+	encode, outputType, extension := encodeJPEG, ContentTypeJPEG, ".jpg"
+	if contentType != ContentTypeJPEG && !opaque(src) {
+		encode, outputType, extension = encodePNG, ContentTypePNG, ".png"
+	}
+
+	// the image is turned after scaling, so the box is turned for it
+	width, height := profile.Width, profile.Height
+	if swapsSides(orientation) {
+		width, height = height, width
+	}
+
+	normalized := orient(resize(src, width, height, profile.Crop), orientation)
+	thumb := resize(normalized, profile.ThumbWidth, profile.ThumbHeight, profile.Crop)
+
+	result := &Result{ContentType: outputType, Extension: extension}
+
+	if result.Image, err = encode(normalized); err != nil {
+		return nil, err
+	}
+
+	if result.Thumbnail, err = encode(thumb); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// DetectContentType sniffs the content instead of trusting the file name
+func DetectContentType(data []byte) string {
+	contentType := http.DetectContentType(data)
+	switch contentType {
+	case ContentTypeJPEG, ContentTypePNG:
+		return contentType
+	}
+
+	if isSVG(data) {
+		return ContentTypeSVG
+	}
+
+	return contentType
+}
+
+func checkDimensions(data []byte) error {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return ErrUnsupportedFormat
+	}
+
+	if cfg.Width > MaxSourceSide || cfg.Height > MaxSourceSide || cfg.Width*cfg.Height > MaxSourcePixels {
+		return ErrTooLarge
+	}
+
+	return nil
+}
+
+// resize scales the image down to fit the box, or to cover it and crops the center if crop is set.
+// Images are never upscaled.
+func resize(src image.Image, width, height int, crop bool) *image.RGBA {
+	bounds := src.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	scale := min(float64(width)/float64(srcW), float64(height)/float64(srcH))
+	if crop {
+		scale = max(float64(width)/float64(srcW), float64(height)/float64(srcH))
+	}
+	scale = min(scale, 1)
+
+	dstW, dstH := max(int(float64(srcW)*scale), 1), max(int(float64(srcH)*scale), 1)
+
+	srcRect := bounds
+	if crop {
+		// the part of the source that covers the box after scaling
+		cropW := min(srcW, int(float64(width)/scale))
+		cropH := min(srcH, int(float64(height)/scale))
+		x0 := bounds.Min.X + (srcW-cropW)/2
+		y0 := bounds.Min.Y + (srcH-cropH)/2
+		srcRect = image.Rect(x0, y0, x0+cropW, y0+cropH)
+
+		dstW, dstH = min(dstW, width), min(dstH, height)
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	draw.CatmullRom.Scale(dst, dst.Bounds(), src, srcRect, draw.Src, nil)
+
+	return dst
+}
+
+func opaque(img image.Image) bool {
+	if o, ok := img.(interface{ Opaque() bool }); ok {
+		return o.Opaque()
+	}
+
+	return false
+}
+
+func encodeJPEG(img image.Image) ([]byte, error) {
+	// jpeg has no alpha, transparent pixels are put on white
+	if !opaque(img) {
+		flat := image.NewRGBA(img.Bounds())
+		draw.Draw(flat, flat.Bounds(), image.NewUniform(color.White), image.Point{}, draw.Src)
+		draw.Draw(flat, flat.Bounds(), img, img.Bounds().Min, draw.Over)
+		img = flat
+	}
+
+	buf := new(bytes.Buffer)
+	if err := jpeg.Encode(buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func encodePNG(img image.Image) ([]byte, error) {
+	buf := new(bytes.Buffer)
+
+	encoder := png.Encoder{CompressionLevel: png.BestCompression}
+	if err := encoder.Encode(buf, img); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
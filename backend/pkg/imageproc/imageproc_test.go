@@ -0,0 +1,199 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func encodeTestJPEG(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	if err := jpeg.Encode(buf, image.NewRGBA(image.Rect(0, 0, w, h)), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+func encodeTestPNG(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	buf := new(bytes.Buffer)
+	if err := png.Encode(buf, image.NewRGBA(image.Rect(0, 0, w, h))); err != nil {
+		t.Fatal(err)
+	}
+
+	return buf.Bytes()
+}
+
+// pngHeader returns a png that declares the size but has no pixels, enough for the checks before decoding
+func pngHeader(t *testing.T, w, h int) []byte {
+	t.Helper()
+
+	data := encodeTestPNG(t, 1, 1)
+	binary.BigEndian.PutUint32(data[16:], uint32(w))
+	binary.BigEndian.PutUint32(data[20:], uint32(h))
+	binary.BigEndian.PutUint32(data[29:], crc32.ChecksumIEEE(data[12:29]))
+
+	return data
+}
+
+// withOrientation inserts an APP1 exif segment with the orientation right after SOI
+func withOrientation(data []byte, orientation uint16, order binary.ByteOrder) []byte {
+	tiff := make([]byte, 8+2+12+4)
+	if order == binary.LittleEndian {
+		copy(tiff, "II")
+	} else {
+		copy(tiff, "MM")
+	}
+	order.PutUint16(tiff[2:], 42)
+	order.PutUint32(tiff[4:], 8)
+	order.PutUint16(tiff[8:], 1)
+	order.PutUint16(tiff[10:], exifOrientationTag)
+	order.PutUint16(tiff[12:], 3)
+	order.PutUint32(tiff[14:], 1)
+	order.PutUint16(tiff[18:], orientation)
+
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := []byte{0xFF, 0xE1, 0, 0}
+	binary.BigEndian.PutUint16(segment[2:], uint16(len(payload)+2))
+	segment = append(segment, payload...)
+
+	out := append([]byte{}, data[:2]...)
+	out = append(out, segment...)
+
+	return append(out, data[2:]...)
+}
+
+func TestProcessLimits(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    []byte
+		profile Profile
+		wantErr error
+		wantW   int
+		wantH   int
+	}{
+		{name: "unsupported format", data: []byte("plain text"), profile: ProfileAvatar, wantErr: ErrUnsupportedFormat},
+		{name: "side too large", data: pngHeader(t, MaxSourceSide+1, 10), profile: ProfileDuelCard, wantErr: ErrTooLarge},
+		{name: "too many pixels", data: pngHeader(t, 7000, 7000), profile: ProfileDuelCard, wantErr: ErrTooLarge},
+		{name: "too small", data: encodeTestPNG(t, 32, 32), profile: ProfileAvatar, wantErr: ErrTooSmall},
+		{name: "avatar is cropped", data: encodeTestJPEG(t, 900, 600), profile: ProfileAvatar, wantW: 512, wantH: 512},
+		{name: "card fits the box", data: encodeTestJPEG(t, 3200, 1600), profile: ProfileDuelCard, wantW: 1600, wantH: 800},
+		{name: "card is not upscaled", data: encodeTestPNG(t, 400, 300), profile: ProfileDuelCard, wantW: 400, wantH: 300},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := Process(tt.data, tt.profile)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Process() error = %v, want %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+
+			cfg, _, err := image.DecodeConfig(bytes.NewReader(result.Image))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if cfg.Width != tt.wantW || cfg.Height != tt.wantH {
+				t.Errorf("Process() size = %dx%d, want %dx%d", cfg.Width, cfg.Height, tt.wantW, tt.wantH)
+			}
+		})
+	}
+}
+
+func TestJPEGOrientation(t *testing.T) {
+	data := encodeTestJPEG(t, 8, 8)
+
+	tests := []struct {
+		name string
+		data []byte
+		want int
+	}{
+		{name: "no exif", data: data, want: 1},
+		{name: "little endian", data: withOrientation(data, 6, binary.LittleEndian), want: 6},
+		{name: "big endian", data: withOrientation(data, 8, binary.BigEndian), want: 8},
+		{name: "out of range", data: withOrientation(data, 9, binary.BigEndian), want: 1},
+		{name: "not a jpeg", data: []byte("plain text"), want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := jpegOrientation(tt.data); got != tt.want {
+				t.Errorf("jpegOrientation() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOrient(t *testing.T) {
+	// a b c
+	// d e f
+	src := image.NewRGBA(image.Rect(0, 0, 3, 2))
+	for i, p := range "abcdef" {
+		src.Set(i%3, i/3, color.RGBA{R: uint8(p), A: 255})
+	}
+
+	tests := []struct {
+		orientation int
+		want        []string
+	}{
+		{orientation: 1, want: []string{"abc", "def"}},
+		{orientation: 2, want: []string{"cba", "fed"}},
+		{orientation: 3, want: []string{"fed", "cba"}},
+		{orientation: 4, want: []string{"def", "abc"}},
+		{orientation: 5, want: []string{"ad", "be", "cf"}},
+		{orientation: 6, want: []string{"da", "eb", "fc"}},
+		{orientation: 7, want: []string{"fc", "eb", "da"}},
+		{orientation: 8, want: []string{"cf", "be", "ad"}},
+	}
+
+	for _, tt := range tests {
+		dst := orient(src, tt.orientation)
+
+		rows := make([]string, dst.Bounds().Dy())
+		for y := range rows {
+			for x := range dst.Bounds().Dx() {
+				rows[y] += string(rune(dst.RGBAAt(x, y).R))
+			}
+		}
+
+		if len(rows) != len(tt.want) {
+			t.Errorf("orient(%d) = %v, want %v", tt.orientation, rows, tt.want)
+			continue
+		}
+		for y := range rows {
+			if rows[y] != tt.want[y] {
+				t.Errorf("orient(%d) = %v, want %v", tt.orientation, rows, tt.want)
+				break
+			}
+		}
+	}
+}
+
+func TestProcessAppliesOrientation(t *testing.T) {
+	data := withOrientation(encodeTestJPEG(t, 600, 400), 6, binary.BigEndian)
+
+	result, err := Process(data, ProfileDuelCard)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(result.Image))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Width != 400 || cfg.Height != 600 {
+		t.Errorf("Process() size = %dx%d, want 400x600", cfg.Width, cfg.Height)
+	}
+}
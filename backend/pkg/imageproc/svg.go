@@ -0,0 +1,63 @@
+package imageproc
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"image"
+	"io"
+	"strings"
+
+	"github.com/srwiley/oksvg"
+	"github.com/srwiley/rasterx"
+)
+
+// isSVG reports whether the first element of the xml document is svg
+func isSVG(data []byte) bool {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	decoder.Strict = false
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return false
+		}
+
+		if start, ok := token.(xml.StartElement); ok {
+			return strings.EqualFold(start.Name.Local, "svg")
+		}
+	}
+}
+
+// rasterizeSVG draws the svg into the profile box keeping its aspect ratio.
+// Only shapes are drawn, scripts, external references and foreign objects are ignored.
+func rasterizeSVG(data []byte, profile Profile) (image.Image, error) {
+	icon, err := oksvg.ReadIconStream(io.NopCloser(bytes.NewReader(data)), oksvg.IgnoreErrorMode)
+	if err != nil {
+		return nil, ErrUnsupportedFormat
+	}
+
+	viewW, viewH := icon.ViewBox.W, icon.ViewBox.H
+	if viewW <= 0 || viewH <= 0 {
+		return nil, errors.Join(ErrUnsupportedFormat, errors.New("svg has no view box"))
+	}
+
+	// svg has no pixel size, draw it as large as the main image needs
+	scale := max(float64(profile.Width)/viewW, float64(profile.Height)/viewH)
+	if !profile.Crop {
+		scale = min(float64(profile.Width)/viewW, float64(profile.Height)/viewH)
+	}
+
+	width, height := max(int(viewW*scale), 1), max(int(viewH*scale), 1)
+	if width > MaxSourceSide || height > MaxSourceSide {
+		return nil, ErrTooLarge
+	}
+
+	icon.SetTarget(0, 0, float64(width), float64(height))
+
+	dst := image.NewRGBA(image.Rect(0, 0, width, height))
+	scanner := rasterx.NewScannerGV(width, height, dst, dst.Bounds())
+	icon.Draw(rasterx.NewDasher(width, height, scanner), 1)
+
+	return dst, nil
+}
@@ -3,19 +3,36 @@ package auth
 import (
 	"duels-api/internal/model"
 	"github.com/google/uuid"
+	"time"
 )
 
 var (
 	ErrGenerateToken        = "failed to generate token"
 	ErrInvalidSigningMethod = "unexpected signing method"
+	ErrUnknownKeyID         = "unknown signing key"
 	ErrInvalidToken         = "token is not valid"
 	ErrInvalidTokenClaims   = "invalid token claims"
 	ErrInvalidUserIDClaim   = "invalid user_id claim"
+	ErrInvalidTokenType     = "invalid token type"
+)
+
+const (
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+	// TokenTypeAPIKey marks claims of a request authenticated by an api key
+	TokenTypeAPIKey = "api_key"
+)
+
+// Audiences keep access and refresh tokens usable only on their own routes
+const (
+	AudienceAccess  = "duels-api"
+	AudienceRefresh = "duels-api:refresh"
 )
 
 type JWTAuthenticator interface {
 	GenerateTokenPair(options TokenClaims) (*TokenPairWithExpiration, error)
-	ParseToken(accessToken string) (*TokenClaims, error)
+	ParseToken(token string, tokenType string) (*TokenClaims, error)
+	JWKS() JWKSet
 }
 
 type TokenPairWithExpiration struct {
@@ -31,7 +48,14 @@ type TokenPair struct {
 type TokenClaims struct {
 	PublicAddress string    `json:"public_address"`
 	SessionID     uuid.UUID `json:"session_id"`
-	UserID        uuid.UUID `json:"user_id"`
+	// FamilyID is the id of the session started at sign in, it is kept across refreshes
+	FamilyID  uuid.UUID `json:"family_id"`
+	UserID    uuid.UUID `json:"user_id"`
+	TokenType string    `json:"token_type"`
+
+	// filled from registered claims of a parsed token
+	TokenID   string    `json:"-"`
+	ExpiresAt time.Time `json:"-"`
 }
 
 func (c *TokenClaims) RefreshSessionID() bool {
@@ -57,6 +81,7 @@ func NewTokenClaimsByUser(user *model.User) (TokenClaims, bool) {
 	return TokenClaims{
 		UserID:        user.ID,
 		SessionID:     sessionID,
+		FamilyID:      sessionID,
 		PublicAddress: user.PublicAddress,
 	}, true
 }
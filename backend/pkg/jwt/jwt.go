@@ -12,14 +12,14 @@ import (
 )
 
 type jwtAuthenticator struct {
-	signKey         string
+	keys            *KeySet
 	accessTokenTTL  time.Duration
 	refreshTokenTTL time.Duration
 }
 
-func NewJWTAuth(c *config.Config) JWTAuthenticator {
+func NewJWTAuth(c *config.Config, keys *KeySet) JWTAuthenticator {
 	return &jwtAuthenticator{
-		signKey:         c.Auth.SecretSignKey,
+		keys:            keys,
 		accessTokenTTL:  c.Auth.AccessTokenTTL,
 		refreshTokenTTL: c.Auth.RefreshTokenTTL,
 	}
@@ -31,17 +31,18 @@ type TokenJWTClaims struct {
 }
 
 type TokenExpiration struct {
+	ID        string
 	Token     string
 	ExpiresAt time.Time
 }
 
 func (a *jwtAuthenticator) GenerateTokenPair(claims TokenClaims) (*TokenPairWithExpiration, error) {
-	refreshToken, err := a.generateToken(claims, a.refreshTokenTTL)
+	refreshToken, err := a.generateToken(claims, TokenTypeRefresh, a.refreshTokenTTL)
 	if err != nil {
 		return nil, err
 	}
 
-	accessToken, err := a.generateToken(claims, a.accessTokenTTL)
+	accessToken, err := a.generateToken(claims, TokenTypeAccess, a.accessTokenTTL)
 	if err != nil {
 		return nil, err
 	}
@@ -54,16 +55,24 @@ func (a *jwtAuthenticator) GenerateTokenPair(claims TokenClaims) (*TokenPairWith
 
 func (a *jwtAuthenticator) generateToken(
 	claims TokenClaims,
+	tokenType string,
 	tokenLifetime time.Duration,
 ) (*TokenExpiration, error) {
 	if claims.UserID == uuid.Nil {
 		return nil, apperrors.Unauthorized(ErrInvalidTokenClaims)
 	}
 
-	mySigningKey := []byte(a.signKey)
+	tokenID, err := uuid.NewV7()
+	if err != nil {
+		return nil, apperrors.Internal(ErrGenerateToken, err)
+	}
+
+	key := a.keys.signingKey()
 	now := time.Now()
 	expiresAt := now.Add(tokenLifetime)
 
+	claims.TokenType = tokenType
+
 	jwtClaims := TokenJWTClaims{
 		TokenClaims: claims,
 		RegisteredClaims: jwt.RegisteredClaims{
@@ -72,28 +81,44 @@ func (a *jwtAuthenticator) generateToken(
 			NotBefore: jwt.NewNumericDate(now),
 			Issuer:    "some-app-api",
 			Subject:   "client",
-			ID:        claims.SessionID.String(),
+			Audience:  jwt.ClaimStrings{audienceOf(tokenType)},
+			ID:        tokenID.String(),
 		},
 	}
 
-	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims).SignedString(mySigningKey)
+	unsigned := jwt.NewWithClaims(key.Method, jwtClaims)
+	unsigned.Header["kid"] = key.ID
+
+	token, err := unsigned.SignedString(key.Private)
 	if err != nil {
 		return nil, apperrors.Unauthorized(ErrGenerateToken, err)
 	}
 
 	return &TokenExpiration{
+		ID:        tokenID.String(),
 		Token:     token,
 		ExpiresAt: expiresAt,
 	}, nil
 }
 
-func (a *jwtAuthenticator) ParseToken(authToken string) (*TokenClaims, error) {
+func audienceOf(tokenType string) string {
+	if tokenType == TokenTypeRefresh {
+		return AudienceRefresh
+	}
+
+	return AudienceAccess
+}
+
+// ParseToken parses the token and checks that it is of the expected type
+func (a *jwtAuthenticator) ParseToken(authToken string, tokenType string) (*TokenClaims, error) {
 	authToken = strings.TrimPrefix(authToken, "Bearer ")
 
 	token, err := jwt.Parse(
 		authToken,
 		a.GetKey,
-		jwt.WithValidMethods([]string{"HS256"}))
+		jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg(), jwt.SigningMethodRS256.Alg()}),
+		jwt.WithAudience(audienceOf(tokenType)),
+		jwt.WithExpirationRequired())
 
 	if err != nil {
 		if errors.Is(err, jwt.ErrSignatureInvalid) || errors.Is(err, jwt.ErrTokenExpired) {
@@ -112,7 +137,16 @@ func (a *jwtAuthenticator) ParseToken(authToken string) (*TokenClaims, error) {
 		return nil, apperrors.Unauthorized(ErrInvalidTokenClaims)
 	}
 
-	return ExtractClaims(claims)
+	parsed, err := ExtractClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	if parsed.TokenType != tokenType {
+		return nil, apperrors.Unauthorized(ErrInvalidTokenType)
+	}
+
+	return parsed, nil
 }
 
 func ExtractClaims(claims jwt.MapClaims) (*TokenClaims, error) {
@@ -131,10 +165,34 @@ func ExtractClaims(claims jwt.MapClaims) (*TokenClaims, error) {
 		return nil, apperrors.Unauthorized("invalid session_id claim")
 	}
 
+	familyID, ok := extractUUID(claims, "family_id")
+	if !ok {
+		return nil, apperrors.Unauthorized("invalid family_id claim")
+	}
+
+	tokenType, ok := ValueFromJWTClaims[string](claims, "token_type")
+	if !ok {
+		return nil, apperrors.Unauthorized("invalid token_type claim")
+	}
+
+	tokenID, ok := ValueFromJWTClaims[string](claims, "jti")
+	if !ok || tokenID == "" {
+		return nil, apperrors.Unauthorized("invalid jti claim")
+	}
+
+	expiresAt, err := claims.GetExpirationTime()
+	if err != nil || expiresAt == nil {
+		return nil, apperrors.Unauthorized("invalid exp claim", err)
+	}
+
 	parsed := &TokenClaims{
 		UserID:        userID,
 		SessionID:     sessionID,
+		FamilyID:      familyID,
 		PublicAddress: publicAddress,
+		TokenType:     tokenType,
+		TokenID:       tokenID,
+		ExpiresAt:     expiresAt.Time,
 	}
 
 	return parsed, nil
@@ -174,10 +232,27 @@ func extractUUID(claims jwt.MapClaims, value string) (uuid.UUID, bool) {
 	return parsed, true
 }
 
+// GetKey returns the verification key referenced by the kid header of the token.
+// HS256 tokens signed before the keys dir have no kid, they are rejected so clients sign in again
 func (a *jwtAuthenticator) GetKey(token *jwt.Token) (any, error) {
-	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+	kid, ok := token.Header["kid"].(string)
+	if !ok || kid == "" {
+		return nil, apperrors.Unauthorized(ErrUnknownKeyID)
+	}
+
+	key, ok := a.keys.verificationKey(kid)
+	if !ok {
+		return nil, apperrors.Unauthorized(ErrUnknownKeyID)
+	}
+
+	if token.Method.Alg() != key.Method.Alg() {
+		// also rejects HS256 tokens signed with a public key as the secret
 		return nil, apperrors.Unauthorized(ErrInvalidSigningMethod)
 	}
 
-	return []byte(a.signKey), nil
+	return key.Public, nil
+}
+
+func (a *jwtAuthenticator) JWKS() JWKSet {
+	return a.keys.JWKS()
 }
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/x509"
+	"duels-api/pkg/apperrors"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+const testSecretSignKey = "baseline-secret-sign-key"
+
+func newTestAuthenticator(t *testing.T) *jwtAuthenticator {
+	t.Helper()
+
+	_, private, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	der, err := x509.MarshalPKCS8PrivateKey(private)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir := t.TempDir()
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err = os.WriteFile(filepath.Join(dir, "2026-01"+privateKeyExt), data, 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	keys, err := NewKeySet(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &jwtAuthenticator{keys: keys, accessTokenTTL: time.Hour, refreshTokenTTL: 24 * time.Hour}
+}
+
+// mintBaselineToken signs a token the way the code before the keys dir did:
+// HS256 with a shared secret, no kid, audience, token type or family
+func mintBaselineToken(t *testing.T, claims TokenClaims, header map[string]any) string {
+	t.Helper()
+
+	now := time.Now()
+	jwtClaims := struct {
+		PublicAddress string    `json:"public_address"`
+		SessionID     uuid.UUID `json:"session_id"`
+		UserID        uuid.UUID `json:"user_id"`
+		jwt.RegisteredClaims
+	}{
+		PublicAddress: claims.PublicAddress,
+		SessionID:     claims.SessionID,
+		UserID:        claims.UserID,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Hour)),
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			Issuer:    "some-app-api",
+			Subject:   "client",
+			ID:        claims.SessionID.String(),
+		},
+	}
+
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodHS256, jwtClaims)
+	for k, v := range header {
+		unsigned.Header[k] = v
+	}
+
+	token, err := unsigned.SignedString([]byte(testSecretSignKey))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func TestParseToken(t *testing.T) {
+	a := newTestAuthenticator(t)
+
+	claims := TokenClaims{
+		PublicAddress: "7xKXtg2CW87d97TXJSDpbD5jBkheTqA83TZRuJosgAsU",
+		SessionID:     uuid.Must(uuid.NewV7()),
+		FamilyID:      uuid.Must(uuid.NewV7()),
+		UserID:        uuid.New(),
+	}
+
+	pair, err := a.GenerateTokenPair(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name      string
+		token     string
+		tokenType string
+		wantErr   bool
+	}{
+		{"access token", pair.AccessToken.Token, TokenTypeAccess, false},
+		{"access token with bearer prefix", "Bearer " + pair.AccessToken.Token, TokenTypeAccess, false},
+		{"refresh token", pair.RefreshToken.Token, TokenTypeRefresh, false},
+		{"access token used as refresh", pair.AccessToken.Token, TokenTypeRefresh, true},
+		{"refresh token used as access", pair.RefreshToken.Token, TokenTypeAccess, true},
+		{"baseline token as access", mintBaselineToken(t, claims, nil), TokenTypeAccess, true},
+		{"baseline token as refresh", mintBaselineToken(t, claims, nil), TokenTypeRefresh, true},
+		{
+			"hs256 token with a known kid",
+			mintBaselineToken(t, claims, map[string]any{"kid": "2026-01"}),
+			TokenTypeAccess,
+			true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := a.ParseToken(tt.token, tt.tokenType)
+			if tt.wantErr {
+				appErr, ok := apperrors.IsAppError(err)
+				if !ok || appErr.Status != http.StatusUnauthorized {
+					t.Fatalf("ParseToken() error = %v, want unauthorized", err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("ParseToken() error = %v", err)
+			}
+			if parsed.UserID != claims.UserID || parsed.SessionID != claims.SessionID ||
+				parsed.FamilyID != claims.FamilyID || parsed.TokenType != tt.tokenType {
+				t.Errorf("ParseToken() = %+v, want claims of %+v", parsed, claims)
+			}
+		})
+	}
+}
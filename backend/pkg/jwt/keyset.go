@@ -0,0 +1,249 @@
+package auth
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"go.uber.org/zap"
+)
+
+// Key files in the keys directory are named by their kid:
+//
+//	<kid>.pem     - private key, used for signing and verification
+//	<kid>.pub.pem - public key, used for verification only
+//
+// The private key with the greatest kid signs new tokens. A key is rotated by
+// publishing the next one as a public key first, so verifiers pick it up from
+// JWKS, then replacing it with the private key and demoting the previous
+// private key to a public one until the tokens signed by it expire.
+const (
+	privateKeyExt = ".pem"
+	publicKeyExt  = ".pub.pem"
+)
+
+type signingKey struct {
+	ID     string
+	Method jwt.SigningMethod
+	// Private is nil for verification only keys
+	Private crypto.Signer
+	Public  crypto.PublicKey
+}
+
+type KeySet struct {
+	dir string
+
+	mutex   sync.RWMutex
+	keys    map[string]*signingKey
+	signing *signingKey
+
+	stop chan struct{}
+	done chan struct{}
+}
+
+func NewKeySet(dir string) (*KeySet, error) {
+	keySet := &KeySet{dir: dir}
+
+	if err := keySet.Reload(); err != nil {
+		return nil, err
+	}
+
+	return keySet, nil
+}
+
+// Reload reads the keys directory again, the current keys stay in use if it fails
+func (s *KeySet) Reload() error {
+	entries, err := os.ReadDir(s.dir)
+	if err != nil {
+		return fmt.Errorf("failed to read jwt keys dir %q set by JWT_KEYS_DIR: %w", s.dir, err)
+	}
+
+	keys := make(map[string]*signingKey)
+	var signing *signingKey
+
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+
+		name := entry.Name()
+		if !strings.HasSuffix(name, privateKeyExt) {
+			continue
+		}
+
+		key, err := readKey(filepath.Join(s.dir, name))
+		if err != nil {
+			return fmt.Errorf("failed to read jwt key %s: %w", name, err)
+		}
+
+		if _, ok := keys[key.ID]; ok {
+			return fmt.Errorf("duplicate jwt key id %s", key.ID)
+		}
+		keys[key.ID] = key
+
+		if key.Private != nil && (signing == nil || key.ID > signing.ID) {
+			signing = key
+		}
+	}
+
+	if signing == nil {
+		return fmt.Errorf("no private jwt key found in %q set by JWT_KEYS_DIR, "+
+			"add one as <kid>.pem, e.g. openssl genpkey -algorithm ed25519 -out %s", s.dir, filepath.Join(s.dir, "<kid>.pem"))
+	}
+
+	s.mutex.Lock()
+	s.keys = keys
+	s.signing = signing
+	s.mutex.Unlock()
+
+	return nil
+}
+
+// Start reloads the keys with the given interval until Stop is called
+func (s *KeySet) Start(interval time.Duration) {
+	s.stop = make(chan struct{})
+	s.done = make(chan struct{})
+
+	go func() {
+		defer close(s.done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-s.stop:
+				return
+			case <-ticker.C:
+				if err := s.Reload(); err != nil {
+					zap.L().Error("failed to reload jwt keys", zap.Error(err))
+				}
+			}
+		}
+	}()
+}
+
+func (s *KeySet) Stop() {
+	if s.stop == nil {
+		return
+	}
+
+	close(s.stop)
+	<-s.done
+}
+
+func (s *KeySet) signingKey() *signingKey {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return s.signing
+}
+
+func (s *KeySet) verificationKey(kid string) (*signingKey, bool) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	key, ok := s.keys[kid]
+	return key, ok
+}
+
+// verificationKeys returns all verification keys sorted by kid
+func (s *KeySet) verificationKeys() []*signingKey {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	keys := make([]*signingKey, 0, len(s.keys))
+	for _, key := range s.keys {
+		keys = append(keys, key)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].ID < keys[j].ID
+	})
+
+	return keys
+}
+
+func readKey(path string) (*signingKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, errors.New("no pem block found")
+	}
+
+	name := filepath.Base(path)
+	if strings.HasSuffix(name, publicKeyExt) {
+		public, err := x509.ParsePKIXPublicKey(block.Bytes)
+		if err != nil {
+			return nil, err
+		}
+
+		return newSigningKey(strings.TrimSuffix(name, publicKeyExt), public, nil)
+	}
+
+	private, err := parsePrivateKey(block)
+	if err != nil {
+		return nil, err
+	}
+
+	return newSigningKey(strings.TrimSuffix(name, privateKeyExt), private.Public(), private)
+}
+
+func parsePrivateKey(block *pem.Block) (crypto.Signer, error) {
+	if block.Type == "RSA PRIVATE KEY" {
+		return x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+
+	private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := private.(crypto.Signer)
+	if !ok {
+		return nil, errors.New("unsupported private key type")
+	}
+
+	return signer, nil
+}
+
+func newSigningKey(kid string, public crypto.PublicKey, private crypto.Signer) (*signingKey, error) {
+	if kid == "" {
+		return nil, errors.New("empty key id")
+	}
+
+	key := &signingKey{
+		ID:      kid,
+		Private: private,
+		Public:  public,
+	}
+
+	switch public := public.(type) {
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	case *rsa.PublicKey:
+		if public.N.BitLen() < 2048 {
+			return nil, errors.New("rsa key must be at least 2048 bits")
+		}
+		key.Method = jwt.SigningMethodRS256
+	default:
+		return nil, fmt.Errorf("unsupported public key type %T", public)
+	}
+
+	return key, nil
+}
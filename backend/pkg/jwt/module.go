@@ -1,9 +1,35 @@
 package auth
 
-import "go.uber.org/fx"
+import (
+	"context"
+	"duels-api/config"
+
+	"go.uber.org/fx"
+)
 
 func Module() fx.Option {
 	return fx.Module("jwt",
-		fx.Provide(NewJWTAuth),
+		fx.Provide(
+			func(lc fx.Lifecycle, c *config.Config) (*KeySet, error) {
+				keys, err := NewKeySet(c.Auth.KeysDir)
+				if err != nil {
+					return nil, err
+				}
+
+				lc.Append(fx.Hook{
+					OnStart: func(ctx context.Context) error {
+						keys.Start(c.Auth.KeysReloadInterval)
+						return nil
+					},
+					OnStop: func(ctx context.Context) error {
+						keys.Stop()
+						return nil
+					},
+				})
+
+				return keys, nil
+			},
+			NewJWTAuth,
+		),
 	)
 }
@@ -46,6 +46,11 @@ type Config struct {
 	//
 	// Default: 1 * time.Minute
 	Expiration time.Duration
+
+	// Storage keeps the counters, use a shared storage when running more than one replica
+	//
+	// Default: process-local memory storage
+	Storage Storage
 }
 
 // ConfigDefault is the default config
@@ -61,20 +66,20 @@ var ConfigDefault = Config{
 }
 
 func configDefault(config ...Config) Config {
-	// Return default config if nothing provided
-	if len(config) < 1 {
-		return ConfigDefault
-	}
+	// Use default config if nothing provided
+	cfg := ConfigDefault
 
 	// Override default config
-	cfg := config[0]
+	if len(config) > 0 {
+		cfg = config[0]
+	}
 
 	// Set default values
 	if cfg.Next == nil {
 		cfg.Next = ConfigDefault.Next
 	}
 	if cfg.Skip == nil {
-		cfg.Next = ConfigDefault.Next
+		cfg.Skip = ConfigDefault.Skip
 	}
 	if cfg.Max <= 0 {
 		cfg.Max = ConfigDefault.Max
@@ -88,6 +93,9 @@ func configDefault(config ...Config) Config {
 	if cfg.LimitReached == nil {
 		cfg.LimitReached = ConfigDefault.LimitReached
 	}
+	if cfg.Storage == nil {
+		cfg.Storage = NewMemoryStorage()
+	}
 	if cfg.MaxFunc == nil {
 		cfg.MaxFunc = func(_ fiber.Ctx) int {
 			return cfg.Max
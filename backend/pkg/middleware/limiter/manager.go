@@ -1,8 +1,9 @@
 package limiter
 
 import (
+	"context"
 	"duels-api/pkg/middleware/limiter/memory"
-	"github.com/gofiber/fiber/v3"
+	"github.com/gofiber/utils/v2"
 	"sync"
 	"time"
 )
@@ -13,11 +14,13 @@ type reqData struct {
 	exp      uint64
 }
 
+// manager is the process-local Storage, limits are not shared between replicas
+//
 //msgp:ignore manager
 type manager struct {
-	pool    sync.Pool
-	memory  *memory.Storage
-	storage fiber.Storage
+	mux    sync.Mutex
+	pool   sync.Pool
+	memory *memory.Storage
 }
 
 func newManager() *manager {
@@ -32,9 +35,90 @@ func newManager() *manager {
 
 	manager.memory = memory.New(5 * time.Second)
 
+	// Update timestamp every second
+	utils.StartTimeStampUpdater()
+
 	return manager
 }
 
+// NewMemoryStorage returns the process-local storage used by default
+func NewMemoryStorage() Storage {
+	return newManager()
+}
+
+func (m *manager) Hit(_ context.Context, key string, expiration uint64) (int, uint64, error) {
+	// Lock entry
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	// Get entry from pool and release when finished
+	e := m.get(key)
+
+	// Get timestamp
+	ts := uint64(utils.Timestamp())
+
+	// Set expiration if entry does not exist
+	if e.exp == 0 {
+		e.exp = ts + expiration
+	} else if ts >= e.exp {
+		// The entry has expired, handle the expiration.
+		// Set the prevHits to the current hits and reset the hits to 0.
+		e.prevHits = e.currHits
+
+		// Reset the current hits to 0.
+		e.currHits = 0
+
+		// Check how much into the current window it currently is and sets the
+		// expiry based on that, otherwise this would only reset on
+		// the next request and not show the correct expiry.
+		elapsed := ts - e.exp
+		if elapsed >= expiration {
+			e.exp = ts + expiration
+		} else {
+			e.exp = ts + expiration - elapsed
+		}
+	}
+
+	// Increment hits
+	e.currHits++
+
+	// Calculate when it resets in seconds
+	resetInSec := e.exp - ts
+
+	// weight = time until current window reset / total window length
+	weight := float64(resetInSec) / float64(expiration)
+
+	// rate = request count in previous window - weight + request count in current window
+	rate := int(float64(e.prevHits)*weight) + e.currHits
+
+	// Update storage. Garbage collect when the next window ends.
+	// |--------------------------|--------------------------|
+	//               ^            ^               ^          ^
+	//              ts         e.exp   End sample window   End next window
+	//               <------------>
+	// 				   Reset In Sec
+	// resetInSec = e.exp - ts - time until end of current window.
+	// duration + expiration = end of next window.
+	// Because we don't want to garbage collect in the middle of a window
+	// we add the expiration to the duration.
+	// Otherwise after the end of "sample window", attackers could launch
+	// a new request with the full window length.
+	m.set(key, e, time.Duration(resetInSec+expiration)*time.Second) //nolint:gosec // Not a concern
+
+	return rate, resetInSec, nil
+}
+
+func (m *manager) Undo(_ context.Context, key string, expiration uint64) error {
+	m.mux.Lock()
+	defer m.mux.Unlock()
+
+	e := m.get(key)
+	e.currHits--
+	m.set(key, e, time.Duration(expiration)*time.Second)
+
+	return nil
+}
+
 // acquire returns an *entry from the sync.Pool
 func (m *manager) acquire() *reqData {
 	return m.pool.Get().(*reqData) //nolint:forcetypeassert,errcheck // We store nothing else in the pool
@@ -48,7 +132,7 @@ func (m *manager) release(e *reqData) {
 	m.pool.Put(e)
 }
 
-// get data from storage or memory
+// get data from memory
 func (m *manager) get(key string) *reqData {
 	var it *reqData
 
@@ -60,7 +144,7 @@ func (m *manager) get(key string) *reqData {
 	return it
 }
 
-// set data to storage or memory
+// set data to memory
 func (m *manager) set(key string, it *reqData, exp time.Duration) {
 	m.memory.Set(key, it, exp)
 }
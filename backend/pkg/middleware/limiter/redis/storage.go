@@ -0,0 +1,82 @@
+package redis
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// hitScript applies the sliding window of the limiter atomically, the time is taken
+// from the redis server, so replicas with skewed clocks share the same windows.
+//
+// KEYS[1] - counters hash, ARGV[1] - window length in seconds
+// returns {rate, seconds until the current window resets}
+var hitScript = redis.NewScript(`
+local expiration = tonumber(ARGV[1])
+local now = tonumber(redis.call('TIME')[1])
+
+local data = redis.call('HMGET', KEYS[1], 'curr', 'prev', 'exp')
+local curr = tonumber(data[1]) or 0
+local prev = tonumber(data[2]) or 0
+local exp = tonumber(data[3]) or 0
+
+if exp == 0 then
+	exp = now + expiration
+elseif now >= exp then
+	prev = curr
+	curr = 0
+
+	local elapsed = now - exp
+	if elapsed >= expiration then
+		exp = now + expiration
+	else
+		exp = now + expiration - elapsed
+	end
+end
+
+curr = curr + 1
+
+local resetIn = exp - now
+local rate = math.floor(prev * resetIn / expiration) + curr
+
+redis.call('HSET', KEYS[1], 'curr', curr, 'prev', prev, 'exp', exp)
+redis.call('EXPIRE', KEYS[1], resetIn + expiration)
+
+return {rate, resetIn}
+`)
+
+// undoScript does not recreate expired counters
+var undoScript = redis.NewScript(`
+if redis.call('EXISTS', KEYS[1]) == 1 then
+	return redis.call('HINCRBY', KEYS[1], 'curr', -1)
+end
+return 0
+`)
+
+// Storage keeps limiter counters in redis, so the limits hold across replicas
+type Storage struct {
+	client *redis.Client
+	prefix string
+}
+
+func New(client *redis.Client, prefix string) *Storage {
+	return &Storage{
+		client: client,
+		prefix: prefix,
+	}
+}
+
+func (s *Storage) Hit(ctx context.Context, key string, expiration uint64) (int, uint64, error) {
+	res, err := hitScript.Run(ctx, s.client, []string{s.prefix + key}, strconv.FormatUint(expiration, 10)).
+		Int64Slice()
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return int(res[0]), uint64(res[1]), nil
+}
+
+func (s *Storage) Undo(ctx context.Context, key string, _ uint64) error {
+	return undoScript.Run(ctx, s.client, []string{s.prefix + key}).Err()
+}
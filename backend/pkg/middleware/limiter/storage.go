@@ -0,0 +1,14 @@
+package limiter
+
+import "context"
+
+// Storage keeps sliding window counters, implementations must be safe for concurrent use.
+// Expiration is the window length in seconds.
+type Storage interface {
+	// Hit counts a request for the key and returns the weighted rate
+	// of the sliding window and seconds until the current window resets
+	Hit(ctx context.Context, key string, expiration uint64) (rate int, resetIn uint64, err error)
+
+	// Undo removes a counted request of the current window
+	Undo(ctx context.Context, key string, expiration uint64) error
+}
@@ -0,0 +1,155 @@
+package sigtracker
+
+import (
+	"context"
+	"time"
+
+	solanalib "github.com/gagliardetto/solana-go"
+	"go.uber.org/zap"
+)
+
+// Max amount of signatures accepted by a single getSignatureStatuses call
+const maxSignaturesPerRequest = 256
+
+type pollEntry struct {
+	registeredAt time.Time
+	deadline     time.Time
+	callbacks    []func(Status)
+}
+
+// Watch registers the signature for batched status polling
+// The returned channel receives exactly one final Status
+func (t *TxTracker) Watch(signature solanalib.Signature, timeout time.Duration) <-chan Status {
+	statusCh := make(chan Status, 1) // buffer prevents poller from blocking on abandoned channels
+	t.WatchFunc(signature, timeout, func(status Status) {
+		statusCh <- status
+	})
+
+	return statusCh
+}
+
+// WatchFunc registers the signature for batched status polling
+// The callback is invoked exactly once, in its own goroutine, with the final Status
+// A closed tracker does not register the signature and reports it as aborted
+func (t *TxTracker) WatchFunc(signature solanalib.Signature, timeout time.Duration, callback func(Status)) {
+	now := time.Now()
+	deadline := now.Add(timeout)
+
+	t.polledMutex.Lock()
+	// Close cancels the context before it drains the watchers, so nothing registered here is left behind
+	if t.ctx.Err() != nil {
+		t.polledMutex.Unlock()
+		go callback(Status{Signature: signature, State: StateAborted})
+		return
+	}
+
+	entry, exists := t.polled[signature]
+	if !exists {
+		entry = &pollEntry{registeredAt: now}
+		t.polled[signature] = entry
+	}
+	// a later watcher must not shorten tracking for the earlier ones
+	if deadline.After(entry.deadline) {
+		entry.deadline = deadline
+	}
+	entry.callbacks = append(entry.callbacks, callback)
+	t.polledMutex.Unlock()
+
+	zap.L().Debug("signature watch registered",
+		zap.String("signature", signature.String()),
+		zap.Duration("timeout", timeout),
+	)
+}
+
+// Background worker — polls statuses of all watched signatures
+func (t *TxTracker) runPoller() {
+	ticker := time.NewTicker(t.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-t.ctx.Done():
+			zap.L().Info("TxTracker poller stopped")
+			return
+		case <-ticker.C:
+			t.pollOnce()
+		}
+	}
+}
+
+// Query statuses of watched signatures, up to maxSignaturesPerRequest per RPC call
+func (t *TxTracker) pollOnce() {
+	now := time.Now()
+
+	t.polledMutex.Lock()
+	signatures := make([]solanalib.Signature, 0, len(t.polled))
+	expired := make([]solanalib.Signature, 0)
+	registeredAt := make(map[solanalib.Signature]time.Time, len(t.polled))
+	for signature, entry := range t.polled {
+		registeredAt[signature] = entry.registeredAt
+		if now.After(entry.deadline) {
+			expired = append(expired, signature)
+			continue
+		}
+		signatures = append(signatures, signature)
+	}
+	t.polledMutex.Unlock()
+
+	for _, signature := range expired {
+		t.resolvePolled(Status{
+			Signature: signature,
+			State:     StateExpired,
+			Latency:   now.Sub(registeredAt[signature]),
+		})
+	}
+
+	for start := 0; start < len(signatures); start += maxSignaturesPerRequest {
+		batch := signatures[start:min(start+maxSignaturesPerRequest, len(signatures))]
+
+		ctx, cancel := context.WithTimeout(t.ctx, t.rpcTimeout)
+		statuses, err := t.rpcClient.GetSignatureStatuses(ctx, true, batch...)
+		cancel()
+
+		if err != nil {
+			zap.L().Error("GetSignatureStatuses failed in poller",
+				zap.Int("batch_size", len(batch)),
+				zap.Error(err),
+			)
+			continue
+		}
+
+		for i, result := range statuses.Value {
+			if i >= len(batch) {
+				break
+			}
+
+			status, final := statusFromResult(batch[i], result, t.minCommitment, registeredAt[batch[i]])
+			if final {
+				t.resolvePolled(status)
+			}
+		}
+	}
+}
+
+// Deliver the final status to all watchers and stop polling the signature
+func (t *TxTracker) resolvePolled(status Status) {
+	t.polledMutex.Lock()
+	entry := t.polled[status.Signature]
+	delete(t.polled, status.Signature)
+	t.polledMutex.Unlock()
+
+	if entry == nil {
+		return
+	}
+
+	for _, callback := range entry.callbacks {
+		go callback(status)
+	}
+
+	zap.L().Info("watched signature resolved",
+		zap.String("signature", status.Signature.String()),
+		zap.String("state", status.State.String()),
+		zap.Uint64("slot", status.Slot),
+		zap.Duration("latency", status.Latency),
+	)
+}
@@ -0,0 +1,244 @@
+package sigtracker
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	solanalib "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// fakeRPC records the batches it is asked for and reports every signature as pending
+// unless a result is set for it
+type fakeRPC struct {
+	mu      sync.Mutex
+	batches []int
+	results map[solanalib.Signature]*rpc.SignatureStatusesResult
+}
+
+func (f *fakeRPC) GetSignatureStatuses(
+	_ context.Context,
+	_ bool,
+	signatures ...solanalib.Signature,
+) (*rpc.GetSignatureStatusesResult, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	f.batches = append(f.batches, len(signatures))
+
+	value := make([]*rpc.SignatureStatusesResult, len(signatures))
+	for i, signature := range signatures {
+		value[i] = f.results[signature]
+	}
+
+	return &rpc.GetSignatureStatusesResult{Value: value}, nil
+}
+
+func newTestTracker(client rpcClientInterface) *TxTracker {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &TxTracker{
+		rpcClient:     client,
+		entries:       make(map[solanalib.Signature]*signatureEntry),
+		polled:        make(map[solanalib.Signature]*pollEntry),
+		minCommitment: rpc.CommitmentConfirmed,
+		rpcTimeout:    time.Second,
+		ctx:           ctx,
+		cancel:        cancel,
+	}
+}
+
+func testSignature(n int) solanalib.Signature {
+	var signature solanalib.Signature
+	signature[0] = byte(n)
+	signature[1] = byte(n >> 8)
+	return signature
+}
+
+// statusRecorder counts the statuses delivered to a watcher
+type statusRecorder struct {
+	mu       sync.Mutex
+	statuses []Status
+}
+
+func (r *statusRecorder) callback(status Status) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.statuses = append(r.statuses, status)
+}
+
+func (r *statusRecorder) wait(t *testing.T, count int) []Status {
+	t.Helper()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		r.mu.Lock()
+		statuses := append([]Status(nil), r.statuses...)
+		r.mu.Unlock()
+
+		if len(statuses) >= count || time.Now().After(deadline) {
+			// late duplicates would arrive in their own goroutines
+			time.Sleep(20 * time.Millisecond)
+			r.mu.Lock()
+			statuses = append([]Status(nil), r.statuses...)
+			r.mu.Unlock()
+			return statuses
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestPollOnceBatches(t *testing.T) {
+	tests := []struct {
+		name       string
+		signatures int
+		want       []int
+	}{
+		{"nothing watched", 0, nil},
+		{"single batch", 3, []int{3}},
+		{"full batch", maxSignaturesPerRequest, []int{maxSignaturesPerRequest}},
+		{"split batches", maxSignaturesPerRequest + 44, []int{maxSignaturesPerRequest, 44}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeRPC{}
+			tracker := newTestTracker(client)
+
+			for i := 0; i < tt.signatures; i++ {
+				tracker.WatchFunc(testSignature(i), time.Hour, func(Status) {})
+			}
+
+			tracker.pollOnce()
+
+			if len(client.batches) != len(tt.want) {
+				t.Fatalf("batches = %v, want %v", client.batches, tt.want)
+			}
+			for i := range tt.want {
+				if client.batches[i] != tt.want[i] {
+					t.Errorf("batches = %v, want %v", client.batches, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWatchFuncDeadline(t *testing.T) {
+	tests := []struct {
+		name     string
+		timeouts []time.Duration
+		want     State
+	}{
+		{"expires after timeout", []time.Duration{time.Millisecond}, StateExpired},
+		{"later watcher extends deadline", []time.Duration{time.Millisecond, time.Hour}, StatePending},
+		{"later watcher does not shorten deadline", []time.Duration{time.Hour, time.Millisecond}, StatePending},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tracker := newTestTracker(&fakeRPC{})
+			signature := testSignature(1)
+
+			recorder := &statusRecorder{}
+			for _, timeout := range tt.timeouts {
+				tracker.WatchFunc(signature, timeout, recorder.callback)
+			}
+
+			time.Sleep(5 * time.Millisecond)
+			tracker.pollOnce()
+
+			if tt.want == StatePending {
+				if statuses := recorder.wait(t, 0); len(statuses) != 0 {
+					t.Fatalf("got %d statuses, want none", len(statuses))
+				}
+				return
+			}
+
+			statuses := recorder.wait(t, len(tt.timeouts))
+			if len(statuses) != len(tt.timeouts) {
+				t.Fatalf("got %d statuses, want %d", len(statuses), len(tt.timeouts))
+			}
+			for _, status := range statuses {
+				if status.State != tt.want {
+					t.Errorf("state = %s, want %s", status.State, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestWatchFuncCallsBackOnce(t *testing.T) {
+	confirmed := &rpc.SignatureStatusesResult{ConfirmationStatus: rpc.ConfirmationStatusFinalized}
+
+	tests := []struct {
+		name     string
+		watchers int
+		resolve  func(tracker *TxTracker, client *fakeRPC, signature solanalib.Signature)
+		want     State
+	}{
+		{
+			"confirmed by poller",
+			2,
+			func(tracker *TxTracker, client *fakeRPC, signature solanalib.Signature) {
+				client.results = map[solanalib.Signature]*rpc.SignatureStatusesResult{signature: confirmed}
+				tracker.pollOnce()
+				tracker.pollOnce()
+			},
+			StateConfirmed,
+		},
+		{
+			"aborted by close",
+			2,
+			func(tracker *TxTracker, _ *fakeRPC, _ solanalib.Signature) {
+				_ = tracker.Close()
+				tracker.pollOnce()
+			},
+			StateAborted,
+		},
+		{
+			"watched after close",
+			0,
+			func(tracker *TxTracker, _ *fakeRPC, _ solanalib.Signature) {
+				_ = tracker.Close()
+			},
+			StateAborted,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := &fakeRPC{}
+			tracker := newTestTracker(client)
+			signature := testSignature(1)
+
+			recorders := make([]*statusRecorder, tt.watchers)
+			for i := range recorders {
+				recorders[i] = &statusRecorder{}
+				tracker.WatchFunc(signature, time.Hour, recorders[i].callback)
+			}
+
+			tt.resolve(tracker, client, signature)
+
+			if tt.watchers == 0 {
+				recorder := &statusRecorder{}
+				tracker.WatchFunc(signature, time.Hour, recorder.callback)
+				recorders = append(recorders, recorder)
+
+				if len(tracker.polled) != 0 {
+					t.Errorf("closed tracker registered %d signatures", len(tracker.polled))
+				}
+			}
+
+			for i, recorder := range recorders {
+				statuses := recorder.wait(t, 1)
+				if len(statuses) != 1 {
+					t.Fatalf("watcher %d got %d statuses, want 1", i, len(statuses))
+				}
+				if statuses[0].State != tt.want {
+					t.Errorf("watcher %d state = %s, want %s", i, statuses[0].State, tt.want)
+				}
+			}
+		})
+	}
+}
@@ -29,6 +29,10 @@ type TxTracker struct {
 
 	pendingSignaturesCh chan solanalib.Signature
 
+	polled       map[solanalib.Signature]*pollEntry
+	polledMutex  sync.Mutex
+	pollInterval time.Duration
+
 	minCommitment rpc.CommitmentType
 	maxWSRetries  int
 
@@ -54,6 +58,8 @@ func NewTransactionTracker(rpcClient *solana.Client, wsURL string) *TxTracker {
 		wsURL:               wsURL,
 		entries:             make(map[solanalib.Signature]*signatureEntry),
 		pendingSignaturesCh: make(chan solanalib.Signature, 1024),
+		polled:              make(map[solanalib.Signature]*pollEntry),
+		pollInterval:        time.Second,
 		minCommitment:       rpc.CommitmentConfirmed,
 		maxWSRetries:        5,
 		maxRPCRetries:       3,
@@ -65,10 +71,11 @@ func NewTransactionTracker(rpcClient *solana.Client, wsURL string) *TxTracker {
 	}
 }
 
-// Start async manager that processes pending signatures
+// Start async manager that processes pending signatures and the batched status poller
 func (t *TxTracker) Start() {
 	zap.L().Info("TxTracker started", zap.String("wsURL", t.wsURL))
 	go t.runSubscriptionManager()
+	go t.runPoller()
 }
 
 // Gracefully stop all subscriptions and release resources
@@ -95,6 +102,22 @@ func (t *TxTracker) Close() error {
 	}
 	t.entriesMutex.Unlock()
 
+	// Notify all polled watchers that their signatures are left unresolved
+	t.polledMutex.Lock()
+	polled := t.polled
+	t.polled = make(map[solanalib.Signature]*pollEntry)
+	t.polledMutex.Unlock()
+
+	for signature, entry := range polled {
+		for _, callback := range entry.callbacks {
+			go callback(Status{
+				Signature: signature,
+				State:     StateAborted,
+				Latency:   time.Since(entry.registeredAt),
+			})
+		}
+	}
+
 	zap.L().Info("TxTracker closed")
 	return nil
 }
@@ -305,12 +328,10 @@ func (t *TxTracker) checkByRPC(signature solanalib.Signature) (bool, error) {
 			return false, nil
 		}
 
-		switch statuses.Value[0].ConfirmationStatus {
-		case rpc.ConfirmationStatusFinalized, rpc.ConfirmationStatusConfirmed:
+		if commitmentReached(statuses.Value[0].ConfirmationStatus, t.minCommitment) {
 			return true, nil
-		default:
-			return false, rpc.ErrNotConfirmed
 		}
+		return false, rpc.ErrNotConfirmed
 	}
 	return false, rpc.ErrNotConfirmed
 }
@@ -0,0 +1,90 @@
+package sigtracker
+
+import (
+	"time"
+
+	solanalib "github.com/gagliardetto/solana-go"
+	"github.com/gagliardetto/solana-go/rpc"
+)
+
+// State describes where a tracked signature ended up
+type State uint8
+
+const (
+	StatePending   State = iota // no final status observed yet
+	StateConfirmed              // reached the tracker's minimal commitment without an error
+	StateFailed                 // landed on-chain with an error
+	StateExpired                // timeout passed before a final status was observed
+	StateAborted                // tracker was closed while the signature was still pending
+)
+
+func (s State) String() string {
+	switch s {
+	case StateConfirmed:
+		return "confirmed"
+	case StateFailed:
+		return "failed"
+	case StateExpired:
+		return "expired"
+	case StateAborted:
+		return "aborted"
+	default:
+		return "pending"
+	}
+}
+
+// Status is the final result of tracking a single signature
+type Status struct {
+	Signature  solanalib.Signature
+	State      State
+	Slot       uint64
+	Commitment rpc.ConfirmationStatusType
+	Err        any           // on-chain transaction error, nil if the transaction succeeded
+	Latency    time.Duration // time between registration and the final status
+}
+
+func (s Status) Confirmed() bool {
+	return s.State == StateConfirmed
+}
+
+var commitmentRank = map[rpc.ConfirmationStatusType]int{
+	rpc.ConfirmationStatusProcessed: 1,
+	rpc.ConfirmationStatusConfirmed: 2,
+	rpc.ConfirmationStatusFinalized: 3,
+}
+
+// Reports whether the observed confirmation status satisfies the required commitment
+func commitmentReached(observed rpc.ConfirmationStatusType, required rpc.CommitmentType) bool {
+	return commitmentRank[observed] >= commitmentRank[rpc.ConfirmationStatusType(required)]
+}
+
+// Converts an RPC status into a final Status, returns false while the signature is still pending
+func statusFromResult(
+	signature solanalib.Signature,
+	result *rpc.SignatureStatusesResult,
+	required rpc.CommitmentType,
+	registeredAt time.Time,
+) (Status, bool) {
+	if result == nil {
+		return Status{}, false
+	}
+
+	status := Status{
+		Signature:  signature,
+		Slot:       result.Slot,
+		Commitment: result.ConfirmationStatus,
+		Err:        result.Err,
+		Latency:    time.Since(registeredAt),
+	}
+
+	switch {
+	case result.Err != nil:
+		status.State = StateFailed
+	case commitmentReached(result.ConfirmationStatus, required):
+		status.State = StateConfirmed
+	default:
+		return Status{}, false
+	}
+
+	return status, true
+}
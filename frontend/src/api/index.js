@@ -1,7 +1,8 @@
 import {apiInstance} from "./instance.js";
 
 // auth
-export const SignIn = async (address, secret) => apiInstance.post(`/auth/sign-in-wallet`,{ address, secret });
+export const GetSignInChallenge = async (address) => apiInstance.post(`/auth/challenge`, { address });
+export const SignIn = async (address, nonce, secret) => apiInstance.post(`/auth/sign-in-wallet`,{ address, nonce, secret });
 
 // user
 export const GetUser = async () => apiInstance.get(`/user`);
@@ -17,6 +18,8 @@ export const CreateDuel = async (duel, tx_hash) => apiInstance.post(`/crypto-due
 export const SignJoinDuel = async (duel_id, answer) => apiInstance.post(`/crypto-duel/solana/join/sign-tx`, { duel_id, answer });
 export const JoinDuel = async (duel_id, answer, tx_hash) => apiInstance.post(`/crypto-duel/solana/join`, { duel_id, answer, tx_hash });
 
+export const GetDuelOperation = async (id) => apiInstance.get(`/crypto-duel/solana/operations/${id}`);
+
 export const ResolveDuel = async (duel_id, answer) => apiInstance.put(`/crypto-duel/solana/resolve`, { duel_id, answer });
 
 export const GetDuels = async () => apiInstance.get(`/duel/all`, {
@@ -0,0 +1,29 @@
+import {GetDuelOperation} from "../api/index.js";
+
+const POLL_INTERVAL = 2000;
+const POLL_TIMEOUT = 2 * 60 * 1000;
+
+export class OperationError extends Error {}
+
+// waitForOperation polls an accepted create/join operation until the backend
+// has recorded it or reports a failure
+export const waitForOperation = async (operation) => {
+  const deadline = Date.now() + POLL_TIMEOUT;
+  let current = operation;
+
+  while (current?.stage !== 'recorded') {
+    if (current?.status === 'failed' || current?.stage === 'failed') {
+      throw new OperationError(current.error || 'Transaction failed');
+    }
+    if (Date.now() > deadline) {
+      throw new OperationError('Transaction is still pending, check the duel later');
+    }
+
+    await new Promise((resolve) => setTimeout(resolve, POLL_INTERVAL));
+
+    const resp = await GetDuelOperation(current.id);
+    current = resp.data;
+  }
+
+  return current;
+};
@@ -25,9 +25,9 @@ export const useUserStore = defineStore("userStore", () => {
     }
   }
 
-  const signIn = async (publicAddress, signedMessage) => {
+  const signIn = async (publicAddress, nonce, signedMessage) => {
     try {
-      const resp = await SignIn(publicAddress, signedMessage);
+      const resp = await SignIn(publicAddress, nonce, signedMessage);
 
       const { jwt_info, user } = resp.data;
 
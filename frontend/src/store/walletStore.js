@@ -4,6 +4,7 @@ import {Connection, PublicKey, Transaction} from "@solana/web3.js";
 import {PhantomWalletAdapter} from "@solana/wallet-adapter-phantom";
 import {useUserStore} from "./userStore.js";
 import {useNotificationStore} from "./notificationStore.js";
+import {GetSignInChallenge} from "../api/index.js";
 
 const programId = new PublicKey('TokenkegQfeZyiNwAJbNbGKPFXCWuBvf9Ss623VQ5DA')
 const associatedTokenProgramId = new PublicKey('ATokenGPvbdGVxr1b2hvZbsiqW5xWH25efTNsLJA8knL')
@@ -42,10 +43,11 @@ export const useWalletStore = defineStore("walletStore", () => {
       address.value = wallet.value?.publicKey ? wallet.value?.publicKey.toString() : '';
 
       if (isSignIn) {
-        const encodedMessage = new TextEncoder().encode(address.value);
+        const challenge = await GetSignInChallenge(address.value);
+        const encodedMessage = new TextEncoder().encode(challenge.data?.message);
         const signature = await wallet.value.signMessage(encodedMessage);
         const signedMessage = Buffer.from(signature).toString("base64");
-        await userStore.signIn(address.value, signedMessage);
+        await userStore.signIn(address.value, challenge.data?.nonce, signedMessage);
       }
     } catch (error) {
       console.log(error, 'error');